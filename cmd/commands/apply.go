@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/topology"
+	"github.com/davioliveeira/gohop/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Aplicar uma topologia declarada em YAML",
+	Long: `Lê filas, exchanges, bindings, policies e sistemas de retry de um arquivo
+YAML, compara com o vhost pela Management API e mostra o plano: o que será
+criado, alterado, recriado (propriedade imutável mudou) e removido.
+Depois da confirmação, aplica o plano. Filas com bloco retry: são
+configuradas com o mesmo setup de 'gohop retry setup'.
+
+Recursos que existem no vhost e não estão no arquivo só são removidos com
+--prune (exceto amq.*, filas exclusivas e componentes de retry das filas
+declaradas).
+
+Exemplo de topology.yaml:
+
+  vhost: /
+  exchanges:
+    - name: orders.events
+      type: topic
+  queues:
+    - name: orders
+      type: quorum
+      retry:
+        backoff: 5s,30s,5m
+  bindings:
+    - source: orders.events
+      destination: orders
+      routing_key: orders.*
+  policies:
+    - name: orders-limit
+      pattern: ^orders$
+      apply_to: queues
+      definition:
+        max-length: 100000
+
+Exemplos:
+  gohop apply -f topology.yaml --plan
+  gohop apply -f topology.yaml
+  gohop apply -f topology.yaml --prune --yes`,
+	RunE: runApply,
+}
+
+func init() {
+	applyCmd.Flags().StringP("file", "f", "", "Arquivo YAML com a topologia")
+	applyCmd.Flags().Bool("plan", false, "Apenas mostrar o plano, sem aplicar")
+	applyCmd.Flags().Bool("prune", false, "Remover recursos do vhost que não estão no arquivo")
+	applyCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	applyCmd.MarkFlagRequired("file")
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	planOnly, _ := cmd.Flags().GetBool("plan")
+	prune, _ := cmd.Flags().GetBool("prune")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	spec, err := topology.LoadSpec(file)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	// O vhost do arquivo tem precedência sobre o do perfil
+	if spec.VHost != "" {
+		cfg.RabbitMQ.VHost = spec.VHost
+	}
+	spec.VHost = cfg.RabbitMQ.VHost
+	if spec.VHost == "" {
+		spec.VHost = "/"
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	live, err := topology.LoadLiveState(mgmtClient, cfg.RabbitMQ, spec)
+	if err != nil {
+		return err
+	}
+
+	plan, err := topology.BuildPlan(spec, live, topology.PlanOptions{Prune: prune})
+	if err != nil {
+		return err
+	}
+
+	if planOnly && outputFmt == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+
+	fmt.Print(ui.SubMenuHeader("📐", "Aplicar Topologia", fmt.Sprintf("%s no vhost '%s'", file, spec.VHost)))
+
+	if plan.Empty() {
+		fmt.Println(ui.SubMenuDone("Nenhuma mudança: o vhost já está de acordo com o arquivo"))
+		return nil
+	}
+
+	printPlan(plan)
+
+	if blocked := plan.Blocked(); len(blocked) > 0 {
+		fmt.Println(ui.SubMenuSection("⛔", "Mudanças bloqueadas"))
+		for _, change := range blocked {
+			fmt.Println(ui.SubMenuError(fmt.Sprintf("%s %s: %s", change.Kind, change.Name, change.Blocked)))
+		}
+		fmt.Println()
+		return fmt.Errorf("%d mudança(s) bloqueada(s); o plano não pode ser aplicado", len(blocked))
+	}
+
+	if planOnly {
+		fmt.Println(ui.SubMenuHelp(fmt.Sprintf("Use 'gohop apply -f %s' para aplicar", file)))
+		return nil
+	}
+
+	if !skipConfirm {
+		description := "As mudanças serão aplicadas no vhost"
+		if plan.Count(topology.ActionRecreate)+plan.Count(topology.ActionDelete) > 0 {
+			description = "Recursos serão removidos ou recriados; bindings fora do arquivo podem ser perdidos"
+		}
+
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Aplicar o plano?").
+					Description(description).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	fmt.Println(ui.SubMenuLoading("Conectando ao RabbitMQ"))
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	fmt.Println(ui.SubMenuSection("⚙", "Aplicando"))
+	applied := 0
+	err = topology.Apply(client, mgmtClient, spec.VHost, plan, func(change topology.Change) {
+		applied++
+		fmt.Println(ui.SubMenuLoading(fmt.Sprintf("[%d/%d] %s %s %s", applied, len(plan.Changes), actionLabel(change.Action), change.Kind, change.Name)))
+	})
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao aplicar o plano"))
+		fmt.Println(ui.SubMenuHelp("As mudanças anteriores foram aplicadas; rode o comando de novo para ver o que falta"))
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone("Topologia aplicada com sucesso!"))
+	return nil
+}
+
+// printPlan mostra as mudanças do plano e o resumo por ação
+func printPlan(plan *topology.Plan) {
+	fmt.Println(ui.SubMenuSection("📋", "Plano"))
+	for _, change := range plan.Changes {
+		status := "success"
+		switch change.Action {
+		case topology.ActionUpdate:
+			status = "info"
+		case topology.ActionRecreate:
+			status = "warning"
+		case topology.ActionDelete:
+			status = "error"
+		}
+		fmt.Println("  " + ui.SubMenuStatus(fmt.Sprintf("%s %s %s", actionLabel(change.Action), change.Kind, change.Name), status))
+		if len(change.Details) > 0 {
+			fmt.Print(ui.SubMenuList(change.Details, "    ·"))
+		}
+	}
+	fmt.Println()
+
+	fmt.Print(ui.SubMenuKeyValue("Criar:", fmt.Sprintf("%d", plan.Count(topology.ActionCreate)), false))
+	fmt.Print(ui.SubMenuKeyValue("Alterar:", fmt.Sprintf("%d", plan.Count(topology.ActionUpdate)), false))
+	fmt.Print(ui.SubMenuKeyValue("Recriar:", fmt.Sprintf("%d", plan.Count(topology.ActionRecreate)), plan.Count(topology.ActionRecreate) > 0))
+	fmt.Print(ui.SubMenuKeyValue("Remover:", fmt.Sprintf("%d", plan.Count(topology.ActionDelete)), plan.Count(topology.ActionDelete) > 0))
+	fmt.Println()
+}
+
+// actionLabel descreve a ação de uma mudança
+func actionLabel(action topology.Action) string {
+	switch action {
+	case topology.ActionCreate:
+		return "criar"
+	case topology.ActionUpdate:
+		return "alterar"
+	case topology.ActionRecreate:
+		return "recriar"
+	case topology.ActionDelete:
+		return "remover"
+	}
+	return string(action)
+}
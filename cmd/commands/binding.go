@@ -0,0 +1,363 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var bindingCmd = &cobra.Command{
+	Use:   "binding",
+	Short: "Gerenciar bindings RabbitMQ",
+	Long:  "Comandos para criar, listar e remover bindings de exchanges para filas e outros exchanges",
+}
+
+var bindingAddCmd = &cobra.Command{
+	Use:   "add [origem] [destino]",
+	Short: "Criar um binding",
+	Long: `Liga um exchange (origem) a uma fila ou a outro exchange (destino).
+
+Em exchanges headers, use --arg para os headers e o x-match. Os valores são
+texto; para comparar com headers de outro tipo, informe o tipo na chave
+(chave:int=5, chave:bool=true ou chave:float=1.5).
+
+Exemplos:
+  gohop binding add orders.events orders --routing-key 'orders.*'
+  gohop binding add orders.events audit.events --destination-type exchange --routing-key '#'
+  gohop binding add orders.headers orders --arg x-match=all --arg type=order
+  gohop binding add orders.headers orders.vip --arg x-match=all --arg priority:int=5`,
+	Args: cobra.ExactArgs(2),
+	RunE: runBindingAdd,
+}
+
+var bindingListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar bindings",
+	Long: `Lista os bindings do vhost, filtrando por origem e/ou destino.
+
+Os bindings implícitos do default exchange só aparecem com --all.
+
+Exemplos:
+  gohop binding list
+  gohop binding list --source orders.events
+  gohop binding list --destination orders --all
+  gohop binding list --vhost /prod`,
+	RunE: runBindingList,
+}
+
+var bindingRemoveCmd = &cobra.Command{
+	Use:   "remove [origem] [destino]",
+	Short: "Remover um binding",
+	Long: `Remove o binding de origem para destino com a routing key e os argumentos informados.
+
+Exemplos:
+  gohop binding remove orders.events orders --routing-key 'orders.*'
+  gohop binding remove orders.headers orders --arg x-match=all --arg type=order`,
+	Args: cobra.ExactArgs(2),
+	RunE: runBindingRemove,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{bindingAddCmd, bindingRemoveCmd} {
+		cmd.Flags().StringP("routing-key", "k", "", "Routing key do binding")
+		cmd.Flags().String("destination-type", rabbitmq.DestinationQueue, "Tipo do destino (queue|exchange)")
+		cmd.Flags().StringArray("arg", nil, "Argumento do binding (chave=valor ou chave:tipo=valor, repetível)")
+	}
+	bindingRemoveCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+
+	bindingListCmd.Flags().String("source", "", "Filtrar pelo exchange de origem")
+	bindingListCmd.Flags().String("destination", "", "Filtrar pela fila ou exchange de destino")
+	bindingListCmd.Flags().String("vhost", "", "VHost a listar (padrão: o do perfil)")
+	bindingListCmd.Flags().Bool("all-vhosts", false, "Listar os bindings de todos os vhosts")
+	bindingListCmd.Flags().Bool("all", false, "Incluir os bindings do default exchange")
+
+	bindingCmd.AddCommand(bindingAddCmd)
+	bindingCmd.AddCommand(bindingListCmd)
+	bindingCmd.AddCommand(bindingRemoveCmd)
+}
+
+func runBindingAdd(cmd *cobra.Command, args []string) error {
+	opts, err := bindingOptionsFromFlags(cmd, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(ui.SubMenuHeader("🔗", "Criar Binding", fmt.Sprintf("Ligando '%s' a '%s'", opts.Source, opts.Destination)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+
+	if err := checkBindingEnds(mgmtClient, vhost, opts); err != nil {
+		return err
+	}
+
+	existing, err := mgmtClient.GetBindingsBetween(vhost, opts.Source, opts.Destination, opts.DestinationType)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar bindings: %w", err)
+	}
+	for _, b := range existing {
+		if opts.Matches(b) {
+			fmt.Println(ui.SubMenuInfo("Binding já existe"))
+			return nil
+		}
+	}
+
+	fmt.Println(ui.SubMenuLoading("Criando binding"))
+	if err := mgmtClient.CreateBinding(vhost, *opts); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao criar binding"))
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone("Binding criado com sucesso!"))
+	fmt.Println()
+	printBindingOptions(opts)
+	return nil
+}
+
+func runBindingList(cmd *cobra.Command, args []string) error {
+	source, _ := cmd.Flags().GetString("source")
+	destination, _ := cmd.Flags().GetString("destination")
+	vhost, _ := cmd.Flags().GetString("vhost")
+	allVHosts, _ := cmd.Flags().GetBool("all-vhosts")
+	includeDefault, _ := cmd.Flags().GetBool("all")
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	if vhost == "" {
+		vhost = cfg.RabbitMQ.VHost
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	var bindings []rabbitmq.BindingInfoManagement
+	if allVHosts {
+		bindings, err = mgmtClient.ListAllBindings()
+	} else {
+		bindings, err = mgmtClient.ListBindings(vhost)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao listar bindings: %w", err)
+	}
+
+	filter := rabbitmq.BindingFilter{Source: source, Destination: destination, IncludeDefault: includeDefault}
+	visible := filter.Apply(bindings)
+	sort.SliceStable(visible, func(i, j int) bool {
+		if visible[i].VHost != visible[j].VHost {
+			return visible[i].VHost < visible[j].VHost
+		}
+		return visible[i].Source < visible[j].Source
+	})
+
+	if outputFmt == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(visible)
+	}
+
+	description := fmt.Sprintf("Bindings do vhost '%s'", vhost)
+	if allVHosts {
+		description = "Bindings de todos os vhosts"
+	}
+	fmt.Print(ui.SubMenuHeader("🔗", "Listar Bindings", description))
+
+	if len(visible) == 0 {
+		fmt.Println(ui.SubMenuWarning("Nenhum binding encontrado"))
+		fmt.Println(ui.SubMenuHelp("Use 'gohop binding add <origem> <destino>' para criar um binding"))
+		return nil
+	}
+
+	headers := []string{"Origem", "Destino", "Tipo", "Routing Key", "Argumentos"}
+	if allVHosts {
+		headers = append([]string{"VHost"}, headers...)
+	}
+	var rows [][]string
+	for _, b := range visible {
+		row := []string{
+			truncateStr(exchangeLabel(b.Source), 30),
+			truncateStr(b.Destination, 30),
+			b.DestinationType,
+			truncateStr(routingKeyLabel(b.RoutingKey), 25),
+			truncateStr(bindingArgumentsLabel(b.Arguments), 30),
+		}
+		if allVHosts {
+			row = append([]string{b.VHost}, row...)
+		}
+		rows = append(rows, row)
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d binding(s) encontrado(s)", len(visible))))
+	fmt.Println()
+	fmt.Print(ui.SubMenuTable(headers, rows))
+	fmt.Println()
+	return nil
+}
+
+func runBindingRemove(cmd *cobra.Command, args []string) error {
+	opts, err := bindingOptionsFromFlags(cmd, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	fmt.Print(ui.SubMenuHeader("🗑️", "Remover Binding", fmt.Sprintf("Desligando '%s' de '%s'", opts.Source, opts.Destination)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+
+	existing, err := mgmtClient.GetBindingsBetween(vhost, opts.Source, opts.Destination, opts.DestinationType)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Binding não encontrado"))
+		return err
+	}
+
+	var target *rabbitmq.BindingInfoManagement
+	for i := range existing {
+		if opts.Matches(existing[i]) {
+			target = &existing[i]
+			break
+		}
+	}
+	if target == nil {
+		fmt.Println(ui.SubMenuError("Binding não encontrado"))
+		if len(existing) > 0 {
+			fmt.Println()
+			fmt.Println(ui.SubMenuSection("🔗", "Bindings existentes entre origem e destino"))
+			fmt.Print(ui.SubMenuTable([]string{"Destino", "Tipo", "Routing Key", "Argumentos"}, bindingRows(existing, false)))
+		}
+		return fmt.Errorf("nenhum binding de '%s' para '%s' com routing key '%s' e esses argumentos", opts.Source, opts.Destination, opts.RoutingKey)
+	}
+
+	fmt.Println(ui.SubMenuSection("📋", "Binding"))
+	printBindingOptions(opts)
+	fmt.Println()
+
+	if !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Confirmar remoção?").
+					Description("Mensagens deixarão de ser roteadas por este binding").
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	fmt.Println(ui.SubMenuLoading("Removendo binding"))
+	if err := mgmtClient.DeleteBinding(vhost, *target); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao remover binding"))
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone("Binding removido com sucesso!"))
+	return nil
+}
+
+// bindingOptionsFromFlags monta o binding a partir dos argumentos e das flags
+func bindingOptionsFromFlags(cmd *cobra.Command, source, destination string) (*rabbitmq.BindingOptions, error) {
+	routingKey, _ := cmd.Flags().GetString("routing-key")
+	destinationType, _ := cmd.Flags().GetString("destination-type")
+	argValues, _ := cmd.Flags().GetStringArray("arg")
+
+	arguments, err := rabbitmq.ParseBindingArguments(argValues)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &rabbitmq.BindingOptions{
+		Source:          source,
+		Destination:     destination,
+		DestinationType: destinationType,
+		RoutingKey:      routingKey,
+		Arguments:       arguments,
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// checkBindingEnds verifica se a origem e o destino existem
+func checkBindingEnds(mgmt *rabbitmq.ManagementClient, vhost string, opts *rabbitmq.BindingOptions) error {
+	if _, err := mgmt.GetExchange(vhost, opts.Source); err != nil {
+		fmt.Println(ui.SubMenuError("Exchange de origem não encontrado"))
+		return fmt.Errorf("exchange não encontrado: %s", opts.Source)
+	}
+
+	if opts.DestinationType == rabbitmq.DestinationExchange {
+		if _, err := mgmt.GetExchange(vhost, opts.Destination); err != nil {
+			fmt.Println(ui.SubMenuError("Exchange de destino não encontrado"))
+			return fmt.Errorf("exchange não encontrado: %s", opts.Destination)
+		}
+		return nil
+	}
+
+	if _, err := mgmt.GetQueue(vhost, opts.Destination); err != nil {
+		fmt.Println(ui.SubMenuError("Fila de destino não encontrada"))
+		return fmt.Errorf("fila não encontrada: %s", opts.Destination)
+	}
+	return nil
+}
+
+func printBindingOptions(opts *rabbitmq.BindingOptions) {
+	fmt.Print(ui.SubMenuKeyValue("Origem:", opts.Source, true))
+	fmt.Print(ui.SubMenuKeyValue("Destino:", fmt.Sprintf("%s (%s)", opts.Destination, opts.DestinationType), true))
+	fmt.Print(ui.SubMenuKeyValue("Routing key:", routingKeyLabel(opts.RoutingKey), false))
+	if len(opts.Arguments) > 0 {
+		fmt.Print(ui.SubMenuKeyValue("Argumentos:", bindingArgumentsLabel(opts.Arguments), false))
+	}
+}
+
+// exchangeLabel nomeia o default exchange, que tem nome vazio
+func exchangeLabel(name string) string {
+	if name == "" {
+		return "(default)"
+	}
+	return name
+}
+
+// routingKeyLabel formata routing keys vazias para tabelas
+func routingKeyLabel(routingKey string) string {
+	if routingKey == "" {
+		return "-"
+	}
+	return routingKey
+}
+
+// bindingArgumentsLabel formata os argumentos de um binding para tabelas
+func bindingArgumentsLabel(arguments map[string]interface{}) string {
+	if len(arguments) == 0 {
+		return "-"
+	}
+	return formatHeaderValue(arguments)
+}
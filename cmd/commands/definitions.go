@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var definitionsCmd = &cobra.Command{
+	Use:   "definitions",
+	Short: "Exportar e importar definições do RabbitMQ",
+	Long: `Comandos para o formato de definições do RabbitMQ (/api/definitions), usado
+para backup e migração: usuários, vhosts, permissões, parâmetros, policies,
+filas, exchanges e bindings. Mensagens não fazem parte das definições.`,
+}
+
+var definitionsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exportar definições do broker ou de um vhost",
+	Long: `Exporta as definições do broker inteiro ou, com --vhost, de um vhost.
+
+--pattern (regex) mantém apenas as filas, exchanges, policies e parâmetros
+cujo nome casa, e os bindings entre eles.
+
+Sem --file, o JSON vai para a saída padrão.
+
+Exemplos:
+  gohop definitions export -f backup.json
+  gohop definitions export --vhost /prod --pattern '^orders' -f orders.json
+  gohop definitions export --strip-passwords > definitions.json`,
+	RunE: runDefinitionsExport,
+}
+
+var definitionsImportCmd = &cobra.Command{
+	Use:   "import [arquivo...]",
+	Short: "Importar definições de um ou mais arquivos",
+	Long: `Valida os arquivos, mostra o resumo e, depois da confirmação, envia as
+definições para o broker inteiro ou, com --vhost, para um vhost.
+
+Vários arquivos são mesclados em ordem: itens com a mesma identidade (ex:
+fila com o mesmo vhost e nome) são substituídos pelos do arquivo seguinte.
+O import cria ou atualiza itens; nada que já existe no broker é removido.
+
+Usuários sem senha (ex: exportados com --strip-passwords) são ignorados,
+junto com suas permissões. --allow-passwordless-users os importa sem senha:
+um usuário que já existe no broker perde a senha atual.
+
+Exemplos:
+  gohop definitions import backup.json --dry-run
+  gohop definitions import base.json orders.json --vhost /staging
+  gohop definitions import backup.json --pattern '^orders' --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDefinitionsImport,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{definitionsExportCmd, definitionsImportCmd} {
+		cmd.Flags().String("vhost", "", "VHost (padrão: broker inteiro)")
+		cmd.Flags().String("pattern", "", "Regex sobre o nome de filas, exchanges, policies e parâmetros")
+		cmd.Flags().Bool("strip-users", false, "Remover usuários e permissões")
+		cmd.Flags().Bool("strip-passwords", false, "Remover o hash de senha dos usuários (ignorados no import)")
+	}
+	definitionsExportCmd.Flags().StringP("file", "f", "", "Arquivo de saída (padrão: saída padrão)")
+	definitionsImportCmd.Flags().Bool("dry-run", false, "Apenas validar e resumir, sem enviar")
+	definitionsImportCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	definitionsImportCmd.Flags().Bool("allow-passwordless-users", false, "Importar usuários sem senha em vez de ignorá-los")
+
+	definitionsCmd.AddCommand(definitionsExportCmd)
+	definitionsCmd.AddCommand(definitionsImportCmd)
+}
+
+func runDefinitionsExport(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	vhost, _ := cmd.Flags().GetString("vhost")
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	defs, err := mgmtClient.GetDefinitions(vhost)
+	if err != nil {
+		return err
+	}
+	if err := transformDefinitions(cmd, defs); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(defs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar JSON: %w", err)
+	}
+	data = append(data, '\n')
+
+	if file == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	fmt.Print(ui.SubMenuHeader("💾", "Exportar Definições", definitionsScope(vhost)))
+
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao gravar o arquivo"))
+		return fmt.Errorf("erro ao gravar %s: %w", file, err)
+	}
+
+	printDefinitionsSummary(defs)
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Definições exportadas para %s", file)))
+	if len(defs.Users) > 0 {
+		fmt.Println(ui.SubMenuHelp("O arquivo contém usuários; use --strip-passwords ou --strip-users para compartilhá-lo"))
+	}
+	return nil
+}
+
+func runDefinitionsImport(cmd *cobra.Command, args []string) error {
+	vhost, _ := cmd.Flags().GetString("vhost")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	allowPasswordless, _ := cmd.Flags().GetBool("allow-passwordless-users")
+
+	var files []*rabbitmq.Definitions
+	for _, path := range args {
+		defs, err := rabbitmq.LoadDefinitions(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, defs)
+	}
+	defs := rabbitmq.MergeDefinitions(files...)
+	if err := transformDefinitions(cmd, defs); err != nil {
+		return err
+	}
+
+	fmt.Print(ui.SubMenuHeader("💾", "Importar Definições", fmt.Sprintf("%d arquivo(s) para %s", len(args), definitionsScope(vhost))))
+
+	if err := defs.Validate(vhost); err != nil {
+		fmt.Println(ui.SubMenuError("Definições inválidas"))
+		return err
+	}
+	if users := defs.PasswordlessUsers(); len(users) > 0 {
+		if allowPasswordless {
+			defs.AllowPasswordlessUsers()
+			fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Usuários importados sem senha (os existentes perdem a senha atual): %s", strings.Join(users, ", "))))
+		} else {
+			defs.DropPasswordlessUsers()
+			fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Usuários sem senha ignorados, com suas permissões: %s", strings.Join(users, ", "))))
+			fmt.Println(ui.SubMenuHelp("Use --allow-passwordless-users para importá-los sem senha"))
+		}
+		fmt.Println()
+	}
+	if defs.Empty() {
+		fmt.Println(ui.SubMenuWarning("Nenhuma definição para importar"))
+		return nil
+	}
+
+	printDefinitionsSummary(defs)
+	if version := defs.RabbitMQVersion; version != "" {
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("Exportado do RabbitMQ %s", version)))
+		fmt.Println()
+	}
+
+	if dryRun {
+		fmt.Println(ui.SubMenuDone("Definições válidas (dry-run: nada foi enviado)"))
+		return nil
+	}
+
+	if !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Importar as definições?").
+					Description(fmt.Sprintf("Itens existentes em %s serão atualizados", definitionsScope(vhost))).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	fmt.Println(ui.SubMenuLoading("Enviando definições"))
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	if err := mgmtClient.ImportDefinitions(vhost, defs); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao importar"))
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone("Definições importadas com sucesso!"))
+	return nil
+}
+
+// transformDefinitions aplica --pattern, --strip-users e --strip-passwords
+func transformDefinitions(cmd *cobra.Command, defs *rabbitmq.Definitions) error {
+	pattern, _ := cmd.Flags().GetString("pattern")
+	stripUsers, _ := cmd.Flags().GetBool("strip-users")
+	stripPasswords, _ := cmd.Flags().GetBool("strip-passwords")
+
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("--pattern inválido: %w", err)
+		}
+		defs.FilterByName(re)
+	}
+	if stripUsers {
+		defs.StripUsers()
+	}
+	if stripPasswords {
+		defs.StripPasswords()
+	}
+	return nil
+}
+
+// printDefinitionsSummary mostra o número de itens de cada seção
+func printDefinitionsSummary(defs *rabbitmq.Definitions) {
+	labels := map[string]string{
+		"users":             "Usuários:",
+		"vhosts":            "VHosts:",
+		"permissions":       "Permissões:",
+		"topic_permissions": "Permissões de tópico:",
+		"parameters":        "Parâmetros:",
+		"global_parameters": "Parâmetros globais:",
+		"policies":          "Policies:",
+		"queues":            "Filas:",
+		"exchanges":         "Exchanges:",
+		"bindings":          "Bindings:",
+	}
+
+	fmt.Println(ui.SubMenuSection("📋", "Resumo"))
+	for _, count := range defs.Counts() {
+		fmt.Print(ui.SubMenuKeyValue(labels[count.Section], fmt.Sprintf("%d", count.Count), false))
+	}
+	fmt.Println()
+}
+
+// definitionsScope descreve o alvo do export/import
+func definitionsScope(vhost string) string {
+	if vhost == "" {
+		return "o broker inteiro"
+	}
+	return fmt.Sprintf("o vhost '%s'", vhost)
+}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"github.com/davioliveeira/gohop/internal/ui"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/spf13/cobra"
+)
+
+var dlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Gerenciar Dead Letter Queues",
+	Long:  "Comandos para inspecionar e reprocessar mensagens das DLQs do sistema de retry",
+}
+
+var dlqReplayCmd = &cobra.Command{
+	Use:   "replay [queue-name]",
+	Short: "Reprocessar mensagens da DLQ",
+	Long: `Move mensagens de <fila>.dlq de volta para a fila principal (ou para um exchange).
+
+Cada mensagem é republicada com confirmação do broker antes de ser removida
+da DLQ. Mensagens fora do filtro permanecem na DLQ.
+
+Exemplos:
+  gohop dlq replay orders --max 100 --rate 50
+  gohop dlq replay orders --header tenant=acme --dry-run
+  gohop dlq replay orders --to-exchange events --routing-key orders.created`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDLQReplay,
+}
+
+var dlqBrowseCmd = &cobra.Command{
+	Use:   "browse [queue-name]",
+	Short: "Explorar mensagens da DLQ",
+	Long: `Abre um navegador interativo das mensagens em <fila>.dlq.
+
+Para cada mensagem mostra o motivo e a fila de origem de cada morte (x-death),
+os headers x-first-death-*, headers de erro da aplicação e um preview do body.
+As mensagens são apenas lidas e voltam para a DLQ.
+
+Teclas:
+  ↑/↓, j/k        - Navegar
+  r               - Recarregar
+  q, ESC          - Sair`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDLQBrowse,
+}
+
+func init() {
+	dlqReplayCmd.Flags().String("to-exchange", "", "Exchange de destino (padrão: fila principal)")
+	dlqReplayCmd.Flags().String("routing-key", "", "Routing key no exchange de destino (padrão: a da mensagem ao chegar na fila, lida do x-death)")
+	dlqReplayCmd.Flags().Int("max", 0, "Máximo de mensagens a reprocessar (0 = todas)")
+	dlqReplayCmd.Flags().Float64("rate", 0, "Limite de mensagens por segundo (0 = sem limite)")
+	dlqReplayCmd.Flags().Bool("keep-x-death", false, "Manter headers x-death (padrão: remover e zerar as tentativas)")
+	dlqReplayCmd.Flags().Bool("dry-run", false, "Apenas listar as mensagens que seriam reprocessadas")
+	dlqReplayCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	addMessageFilterFlags(dlqReplayCmd)
+
+	dlqCmd.AddCommand(dlqReplayCmd)
+	dlqCmd.AddCommand(dlqBrowseCmd)
+}
+
+func runDLQBrowse(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	if err := ui.RunDLQBrowser(args[0], cfg); err != nil {
+		return fmt.Errorf("erro ao executar navegador da DLQ: %w", err)
+	}
+	return nil
+}
+
+func runDLQReplay(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	dlqName := retry.DLQName(queueName)
+
+	fmt.Print(ui.SubMenuHeader("♻️", "Replay da DLQ", fmt.Sprintf("Reprocessando '%s'", dlqName)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	filter, err := messageFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	exchange, _ := cmd.Flags().GetString("to-exchange")
+	routingKey, _ := cmd.Flags().GetString("routing-key")
+	maxCount, _ := cmd.Flags().GetInt("max")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	keepXDeath, _ := cmd.Flags().GetBool("keep-x-death")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	dlq, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, dlqName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("DLQ não encontrada"))
+		return fmt.Errorf("DLQ não encontrada: %s", dlqName)
+	}
+
+	destination := fmt.Sprintf("fila '%s'", queueName)
+	if exchange != "" {
+		destination = fmt.Sprintf("exchange '%s'", exchange)
+		if routingKey != "" {
+			destination += fmt.Sprintf(" (routing key '%s')", routingKey)
+		}
+	}
+
+	fmt.Println(ui.SubMenuSection("📋", "Replay"))
+	fmt.Print(ui.SubMenuKeyValue("DLQ:", fmt.Sprintf("%s (%d msgs)", dlqName, dlq.MessagesReady), true))
+	fmt.Print(ui.SubMenuKeyValue("Destino:", destination, false))
+	if maxCount > 0 {
+		fmt.Print(ui.SubMenuKeyValue("Máximo:", fmt.Sprintf("%d msgs", maxCount), false))
+	}
+	if rate > 0 {
+		fmt.Print(ui.SubMenuKeyValue("Limite:", fmt.Sprintf("%.1f msgs/s", rate), false))
+	}
+	if !filter.IsEmpty() {
+		fmt.Print(ui.SubMenuKeyValue("Filtro:", "ativo", false))
+	}
+	xDeath := "remover"
+	if keepXDeath {
+		xDeath = "manter"
+	}
+	fmt.Print(ui.SubMenuKeyValue("x-death:", xDeath, false))
+	fmt.Println()
+
+	if dlq.MessagesReady == 0 {
+		fmt.Println(ui.SubMenuInfo("DLQ vazia, nada a reprocessar"))
+		return nil
+	}
+
+	if !dryRun && !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("♻️  Reprocessar mensagens?").
+					Description(fmt.Sprintf("Mensagens de '%s' serão enviadas para %s", dlqName, destination)).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := retry.ReplayOptions{
+		QueueName:  queueName,
+		Exchange:   exchange,
+		RoutingKey: routingKey,
+		Max:        maxCount,
+		Filter:     filter,
+		Rate:       rate,
+		KeepXDeath: keepXDeath,
+		DryRun:     dryRun,
+		OnMessage: func(d amqp.Delivery, result retry.ReplayResult) {
+			if dryRun {
+				fmt.Print(ui.SubMenuKeyValue(fmt.Sprintf("#%d", result.Matched), describeDelivery(d), false))
+				return
+			}
+			fmt.Printf("\r  ⏳ Reprocessadas: %d", result.Replayed)
+		},
+	}
+
+	if dryRun {
+		fmt.Println(ui.SubMenuSection("🔍", "Dry Run - Mensagens que seriam reprocessadas"))
+	}
+
+	result, err := retry.ReplayDLQ(ctx, client, opts)
+	if !dryRun && result != nil && result.Replayed > 0 {
+		fmt.Println()
+	}
+	if err != nil {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("Replay interrompido após %d mensagem(ns)", result.Replayed)))
+		return err
+	}
+
+	fmt.Println()
+	if dryRun {
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("%d de %d mensagem(ns) seriam reprocessadas (nada foi alterado)", result.Matched, result.Scanned)))
+		return nil
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) reprocessada(s) para %s %s", result.Replayed, destination, formatThroughput(result.Stats))))
+	printUserIdDropped(result.Stats.UserIdDropped)
+	if skipped := result.Scanned - result.Matched; skipped > 0 {
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("%d mensagem(ns) fora do filtro mantida(s) na DLQ", skipped)))
+	}
+
+	return nil
+}
+
+// describeDelivery resume uma mensagem em uma linha
+func describeDelivery(d amqp.Delivery) string {
+	id := d.MessageId
+	if id == "" {
+		id = "(sem message-id)"
+	}
+	reason, _ := d.Headers["x-first-death-reason"].(string)
+	if reason == "" {
+		reason = "-"
+	}
+	return fmt.Sprintf("%s │ %d bytes │ motivo: %s │ %s", id, len(d.Body), reason, truncateStr(string(d.Body), 40))
+}
@@ -0,0 +1,470 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var exchangeCmd = &cobra.Command{
+	Use:   "exchange",
+	Short: "Gerenciar exchanges RabbitMQ",
+	Long:  "Comandos para criar, listar, deletar e inspecionar exchanges",
+}
+
+var exchangeCreateCmd = &cobra.Command{
+	Use:   "create [nome]",
+	Short: "Criar um novo exchange",
+	Long: `Cria um exchange com tipo, durabilidade, alternate-exchange e argumentos.
+
+Sem o nome, abre um formulário interativo (requer terminal).
+
+Exemplos:
+  gohop exchange create orders.events --type topic
+  gohop exchange create orders.unrouted --type fanout
+  gohop exchange create orders --type direct --alternate-exchange orders.unrouted
+  gohop exchange create orders.delayed --type x-delayed-message --arg x-delayed-type=direct
+  gohop exchange create`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExchangeCreate,
+}
+
+var exchangeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar exchanges",
+	Long:  "Lista os exchanges do vhost (sem os criados pelo broker, a menos que --all seja usado)",
+	RunE:  runExchangeList,
+}
+
+var exchangeDeleteCmd = &cobra.Command{
+	Use:   "delete [nome]",
+	Short: "Deletar um exchange",
+	Long:  "Remove um exchange e todos os bindings em que ele é origem ou destino",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExchangeDelete,
+}
+
+var exchangeStatusCmd = &cobra.Command{
+	Use:   "status [nome]",
+	Short: "Status de um exchange",
+	Long:  "Mostra a configuração, as métricas e os bindings de um exchange",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExchangeStatus,
+}
+
+func init() {
+	exchangeCreateCmd.Flags().String("type", "direct", "Tipo do exchange (direct|topic|fanout|headers ou tipo de plugin)")
+	exchangeCreateCmd.Flags().Bool("durable", true, "Exchange durável")
+	exchangeCreateCmd.Flags().Bool("auto-delete", false, "Auto-deletar quando o último binding for removido")
+	exchangeCreateCmd.Flags().Bool("internal", false, "Aceitar publicações apenas de outros exchanges")
+	exchangeCreateCmd.Flags().String("alternate-exchange", "", "Exchange que recebe as mensagens sem rota")
+	exchangeCreateCmd.Flags().StringArray("arg", nil, "Argumento do exchange (chave=valor ou chave:tipo=valor, repetível)")
+	exchangeCreateCmd.Flags().Bool("dry-run", false, "Mostrar o que seria criado sem executar")
+
+	exchangeListCmd.Flags().Bool("all", false, "Incluir o default exchange e os amq.*")
+
+	exchangeDeleteCmd.Flags().Bool("if-unused", false, "Só deletar se não tiver bindings")
+	exchangeDeleteCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+
+	exchangeCmd.AddCommand(exchangeCreateCmd)
+	exchangeCmd.AddCommand(exchangeListCmd)
+	exchangeCmd.AddCommand(exchangeDeleteCmd)
+	exchangeCmd.AddCommand(exchangeStatusCmd)
+}
+
+func runExchangeCreate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	var opts *rabbitmq.CreateExchangeOptions
+	if len(args) == 0 {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("informe o nome do exchange (o formulário interativo requer um terminal)")
+		}
+		opts, err = ui.RunExchangeCreateForm(cfg)
+		if err != nil {
+			return err
+		}
+	} else {
+		opts, err = exchangeOptionsFromFlags(cmd, args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Print(ui.SubMenuHeader("🔀", "Criar Exchange", fmt.Sprintf("Criando exchange '%s'", opts.Name)))
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Println(ui.SubMenuSection("📋", "Dry Run - O que seria criado"))
+		printExchangeOptions(opts)
+		return nil
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	vhost := cfg.RabbitMQ.VHost
+
+	if err := validateExchangeType(mgmtClient, opts.Type); err != nil {
+		return err
+	}
+
+	if existing, err := mgmtClient.GetExchange(vhost, opts.Name); err == nil {
+		if !opts.Matches(*existing) {
+			fmt.Println(ui.SubMenuError("Exchange já existe com outra configuração"))
+			return fmt.Errorf("exchange '%s' já existe (tipo %s); remova-o com 'gohop exchange delete' para recriá-lo", opts.Name, existing.Type)
+		}
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("Exchange '%s' já existe com a mesma configuração", opts.Name)))
+		return nil
+	}
+
+	if opts.AlternateExchange != "" {
+		if _, err := mgmtClient.GetExchange(vhost, opts.AlternateExchange); err != nil {
+			fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Alternate exchange '%s' ainda não existe: mensagens sem rota serão descartadas até ele ser criado", opts.AlternateExchange)))
+		}
+	}
+
+	fmt.Println(ui.SubMenuLoading("Criando exchange"))
+	if err := mgmtClient.CreateExchange(vhost, *opts); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao criar exchange"))
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone("Exchange criado com sucesso!"))
+	fmt.Println()
+	fmt.Println(ui.SubMenuSection("📋", "Detalhes do Exchange"))
+	printExchangeOptions(opts)
+	return nil
+}
+
+func runExchangeList(cmd *cobra.Command, args []string) error {
+	showAll, _ := cmd.Flags().GetBool("all")
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	exchanges, err := mgmtClient.ListExchanges(cfg.RabbitMQ.VHost)
+	if err != nil {
+		return fmt.Errorf("erro ao listar exchanges: %w", err)
+	}
+
+	var visible []rabbitmq.ExchangeInfoManagement
+	for _, ex := range exchanges {
+		if showAll || !rabbitmq.IsBuiltinExchange(ex.Name) {
+			visible = append(visible, ex)
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool { return visible[i].Name < visible[j].Name })
+
+	if outputFmt == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(visible)
+	}
+
+	fmt.Print(ui.SubMenuHeader("🔀", "Listar Exchanges", "Exchanges disponíveis no RabbitMQ"))
+
+	if len(visible) == 0 {
+		fmt.Println(ui.SubMenuWarning("Nenhum exchange encontrado"))
+		fmt.Println(ui.SubMenuHelp("Use 'gohop exchange create <nome>' para criar um exchange"))
+		return nil
+	}
+
+	// Quantidade de bindings por exchange de origem
+	bindingCount := map[string]int{}
+	if bindings, err := mgmtClient.ListBindings(cfg.RabbitMQ.VHost); err == nil {
+		for _, b := range bindings {
+			bindingCount[b.Source]++
+		}
+	}
+
+	headers := []string{"Nome", "Tipo", "Durável", "Interno", "Alternate", "Bindings"}
+	var rows [][]string
+	for _, ex := range visible {
+		name := ex.Name
+		if name == "" {
+			name = "(default)"
+		}
+		alternate, _ := ex.Arguments["alternate-exchange"].(string)
+		if alternate == "" {
+			alternate = "-"
+		}
+		rows = append(rows, []string{
+			truncateStr(name, 35),
+			ex.Type,
+			boolLabel(ex.Durable),
+			boolLabel(ex.Internal),
+			truncateStr(alternate, 25),
+			strconv.Itoa(bindingCount[ex.Name]),
+		})
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d exchange(s) encontrado(s)", len(visible))))
+	fmt.Println()
+	fmt.Print(ui.SubMenuTable(headers, rows))
+	fmt.Println()
+	return nil
+}
+
+func runExchangeDelete(cmd *cobra.Command, args []string) error {
+	exchangeName := args[0]
+	ifUnused, _ := cmd.Flags().GetBool("if-unused")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	fmt.Print(ui.SubMenuHeader("🗑️", "Deletar Exchange", fmt.Sprintf("Removendo exchange '%s'", exchangeName)))
+
+	if rabbitmq.IsBuiltinExchange(exchangeName) {
+		return fmt.Errorf("o exchange '%s' é criado pelo broker e não pode ser removido", exchangeName)
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	exchange, err := mgmtClient.GetExchange(vhost, exchangeName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Exchange não encontrado"))
+		return fmt.Errorf("exchange não encontrado: %s", exchangeName)
+	}
+
+	source, _ := mgmtClient.GetExchangeBindings(vhost, exchangeName)
+	destination, _ := mgmtClient.GetExchangeDestinationBindings(vhost, exchangeName)
+
+	fmt.Println(ui.SubMenuSection("📊", "Informações do Exchange"))
+	fmt.Print(ui.SubMenuKeyValue("Nome:", exchange.Name, true))
+	fmt.Print(ui.SubMenuKeyValue("Tipo:", exchange.Type, false))
+	fmt.Print(ui.SubMenuKeyValue("Bindings de saída:", strconv.Itoa(len(source)), len(source) > 0))
+	fmt.Print(ui.SubMenuKeyValue("Bindings de entrada:", strconv.Itoa(len(destination)), len(destination) > 0))
+	fmt.Println()
+
+	if len(source)+len(destination) > 0 {
+		if ifUnused && len(source) > 0 {
+			fmt.Println(ui.SubMenuError("O exchange tem bindings"))
+			return fmt.Errorf("exchange '%s' em uso por %d binding(s) (--if-unused)", exchangeName, len(source))
+		}
+		fmt.Println(ui.SubMenuWarning("Os bindings do exchange também serão removidos!"))
+		fmt.Println()
+	}
+
+	if !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Confirmar exclusão?").
+					Description("Publicações neste exchange passarão a falhar").
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	fmt.Println(ui.SubMenuLoading("Deletando exchange"))
+	if err := mgmtClient.DeleteExchange(vhost, exchangeName, ifUnused); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao deletar exchange"))
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Exchange '%s' deletado com sucesso!", exchangeName)))
+	return nil
+}
+
+// exchangeStatus é a saída JSON de 'exchange status'
+type exchangeStatus struct {
+	Exchange  *rabbitmq.ExchangeInfoManagement `json:"exchange"`
+	Bindings  []rabbitmq.BindingInfoManagement `json:"bindings"`
+	BoundFrom []rabbitmq.BindingInfoManagement `json:"bound_from"`
+}
+
+func runExchangeStatus(cmd *cobra.Command, args []string) error {
+	exchangeName := args[0]
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	exchange, err := mgmtClient.GetExchange(vhost, exchangeName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Exchange não encontrado"))
+		return fmt.Errorf("exchange não encontrado: %s", exchangeName)
+	}
+
+	source, err := mgmtClient.GetExchangeBindings(vhost, exchangeName)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar bindings: %w", err)
+	}
+	destination, err := mgmtClient.GetExchangeDestinationBindings(vhost, exchangeName)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar bindings: %w", err)
+	}
+
+	if outputFmt == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exchangeStatus{Exchange: exchange, Bindings: source, BoundFrom: destination})
+	}
+
+	fmt.Print(ui.SubMenuHeader("📊", "Status do Exchange", fmt.Sprintf("Detalhes de '%s'", exchangeName)))
+
+	fmt.Println(ui.SubMenuSection("⚙", "Configuração"))
+	fmt.Print(ui.SubMenuKeyValue("Nome:", exchange.Name, true))
+	fmt.Print(ui.SubMenuKeyValue("Tipo:", exchange.Type, false))
+	fmt.Print(ui.SubMenuKeyValue("VHost:", exchange.VHost, false))
+	fmt.Print(ui.SubMenuKeyValue("Durável:", fmt.Sprintf("%v", exchange.Durable), false))
+	fmt.Print(ui.SubMenuKeyValue("Auto-delete:", fmt.Sprintf("%v", exchange.AutoDelete), false))
+	fmt.Print(ui.SubMenuKeyValue("Interno:", fmt.Sprintf("%v", exchange.Internal), false))
+	if len(exchange.Arguments) > 0 {
+		fmt.Println(ui.SubMenuSection("🔧", "Argumentos"))
+		keys := make([]string, 0, len(exchange.Arguments))
+		for k := range exchange.Arguments {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Print(ui.SubMenuKeyValue(k+":", formatHeaderValue(exchange.Arguments[k]), k == "alternate-exchange"))
+		}
+	}
+
+	if exchange.MessageStats.PublishIn > 0 || exchange.MessageStats.PublishOut > 0 {
+		fmt.Println(ui.SubMenuSection("📈", "Métricas"))
+		fmt.Print(ui.SubMenuKeyValue("Publicações recebidas:", strconv.Itoa(exchange.MessageStats.PublishIn), false))
+		fmt.Print(ui.SubMenuKeyValue("Publicações roteadas:", strconv.Itoa(exchange.MessageStats.PublishOut), false))
+	}
+
+	fmt.Println(ui.SubMenuSection("🔗", fmt.Sprintf("Bindings (%d)", len(source))))
+	if len(source) == 0 {
+		fmt.Println(ui.SubMenuWarning("Nenhum binding: mensagens publicadas neste exchange não têm rota"))
+	} else {
+		fmt.Print(ui.SubMenuTable([]string{"Destino", "Tipo", "Routing Key", "Argumentos"}, bindingRows(source, false)))
+	}
+
+	if len(destination) > 0 {
+		fmt.Println(ui.SubMenuSection("↩️", fmt.Sprintf("Recebe de (%d)", len(destination))))
+		fmt.Print(ui.SubMenuTable([]string{"Origem", "Tipo", "Routing Key", "Argumentos"}, bindingRows(destination, true)))
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// exchangeOptionsFromFlags monta as opções de criação a partir das flags
+func exchangeOptionsFromFlags(cmd *cobra.Command, name string) (*rabbitmq.CreateExchangeOptions, error) {
+	if rabbitmq.IsBuiltinExchange(name) {
+		return nil, fmt.Errorf("nome inválido '%s': o prefixo amq. é reservado", name)
+	}
+
+	exchangeType, _ := cmd.Flags().GetString("type")
+	durable, _ := cmd.Flags().GetBool("durable")
+	autoDelete, _ := cmd.Flags().GetBool("auto-delete")
+	internal, _ := cmd.Flags().GetBool("internal")
+	alternate, _ := cmd.Flags().GetString("alternate-exchange")
+	argValues, _ := cmd.Flags().GetStringArray("arg")
+
+	arguments, err := rabbitmq.ParseArguments(argValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rabbitmq.CreateExchangeOptions{
+		Name:              name,
+		Type:              exchangeType,
+		Durable:           durable,
+		AutoDelete:        autoDelete,
+		Internal:          internal,
+		AlternateExchange: alternate,
+		Arguments:         arguments,
+	}, nil
+}
+
+// validateExchangeType aceita os tipos nativos e os de plugins habilitados no broker
+func validateExchangeType(mgmt *rabbitmq.ManagementClient, exchangeType string) error {
+	for _, t := range rabbitmq.ExchangeTypes {
+		if t == exchangeType {
+			return nil
+		}
+	}
+
+	supported, err := mgmt.HasExchangeType(exchangeType)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar tipos de exchange: %w", err)
+	}
+	if !supported {
+		return fmt.Errorf("tipo de exchange não suportado pelo broker: %s (use direct, topic, fanout ou headers)", exchangeType)
+	}
+	return nil
+}
+
+func printExchangeOptions(opts *rabbitmq.CreateExchangeOptions) {
+	fmt.Print(ui.SubMenuKeyValue("Nome:", opts.Name, true))
+	fmt.Print(ui.SubMenuKeyValue("Tipo:", opts.Type, false))
+	fmt.Print(ui.SubMenuKeyValue("Durável:", fmt.Sprintf("%v", opts.Durable), false))
+	fmt.Print(ui.SubMenuKeyValue("Auto-delete:", fmt.Sprintf("%v", opts.AutoDelete), false))
+	fmt.Print(ui.SubMenuKeyValue("Interno:", fmt.Sprintf("%v", opts.Internal), false))
+	if opts.AlternateExchange != "" {
+		fmt.Print(ui.SubMenuKeyValue("Alternate exchange:", opts.AlternateExchange, false))
+	}
+	keys := make([]string, 0, len(opts.Arguments))
+	for k := range opts.Arguments {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Print(ui.SubMenuKeyValue(k+":", fmt.Sprint(opts.Arguments[k]), false))
+	}
+}
+
+// bindingRows formata bindings para tabela; incoming usa a origem em vez do destino
+func bindingRows(bindings []rabbitmq.BindingInfoManagement, incoming bool) [][]string {
+	rows := make([][]string, 0, len(bindings))
+	for _, b := range bindings {
+		name, kind := b.Destination, b.DestinationType
+		if incoming {
+			name, kind = exchangeLabel(b.Source), "exchange"
+		}
+		rows = append(rows, []string{
+			truncateStr(name, 35),
+			kind,
+			truncateStr(routingKeyLabel(b.RoutingKey), 30),
+			truncateStr(bindingArgumentsLabel(b.Arguments), 30),
+		})
+	}
+	return rows
+}
+
+// boolLabel formata booleanos para tabelas
+func boolLabel(b bool) string {
+	if b {
+		return "sim"
+	}
+	return "não"
+}
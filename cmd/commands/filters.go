@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/spf13/cobra"
+)
+
+// addMessageFilterFlags registra as flags de filtro de mensagens
+func addMessageFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("header", nil, "Filtrar por header (chave=valor, pode repetir)")
+	cmd.Flags().String("body-contains", "", "Filtrar mensagens cujo body contém o texto")
+	cmd.Flags().String("body-regex", "", "Filtrar mensagens cujo body casa com a regex")
+	cmd.Flags().String("jsonpath", "", "Filtrar bodies JSON por caminho (ex: '$.order.id==42')")
+	cmd.Flags().String("death-reason", "", "Filtrar pelo motivo do dead-letter (rejected|expired|maxlen|delivery_limit)")
+}
+
+// messageFilterFromFlags monta o filtro a partir das flags registradas por addMessageFilterFlags
+func messageFilterFromFlags(cmd *cobra.Command) (rabbitmq.MessageFilter, error) {
+	var filter rabbitmq.MessageFilter
+
+	headerValues, _ := cmd.Flags().GetStringArray("header")
+	headers, err := rabbitmq.ParseHeaderFilters(headerValues)
+	if err != nil {
+		return filter, err
+	}
+	if len(headers) > 0 {
+		filter.Headers = headers
+	}
+
+	filter.BodyContains, _ = cmd.Flags().GetString("body-contains")
+
+	if pattern, _ := cmd.Flags().GetString("body-regex"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return filter, fmt.Errorf("regex inválida: %w", err)
+		}
+		filter.BodyRegex = re
+	}
+
+	if expr, _ := cmd.Flags().GetString("jsonpath"); expr != "" {
+		predicate, err := rabbitmq.ParseJSONPathPredicate(expr)
+		if err != nil {
+			return filter, err
+		}
+		filter.JSONPath = predicate
+	}
+
+	filter.DeathReason, _ = cmd.Flags().GetString("death-reason")
+
+	return filter, nil
+}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/ui"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var messageCmd = &cobra.Command{
+	Use:   "message",
+	Short: "Publicar e inspecionar mensagens",
+	Long:  "Comandos para trabalhar com mensagens individuais",
+}
+
+var messagePublishCmd = &cobra.Command{
+	Use:   "publish [body]",
+	Short: "Publicar uma mensagem",
+	Long: `Publica uma mensagem em uma fila ou exchange com confirmação do broker.
+
+O body vem do argumento, de --file ou da entrada padrão (--file - ou pipe).
+A publicação usa mandatory: mensagens sem rota são reportadas.
+
+Exemplos:
+  gohop message publish --queue orders '{"id":1}' --content-type application/json
+  gohop message publish --exchange events --routing-key orders.created --file order.json
+  cat order.json | gohop message publish --queue orders --header tenant=acme --count 10`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMessagePublish,
+}
+
+func init() {
+	messagePublishCmd.Flags().String("queue", "", "Fila de destino (publica no default exchange)")
+	messagePublishCmd.Flags().String("exchange", "", "Exchange de destino")
+	messagePublishCmd.Flags().String("routing-key", "", "Routing key no exchange de destino")
+	messagePublishCmd.Flags().String("file", "", "Ler o body de um arquivo (- = entrada padrão)")
+	messagePublishCmd.Flags().Int("count", 1, "Quantas vezes publicar a mensagem")
+	addMessagePropertyFlags(messagePublishCmd)
+
+	messageCmd.AddCommand(messagePublishCmd)
+}
+
+func runMessagePublish(cmd *cobra.Command, args []string) error {
+	queueName, _ := cmd.Flags().GetString("queue")
+	exchange, _ := cmd.Flags().GetString("exchange")
+	routingKey, _ := cmd.Flags().GetString("routing-key")
+	file, _ := cmd.Flags().GetString("file")
+	count, _ := cmd.Flags().GetInt("count")
+
+	if queueName != "" && (exchange != "" || routingKey != "") {
+		return fmt.Errorf("use --queue ou --exchange/--routing-key, não ambos")
+	}
+	if queueName == "" && exchange == "" && routingKey == "" {
+		return fmt.Errorf("informe o destino com --queue ou --exchange")
+	}
+	if queueName != "" {
+		routingKey = queueName
+	}
+	if count <= 0 {
+		return fmt.Errorf("--count deve ser maior que zero")
+	}
+
+	destination := fmt.Sprintf("fila '%s'", queueName)
+	if queueName == "" {
+		destination = fmt.Sprintf("exchange '%s' (routing key '%s')", exchange, routingKey)
+	}
+
+	fmt.Print(ui.SubMenuHeader("📤", "Publicar Mensagem", fmt.Sprintf("Publicando em %s", destination)))
+
+	body, err := readMessageBody(args, file, os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	msg, err := publishingFromFlags(cmd, body)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	fmt.Println(ui.SubMenuLoading("Conectando ao RabbitMQ"))
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var onProgress func(current, total int)
+	if count > 1 {
+		onProgress = func(current, total int) {
+			fmt.Printf("\r  ⏳ Publicando: %d/%d", current, total)
+		}
+	}
+
+	result, err := client.Publish(ctx, rabbitmq.PublishOptions{
+		Exchange:   exchange,
+		RoutingKey: routingKey,
+		Count:      count,
+	}, msg, onProgress)
+	if count > 1 {
+		fmt.Println()
+	}
+	if err != nil {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("%d mensagem(ns) confirmada(s) antes do erro", result.Confirmed)))
+		return err
+	}
+
+	fmt.Print(ui.SubMenuKeyValue("Body:", fmt.Sprintf("%d bytes", len(body)), false))
+	fmt.Print(ui.SubMenuKeyValue("Confirmadas:", strconv.Itoa(result.Confirmed), true))
+	if result.Returned > 0 {
+		fmt.Print(ui.SubMenuKeyValue("Sem rota:", ui.SubMenuStatus(strconv.Itoa(result.Returned), "error"), false))
+		fmt.Println()
+		fmt.Println(ui.SubMenuWarning(fmt.Sprintf("%d mensagem(ns) devolvida(s) pelo broker: %s", result.Returned, result.ReplyText)))
+		fmt.Println(ui.SubMenuHelp("Verifique se o exchange tem um binding para a routing key"))
+		return fmt.Errorf("%d mensagem(ns) sem rota", result.Returned)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) publicada(s) em %s", result.Confirmed, destination)))
+	return nil
+}
+
+// readMessageBody lê o body do argumento, de um arquivo ou da entrada padrão.
+// Sem argumento nem --file, lê a entrada padrão apenas se ela não for um terminal.
+func readMessageBody(args []string, file string, stdin *os.File) ([]byte, error) {
+	if len(args) > 0 && file != "" {
+		return nil, fmt.Errorf("informe o body como argumento ou com --file, não ambos")
+	}
+
+	switch {
+	case len(args) > 0:
+		return []byte(args[0]), nil
+	case file == "-":
+		return readAll(stdin)
+	case file != "":
+		body, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+		}
+		return body, nil
+	case !term.IsTerminal(int(stdin.Fd())):
+		return readAll(stdin)
+	}
+
+	return nil, errors.New("informe o body como argumento, com --file ou pela entrada padrão")
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler entrada padrão: %w", err)
+	}
+	return body, nil
+}
+
+// addMessagePropertyFlags registra as flags de propriedades da mensagem
+func addMessagePropertyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("header", nil, "Header da mensagem (chave=valor, pode repetir)")
+	cmd.Flags().String("content-type", "", "Content type (ex: application/json)")
+	cmd.Flags().String("correlation-id", "", "Correlation ID")
+	cmd.Flags().String("reply-to", "", "Fila de resposta (reply-to)")
+	cmd.Flags().Uint8("priority", 0, "Prioridade (0-255)")
+	cmd.Flags().String("expiration", "", "Expiração da mensagem (ex: 30s, 5m ou milissegundos)")
+	cmd.Flags().String("message-id", "", "Message ID")
+	cmd.Flags().Bool("persistent", true, "Mensagem persistente (delivery mode 2)")
+}
+
+// publishingFromFlags monta a mensagem AMQP a partir das flags registradas por addMessagePropertyFlags
+func publishingFromFlags(cmd *cobra.Command, body []byte) (amqp.Publishing, error) {
+	headerValues, _ := cmd.Flags().GetStringArray("header")
+	headers, err := rabbitmq.ParseHeaders(headerValues)
+	if err != nil {
+		return amqp.Publishing{}, err
+	}
+
+	expirationFlag, _ := cmd.Flags().GetString("expiration")
+	expiration, err := parseExpiration(expirationFlag)
+	if err != nil {
+		return amqp.Publishing{}, err
+	}
+
+	msg := amqp.Publishing{
+		Body:         body,
+		Expiration:   expiration,
+		DeliveryMode: amqp.Transient,
+		Timestamp:    time.Now(),
+	}
+	if len(headers) > 0 {
+		msg.Headers = headers
+	}
+	if persistent, _ := cmd.Flags().GetBool("persistent"); persistent {
+		msg.DeliveryMode = amqp.Persistent
+	}
+	msg.ContentType, _ = cmd.Flags().GetString("content-type")
+	msg.CorrelationId, _ = cmd.Flags().GetString("correlation-id")
+	msg.ReplyTo, _ = cmd.Flags().GetString("reply-to")
+	msg.Priority, _ = cmd.Flags().GetUint8("priority")
+	msg.MessageId, _ = cmd.Flags().GetString("message-id")
+
+	return msg, nil
+}
+
+// parseExpiration converte "30s", "5m" ou milissegundos no formato da
+// propriedade expiration (milissegundos como texto)
+func parseExpiration(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if ms, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return strconv.FormatUint(ms, 10), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return "", fmt.Errorf("expiração inválida %q: use uma duração (30s, 5m) ou milissegundos", value)
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10), nil
+}
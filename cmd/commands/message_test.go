@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpiration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"1500", "1500", false},
+		{"30s", "30000", false},
+		{"5m", "300000", false},
+		{"-1s", "", true},
+		{"amanhã", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			expiration, err := parseExpiration(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expiration)
+		})
+	}
+}
+
+func TestReadMessageBody(t *testing.T) {
+	body, err := readMessageBody([]string{`{"id":1}`}, "", os.Stdin)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":1}`), body)
+
+	path := filepath.Join(t.TempDir(), "order.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":2}`), 0o644))
+	body, err = readMessageBody(nil, path, os.Stdin)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":2}`), body)
+
+	_, err = readMessageBody([]string{"x"}, path, os.Stdin)
+	assert.Error(t, err)
+}
+
+func TestPublishingFromFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	addMessagePropertyFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{
+		"--header", "tenant=acme",
+		"--content-type", "application/json",
+		"--correlation-id", "corr-1",
+		"--priority", "5",
+		"--expiration", "10s",
+		"--persistent=false",
+	}))
+
+	msg, err := publishingFromFlags(cmd, []byte("payload"))
+	require.NoError(t, err)
+	assert.Equal(t, amqp.Table{"tenant": "acme"}, msg.Headers)
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, "corr-1", msg.CorrelationId)
+	assert.Equal(t, uint8(5), msg.Priority)
+	assert.Equal(t, "10000", msg.Expiration)
+	assert.Equal(t, amqp.Transient, msg.DeliveryMode)
+	assert.Equal(t, []byte("payload"), msg.Body)
+}
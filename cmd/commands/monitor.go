@@ -23,7 +23,8 @@ Mostra:
 
 Teclas:
   q, ESC, Ctrl+C  - Sair
-  r               - Atualizar manualmente`,
+  r               - Atualizar manualmente
+  d               - Explorar mensagens da DLQ (q volta ao dashboard)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMonitor,
 }
@@ -79,7 +80,7 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	fmt.Println(ui.SubMenuInfo("Iniciando dashboard interativo..."))
-	fmt.Println(ui.SubMenuHelp("Pressione 'q' para sair, 'r' para atualizar"))
+	fmt.Println(ui.SubMenuHelp("Pressione 'q' para sair, 'r' para atualizar, 'd' para explorar a DLQ"))
 	fmt.Println()
 
 	// Pequena pausa para usuário ler
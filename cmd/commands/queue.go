@@ -1,14 +1,27 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/huh"
 	"github.com/davioliveeira/gohop/internal/config"
 	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
 	"github.com/davioliveeira/gohop/internal/ui"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -58,6 +71,171 @@ var queuePurgeCmd = &cobra.Command{
 	RunE:  runQueuePurge,
 }
 
+var queuePeekCmd = &cobra.Command{
+	Use:   "peek [nome]",
+	Short: "Ver mensagens sem consumi-las",
+	Long: `Mostra as primeiras mensagens de uma fila sem removê-las.
+
+Usa a Management API com ackmode ack_requeue_true: as mensagens voltam
+para a fila na mesma posição, marcadas como redelivered.
+
+Exemplos:
+  gohop queue peek orders -n 10
+  gohop queue peek orders -n 1 -o json | jq .`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueuePeek,
+}
+
+var queueExportCmd = &cobra.Command{
+	Use:   "export [nome]",
+	Short: "Exportar mensagens para um arquivo NDJSON",
+	Long: `Grava as mensagens da fila em um arquivo NDJSON, uma por linha, com as
+propriedades AMQP, os headers (cada valor com o seu tipo AMQP, ex:
+{"type":"long","value":3}, para que o import devolva inteiros, timestamps e
+o x-death intactos) e o body em base64.
+
+As mensagens são gravadas em disco à medida que são lidas. Por padrão elas
+são copiadas e voltam para a fila; com --remove são removidas da fila
+somente depois de gravadas no arquivo.
+
+Exemplos:
+  gohop queue export orders --file orders.ndjson
+  gohop queue export orders.dlq --file incident.ndjson --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueExport,
+}
+
+var queueImportCmd = &cobra.Command{
+	Use:   "import [arquivo]",
+	Short: "Importar mensagens de um arquivo NDJSON",
+	Long: `Publica as mensagens de um arquivo gerado por 'gohop queue export' em uma
+fila ou exchange, com confirmação do broker em lotes.
+
+O destino (--to) é resolvido como fila e, se não existir, como exchange.
+Na fila, as mensagens vão pelo default exchange; no exchange, usam a
+routing key original de cada mensagem, a menos que --routing-key seja
+informada. Use --profile para importar em outro broker.
+
+Se a importação falhar, a linha para retomada é exibida; use --from-line
+para continuar de onde parou.
+
+Exemplos:
+  gohop queue import orders.ndjson --to orders
+  gohop queue import incident.ndjson --to events --routing-key orders.created
+  gohop queue import orders.ndjson --to orders --from-line 1201
+  gohop queue import orders.ndjson --to orders --set-header source=replay --remove-header x-death`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueImport,
+}
+
+var queueMoveCmd = &cobra.Command{
+	Use:   "move [origem] [destino]",
+	Short: "Mover mensagens entre filas",
+	Long: `Move mensagens de uma fila para outra, no mesmo broker ou em outro perfil
+(--to-profile).
+
+As mensagens são lidas uma a uma e cada uma só é removida da origem depois
+que o broker de destino confirma a publicação; se o processo for
+interrompido, as mensagens ainda não confirmadas voltam para a origem.
+Mensagens fora do filtro permanecem na origem. São movidas no máximo as
+mensagens que estavam na origem no início; as que chegam depois ficam para
+a próxima execução.
+
+Exemplos:
+  gohop queue move orders.parking orders
+  gohop queue move orders orders --to-profile staging --max 500
+  gohop queue move orders.dlq orders.manual --header tenant=acme`,
+	Args: cobra.ExactArgs(2),
+	RunE: runQueueMove,
+}
+
+var queueReconfigureCmd = &cobra.Command{
+	Use:   "reconfigure [nome]",
+	Short: "Reconfigurar fila existente com retry",
+	Long: `Recria uma fila existente com DLX e cria o sistema de retry sem perder
+mensagens.
+
+As mensagens são gravadas em um spool em disco (cada uma só sai da fila
+depois de sincronizada) e cada etapa concluída é registrada em um journal
+em ~/.gohop/reconfigure. Se a execução for interrompida:
+
+  --resume    conclui a reconfiguração de onde parou
+  --rollback  devolve as mensagens e restaura a fila original
+              (possível enquanto a fila não foi recriada com DLX)
+
+--backoff, --backoff-multiplier, --max-retry-delay e --backend são os de
+'gohop retry setup'; ficam no journal e valem também no --resume.
+
+Exemplos:
+  gohop queue reconfigure orders --max-retries 5 --retry-delay 10
+  gohop queue reconfigure orders --backoff 5s,30s,5m
+  gohop queue reconfigure orders --resume`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueReconfigure,
+}
+
+var queueTailCmd = &cobra.Command{
+	Use:   "tail [nome]",
+	Short: "Acompanhar mensagens da fila em tempo real",
+	Long: `Exibe as mensagens que chegam na fila à medida que são publicadas, sem
+afetar os consumers.
+
+Uma fila temporária exclusiva é ligada aos exchanges de origem da fila com
+os mesmos bindings e recebe uma cópia de cada mensagem; ela é removida ao
+sair. Mensagens publicadas direto na fila (default exchange) não aparecem.
+
+Com --consume as mensagens são lidas da própria fila (disputando com os
+consumers) e tratadas conforme --ack-mode:
+  ack      remove as mensagens da fila (padrão)
+  nack     rejeita sem devolver (vão para o DLX, se houver)
+  requeue  devolve as mensagens para a fila ao sair
+
+Com --consume, --last só é aceito com --ack-mode requeue, para que as
+mensagens que não são exibidas voltem para a fila.
+
+Com -o json cada mensagem é uma linha NDJSON no formato de 'queue export'.
+
+Exemplos:
+  gohop queue tail orders
+  gohop queue tail orders --header tenant=acme -n 10
+  gohop queue tail orders --duration 1m --last 20
+  gohop queue tail orders -o json > captured.ndjson
+  gohop queue tail orders.dlq --consume --ack-mode requeue`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueTail,
+}
+
+var queueGrepCmd = &cobra.Command{
+	Use:   "grep [nome]",
+	Short: "Procurar mensagens na fila",
+	Long: `Lê a fila sem consumir as mensagens e lista as que casam com os critérios,
+com a posição de cada uma (1 = a mais antiga).
+
+Critérios (todos os informados precisam casar):
+  --body-regex     regex no body
+  --body-contains  texto no body
+  --jsonpath       caminho em bodies JSON: '$.order.id', '$.items[*].sku==A1',
+                   '$.status!=ok' (sem comparação: o campo existe e não é null)
+  --header         header igual ao valor (chave=valor, pode repetir)
+  --death-reason   motivo de dead-letter no x-death (rejected, expired, ...)
+
+Durante a busca as mensagens lidas ficam reservadas (unacked) e não são
+entregues aos consumers: em filas grandes os consumers ficam parados até o
+fim da busca. Ao final as mensagens voltam para a fila na ordem original.
+
+Em filas quorum cada devolução conta uma entrega (delivery-count); ao passar
+do delivery-limit a mensagem vai para o DLX ou é descartada. Com um limite
+definido o grep só roda com --force; sem limite, o RabbitMQ 4 usa 20.
+
+Exemplos:
+  gohop queue grep orders.dlq --jsonpath '$.order_id==98231'
+  gohop queue grep orders.dlq --death-reason expired --header tenant=acme
+  gohop queue grep orders --body-regex 'timeout|refused' --max 5 --full
+  gohop queue grep orders.dlq --body-contains ACME -o json | jq .position`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueGrep,
+}
+
 func init() {
 	queueCreateCmd.Flags().String("type", "quorum", "Tipo de fila (classic|quorum)")
 	queueCreateCmd.Flags().Bool("durable", true, "Fila durável")
@@ -76,6 +254,62 @@ func init() {
 	queueCmd.AddCommand(queueDeleteCmd)
 	queueCmd.AddCommand(queueStatusCmd)
 	queueCmd.AddCommand(queuePurgeCmd)
+
+	queuePeekCmd.Flags().IntP("count", "n", 5, "Quantidade de mensagens")
+	queuePeekCmd.Flags().Int("truncate", 50000, "Tamanho máximo do body em bytes (0 = sem limite)")
+	queueCmd.AddCommand(queuePeekCmd)
+
+	queueExportCmd.Flags().String("file", "", "Arquivo de destino (padrão: <fila>.ndjson)")
+	queueExportCmd.Flags().Bool("remove", false, "Remover as mensagens da fila após gravá-las")
+	queueExportCmd.Flags().Int("max", 0, "Máximo de mensagens exportadas (0 = todas)")
+	queueExportCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	queueCmd.AddCommand(queueExportCmd)
+
+	queueImportCmd.Flags().String("to", "", "Fila ou exchange de destino")
+	queueImportCmd.Flags().Bool("exchange", false, "Tratar --to como exchange (sem resolver como fila)")
+	queueImportCmd.Flags().String("routing-key", "", "Routing key usada no exchange (padrão: a original)")
+	queueImportCmd.Flags().StringArray("set-header", nil, "Header adicionado às mensagens (chave=valor, repetível)")
+	queueImportCmd.Flags().StringArray("remove-header", nil, "Header removido das mensagens (repetível)")
+	queueImportCmd.Flags().Int("from-line", 0, "Linha do arquivo a partir da qual importar")
+	queueImportCmd.Flags().Int("batch", 100, "Mensagens publicadas antes de aguardar confirmações")
+	queueImportCmd.MarkFlagRequired("to")
+	queueCmd.AddCommand(queueImportCmd)
+
+	queueMoveCmd.Flags().String("to-profile", "", "Perfil do broker de destino (padrão: o mesmo da origem)")
+	queueMoveCmd.Flags().Int("max", 0, "Máximo de mensagens movidas (0 = todas)")
+	queueMoveCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	addMessageFilterFlags(queueMoveCmd)
+	queueCmd.AddCommand(queueMoveCmd)
+
+	queueReconfigureCmd.Flags().Int("max-retries", 3, "Número máximo de tentativas")
+	queueReconfigureCmd.Flags().Int("retry-delay", 5, "Delay entre tentativas (segundos)")
+	queueReconfigureCmd.Flags().String("backoff", "", "Delay de cada tentativa (ex: 5s,30s,5m,30m)")
+	queueReconfigureCmd.Flags().Float64("backoff-multiplier", 0, "Multiplica o retry-delay a cada tentativa (ex: 2)")
+	queueReconfigureCmd.Flags().Int("max-retry-delay", 0, "Delay máximo com backoff-multiplier (segundos, 0 = sem limite)")
+	queueReconfigureCmd.Flags().String("backend", retry.BackendTTL, "Backend do delay: ttl (wait queues) ou delayed (plugin x-delayed-message)")
+	queueReconfigureCmd.Flags().Int("dlq-ttl", 604800000, "TTL das mensagens na DLQ em ms (0 = sem expiração)")
+	queueReconfigureCmd.Flags().String("type", "", "Tipo da fila recriada (classic|quorum, padrão: o atual)")
+	queueReconfigureCmd.Flags().Bool("resume", false, "Concluir uma reconfiguração interrompida")
+	queueReconfigureCmd.Flags().Bool("rollback", false, "Desfazer uma reconfiguração interrompida")
+	queueReconfigureCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	queueReconfigureCmd.MarkFlagsMutuallyExclusive("resume", "rollback")
+	queueCmd.AddCommand(queueReconfigureCmd)
+
+	queueTailCmd.Flags().IntP("max", "n", 0, "Encerrar após N mensagens (0 = sem limite)")
+	queueTailCmd.Flags().Int("last", 0, "Exibir apenas as últimas N mensagens ao encerrar")
+	queueTailCmd.Flags().Duration("duration", 0, "Encerrar após o tempo informado (ex: 30s, 5m)")
+	queueTailCmd.Flags().Bool("consume", false, "Ler da própria fila, tirando as mensagens dos consumers")
+	queueTailCmd.Flags().String("ack-mode", rabbitmq.AckModeAck, "Com --consume: ack, nack ou requeue")
+	queueTailCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	addMessageFilterFlags(queueTailCmd)
+	queueCmd.AddCommand(queueTailCmd)
+
+	queueGrepCmd.Flags().Int("max", 0, "Encerrar após N mensagens encontradas (0 = sem limite)")
+	queueGrepCmd.Flags().Int("limit", 0, "Máximo de mensagens lidas (0 = a fila inteira)")
+	queueGrepCmd.Flags().Bool("full", false, "Exibir as mensagens encontradas por completo")
+	queueGrepCmd.Flags().Bool("force", false, "Procurar mesmo em fila quorum com delivery-limit")
+	addMessageFilterFlags(queueGrepCmd)
+	queueCmd.AddCommand(queueGrepCmd)
 }
 
 func runQueueCreate(cmd *cobra.Command, args []string) error {
@@ -293,10 +527,18 @@ func runQueueDelete(cmd *cobra.Command, args []string) error {
 	// Deletar filas relacionadas (cascade)
 	if cascade {
 		fmt.Println(ui.SubMenuLoading("Deletando filas relacionadas"))
-		waitQueue := fmt.Sprintf("%s.wait", queueName)
-		dlqQueue := fmt.Sprintf("%s.dlq", queueName)
+		relatedQueues := []string{retry.WaitQueueName(queueName, 1), retry.DLQName(queueName)}
+
+		// Wait queues das tentativas seguintes ("<fila>.wait.N")
+		if queues, err := mgmtClient.ListQueues(); err == nil {
+			for _, q := range queues {
+				if strings.HasPrefix(q.Name, queueName+".wait.") && retry.IsRetryQueue(q.Name) {
+					relatedQueues = append(relatedQueues, q.Name)
+				}
+			}
+		}
 
-		for _, relatedQueue := range []string{waitQueue, dlqQueue} {
+		for _, relatedQueue := range relatedQueues {
 			_, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, relatedQueue)
 			if err == nil {
 				if err := mgmtClient.DeleteQueueViaAPI(cfg.RabbitMQ.VHost, relatedQueue); err == nil {
@@ -378,6 +620,17 @@ func runQueueStatus(cmd *cobra.Command, args []string) error {
 		fmt.Print(ui.SubMenuKeyValue("Acknowledges:", strconv.Itoa(queue.MessageStats.Ack), false))
 	}
 
+	// Bindings (o implícito do default exchange fica de fora)
+	if bindings, err := mgmtClient.GetQueueBindings(cfg.RabbitMQ.VHost, queueName); err == nil {
+		bindings = rabbitmq.BindingFilter{}.Apply(bindings)
+		fmt.Println(ui.SubMenuSection("🔗", fmt.Sprintf("Bindings (%d)", len(bindings))))
+		if len(bindings) == 0 {
+			fmt.Println(ui.SubMenuInfo("Recebe mensagens apenas pelo default exchange"))
+		} else {
+			fmt.Print(ui.SubMenuTable([]string{"Origem", "Tipo", "Routing Key", "Argumentos"}, bindingRows(bindings, true)))
+		}
+	}
+
 	fmt.Println()
 	return nil
 }
@@ -450,6 +703,1017 @@ func runQueuePurge(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runQueuePeek(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	count, _ := cmd.Flags().GetInt("count")
+	truncate, _ := cmd.Flags().GetInt("truncate")
+	if count <= 0 {
+		return fmt.Errorf("--count deve ser maior que zero")
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	messages, err := mgmtClient.PeekMessages(cfg.RabbitMQ.VHost, queueName, count, truncate)
+	if err != nil {
+		return err
+	}
+
+	// JSON puro para scripts (sem cabeçalho)
+	if outputFmt == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(messages)
+	}
+
+	fmt.Print(ui.SubMenuHeader("👀", "Peek", fmt.Sprintf("Primeiras mensagens de '%s'", queueName)))
+
+	if len(messages) == 0 {
+		fmt.Println(ui.SubMenuWarning("Fila vazia"))
+		return nil
+	}
+
+	for i, msg := range messages {
+		printQueueMessage(fmt.Sprintf("Mensagem #%d", i+1), msg)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) exibida(s); todas continuam na fila", len(messages))))
+	return nil
+}
+
+func runQueueExport(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+
+	fmt.Print(ui.SubMenuHeader("💾", "Exportar Fila", fmt.Sprintf("Exportando mensagens de '%s'", queueName)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	file, _ := cmd.Flags().GetString("file")
+	remove, _ := cmd.Flags().GetBool("remove")
+	maxCount, _ := cmd.Flags().GetInt("max")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	if file == "" {
+		file = queueName + ".ndjson"
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	queue, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, queueName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Fila não encontrada"))
+		return fmt.Errorf("fila não encontrada: %s", queueName)
+	}
+
+	mode := "cópia (as mensagens continuam na fila)"
+	if remove {
+		mode = "remoção (as mensagens saem da fila)"
+	}
+	fmt.Print(ui.SubMenuKeyValue("Mensagens:", strconv.Itoa(queue.MessagesReady), true))
+	fmt.Print(ui.SubMenuKeyValue("Arquivo:", file, false))
+	fmt.Print(ui.SubMenuKeyValue("Modo:", mode, false))
+	fmt.Println()
+
+	if remove && !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Remover mensagens da fila?").
+					Description(fmt.Sprintf("As mensagens de '%s' ficarão apenas em %s", queueName, file)).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo de exportação: %w", err)
+	}
+	defer out.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	total := queue.MessagesReady
+	if maxCount > 0 && maxCount < total {
+		total = maxCount
+	}
+
+	exported, err := client.ExportQueue(ctx, queueName, rabbitmq.NewMessageWriter(out), rabbitmq.ExportOptions{
+		Remove: remove,
+		Max:    maxCount,
+		OnProgress: func(exported int) {
+			fmt.Printf("\r  ⏳ Exportando: %d/%d", exported, max(total, exported))
+		},
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("Exportação interrompida após %d mensagem(ns)", exported)))
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo de exportação: %w", err)
+	}
+
+	if remove {
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) exportada(s) para %s e removida(s) da fila", exported, file)))
+	} else {
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) exportada(s) para %s", exported, file)))
+	}
+	return nil
+}
+
+func runQueueImport(cmd *cobra.Command, args []string) error {
+	file := args[0]
+	target, _ := cmd.Flags().GetString("to")
+	forceExchange, _ := cmd.Flags().GetBool("exchange")
+	routingKey, _ := cmd.Flags().GetString("routing-key")
+	setHeaders, _ := cmd.Flags().GetStringArray("set-header")
+	removeHeaders, _ := cmd.Flags().GetStringArray("remove-header")
+	fromLine, _ := cmd.Flags().GetInt("from-line")
+	batchSize, _ := cmd.Flags().GetInt("batch")
+
+	fmt.Print(ui.SubMenuHeader("📥", "Importar Mensagens", fmt.Sprintf("Importando %s para '%s'", file, target)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	headers, err := rabbitmq.ParseHeaders(setHeaders)
+	if err != nil {
+		return err
+	}
+
+	opts := rabbitmq.ImportOptions{
+		FromLine:      fromLine,
+		BatchSize:     batchSize,
+		SetHeaders:    headers,
+		RemoveHeaders: removeHeaders,
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	kind, err := resolveImportTarget(mgmtClient, cfg.RabbitMQ.VHost, target, forceExchange)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Destino não encontrado"))
+		return err
+	}
+	if kind == "queue" {
+		if routingKey != "" {
+			return fmt.Errorf("--routing-key só se aplica quando o destino é um exchange")
+		}
+		opts.RoutingKey = target
+	} else {
+		opts.Exchange = target
+		opts.RoutingKey = routingKey
+	}
+
+	in, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo de importação: %w", err)
+	}
+	defer in.Close()
+
+	destination := "fila " + target
+	if kind == "exchange" {
+		destination = "exchange " + target
+	}
+	fmt.Print(ui.SubMenuKeyValue("Arquivo:", file, true))
+	fmt.Print(ui.SubMenuKeyValue("Destino:", destination, false))
+	if routingKey != "" {
+		fmt.Print(ui.SubMenuKeyValue("Routing key:", routingKey, false))
+	}
+	if fromLine > 1 {
+		fmt.Print(ui.SubMenuKeyValue("A partir da linha:", strconv.Itoa(fromLine), false))
+	}
+	fmt.Println()
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts.OnProgress = func(imported, line int) {
+		fmt.Printf("\r  ⏳ Importando: %d mensagem(ns) (linha %d)", imported, line)
+	}
+
+	stats, err := client.ImportMessages(ctx, rabbitmq.NewMessageReader(in), opts)
+	fmt.Println()
+	if err != nil {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("Importação interrompida após %d mensagem(ns)", stats.Confirmed)))
+		var importErr *rabbitmq.ImportError
+		if errors.As(err, &importErr) {
+			fmt.Println(ui.SubMenuInfo(fmt.Sprintf("Para retomar, repita o comando com --from-line %d", importErr.Line)))
+		}
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) importada(s) para %s %s", stats.Confirmed, destination, formatThroughput(*stats))))
+	printUserIdDropped(stats.UserIdDropped)
+	return nil
+}
+
+func runQueueMove(cmd *cobra.Command, args []string) error {
+	source, target := args[0], args[1]
+	toProfile, _ := cmd.Flags().GetString("to-profile")
+	maxCount, _ := cmd.Flags().GetInt("max")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	fmt.Print(ui.SubMenuHeader("🚚", "Mover Mensagens", fmt.Sprintf("Movendo mensagens de '%s' para '%s'", source, target)))
+
+	otherProfile := toProfile != "" && toProfile != profile
+
+	filter, err := messageFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	destCfg := cfg
+	if otherProfile {
+		destCfg, err = config.Load(toProfile)
+		if err != nil {
+			fmt.Println(ui.SubMenuError("Erro ao carregar perfil de destino"))
+			return fmt.Errorf("erro ao carregar perfil '%s': %w", toProfile, err)
+		}
+	}
+
+	// Perfis diferentes podem apontar para o mesmo broker e vhost
+	if source == target && sameVHost(cfg.RabbitMQ, destCfg.RabbitMQ) {
+		return fmt.Errorf("origem e destino são a mesma fila: %s", source)
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	queue, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, source)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Fila de origem não encontrada"))
+		return fmt.Errorf("fila não encontrada: %s", source)
+	}
+
+	destination := fmt.Sprintf("fila '%s'", target)
+	if otherProfile {
+		destination += fmt.Sprintf(" (perfil %s)", toProfile)
+	}
+
+	fmt.Print(ui.SubMenuKeyValue("Origem:", fmt.Sprintf("%s (%d msgs)", source, queue.MessagesReady), true))
+	fmt.Print(ui.SubMenuKeyValue("Destino:", destination, false))
+	if maxCount > 0 {
+		fmt.Print(ui.SubMenuKeyValue("Máximo:", fmt.Sprintf("%d msgs", maxCount), false))
+	}
+	if !filter.IsEmpty() {
+		fmt.Print(ui.SubMenuKeyValue("Filtro:", "ativo", false))
+	}
+	fmt.Println()
+
+	if queue.MessagesReady == 0 {
+		fmt.Println(ui.SubMenuInfo("Fila vazia, nada a mover"))
+		return nil
+	}
+
+	if !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("🚚 Mover mensagens?").
+					Description(fmt.Sprintf("Mensagens de '%s' serão enviadas para %s", source, destination)).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	destClient := client
+	if otherProfile {
+		destClient, err = rabbitmq.NewClient(destCfg.RabbitMQ)
+		if err != nil {
+			fmt.Println(ui.SubMenuError("Erro ao conectar ao destino"))
+			return fmt.Errorf("erro ao conectar ao perfil '%s': %w", toProfile, err)
+		}
+		defer destClient.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := client.MoveMessages(ctx, source, destClient, target, rabbitmq.MoveOptions{
+		Max:    maxCount,
+		Filter: filter,
+		OnProgress: func(result rabbitmq.MoveResult) {
+			fmt.Printf("\r  ⏳ Movidas: %d", result.Moved)
+		},
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("Movimentação interrompida após %d mensagem(ns); as demais continuam em '%s'", result.Moved, source)))
+		return err
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) movida(s) para %s %s", result.Moved, destination, formatThroughput(result.Stats))))
+	printUserIdDropped(result.Stats.UserIdDropped)
+	if skipped := result.Scanned - result.Moved; skipped > 0 && !filter.IsEmpty() {
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("%d mensagem(ns) fora do filtro mantida(s) em '%s'", skipped, source)))
+	}
+	return nil
+}
+
+// sameVHost indica se as duas configurações apontam para o mesmo vhost do
+// mesmo broker, comparando host (ou os endereços resolvidos), porta e vhost
+func sameVHost(a, b config.RabbitMQConfig) bool {
+	if a.Port != b.Port || normalizeVHost(a.VHost) != normalizeVHost(b.VHost) {
+		return false
+	}
+	if strings.EqualFold(a.Host, b.Host) {
+		return true
+	}
+
+	addrsA, errA := net.LookupHost(a.Host)
+	addrsB, errB := net.LookupHost(b.Host)
+	if errA != nil || errB != nil {
+		return false
+	}
+	for _, addrA := range addrsA {
+		for _, addrB := range addrsB {
+			if addrA == addrB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeVHost trata o vhost vazio como o padrão "/"
+func normalizeVHost(vhost string) string {
+	if vhost == "" {
+		return "/"
+	}
+	return vhost
+}
+
+func runQueueReconfigure(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	resume, _ := cmd.Flags().GetBool("resume")
+	rollback, _ := cmd.Flags().GetBool("rollback")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	fmt.Print(ui.SubMenuHeader("🔄", "Reconfigurar Fila", fmt.Sprintf("Reconfigurando '%s' com retry", queueName)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	if vhost == "" {
+		vhost = "/"
+	}
+	dir := retry.ReconfigureDir()
+
+	var journal *retry.ReconfigureJournal
+	if resume || rollback {
+		journal, err = retry.LoadReconfigureJournal(dir, vhost, queueName)
+		if err != nil {
+			fmt.Println(ui.SubMenuError("Nenhuma reconfiguração pendente"))
+			return err
+		}
+
+		done := make([]string, len(journal.Steps))
+		for i, step := range journal.Steps {
+			done[i] = step.Title()
+		}
+		if len(done) == 0 {
+			done = []string{"nenhuma"}
+		}
+		fmt.Print(ui.SubMenuKeyValue("Iniciada em:", journal.StartedAt.Format("2006-01-02 15:04:05"), true))
+		fmt.Print(ui.SubMenuKeyValue("Etapas concluídas:", strings.Join(done, ", "), false))
+		fmt.Print(ui.SubMenuKeyValue("Spool:", fmt.Sprintf("%s (%d msgs)", journal.SpoolPath(), journal.Spooled), false))
+		fmt.Println()
+	} else {
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+		retryDelay, _ := cmd.Flags().GetInt("retry-delay")
+		dlqTTL, _ := cmd.Flags().GetInt("dlq-ttl")
+		queueType, _ := cmd.Flags().GetString("type")
+
+		if queueType != "" && queueType != "classic" && queueType != "quorum" {
+			return fmt.Errorf("tipo de fila inválido: %s (use classic ou quorum)", queueType)
+		}
+		if maxRetries < 1 || retryDelay < 1 {
+			return fmt.Errorf("--max-retries e --retry-delay devem ser pelo menos 1")
+		}
+
+		setupOpts := retry.SetupOptions{
+			QueueName:  queueName,
+			QueueType:  queueType,
+			MaxRetries: maxRetries,
+			RetryDelay: retryDelay,
+			DLQTTL:     dlqTTL,
+		}
+		if err := readRetryDelayFlags(cmd, &setupOpts); err != nil {
+			return err
+		}
+
+		mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+		if setupOpts.Backend == retry.BackendDelayed {
+			if err := retry.CheckDelayedPlugin(mgmtClient); err != nil {
+				fmt.Println(ui.SubMenuError("Plugin indisponível"))
+				return err
+			}
+		}
+		queue, err := mgmtClient.GetQueue(vhost, queueName)
+		if err != nil {
+			fmt.Println(ui.SubMenuError("Fila não encontrada"))
+			return fmt.Errorf("fila não encontrada: %s", queueName)
+		}
+
+		fmt.Print(ui.SubMenuKeyValue("Mensagens:", strconv.Itoa(queue.MessagesReady), true))
+		fmt.Print(ui.SubMenuKeyValue("Max Retries:", strconv.Itoa(setupOpts.MaxRetries), false))
+		fmt.Print(ui.SubMenuKeyValue("Backoff:", formatTierDelays(setupOpts.TierDelays()), false))
+		fmt.Print(ui.SubMenuKeyValue("Backend:", setupOpts.Backend, false))
+		fmt.Println()
+
+		if !skipConfirm {
+			var confirm bool
+			confirmForm := huh.NewForm(
+				huh.NewGroup(
+					huh.NewConfirm().
+						Title("⚠️  Recriar a fila com retry?").
+						Description(fmt.Sprintf("'%s' será removida e recriada; as mensagens ficam em disco durante a operação", queueName)).
+						Value(&confirm),
+				),
+			)
+			confirmForm.WithTheme(ui.GetCharmTheme())
+
+			if err := confirmForm.Run(); err != nil {
+				return err
+			}
+			if !confirm {
+				fmt.Println(ui.SubMenuError("Operação cancelada"))
+				return nil
+			}
+		}
+
+		journal, err = retry.StartReconfigure(dir, mgmtClient, vhost, setupOpts)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	hooks := retry.ReconfigureHooks{
+		OnStep: func(step retry.ReconfigureStep, index int) {
+			fmt.Println()
+			fmt.Println(ui.SubMenuLoading(step.Title()))
+		},
+		OnProgress: func(step retry.ReconfigureStep, count int) {
+			fmt.Printf("\r  ⏳ %d mensagem(ns)", count)
+		},
+	}
+
+	if rollback {
+		if err := retry.RollbackReconfigure(ctx, cfg.RabbitMQ, journal, hooks); err != nil {
+			fmt.Println()
+			fmt.Println(ui.SubMenuError("Rollback interrompido"))
+			return err
+		}
+		fmt.Println()
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("Reconfiguração de '%s' desfeita", queueName)))
+		return nil
+	}
+
+	if err := retry.RunReconfigure(ctx, cfg.RabbitMQ, journal, hooks); err != nil {
+		fmt.Println()
+		fmt.Println(ui.SubMenuError("Reconfiguração interrompida"))
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("As mensagens estão salvas em %s", journal.SpoolPath())))
+		fmt.Println(ui.SubMenuInfo("Para concluir, repita o comando com --resume (ou --rollback para desfazer)"))
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Fila '%s' reconfigurada com retry (%d mensagem(ns) preservada(s))", queueName, journal.Spooled)))
+	printUserIdDropped(journal.UserIdDropped)
+	return nil
+}
+
+func runQueueTail(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	maxCount, _ := cmd.Flags().GetInt("max")
+	last, _ := cmd.Flags().GetInt("last")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	consume, _ := cmd.Flags().GetBool("consume")
+	ackMode, _ := cmd.Flags().GetString("ack-mode")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	if err := checkTailAckMode(consume, ackMode, cmd.Flags().Changed("ack-mode"), last); err != nil {
+		return err
+	}
+
+	filter, err := messageFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	jsonOutput := outputFmt == "json"
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+
+	var bindings []rabbitmq.BindingInfoManagement
+	if !consume {
+		bindings, err = mgmtClient.GetQueueBindings(cfg.RabbitMQ.VHost, queueName)
+		if err != nil {
+			return err
+		}
+	} else if _, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, queueName); err != nil {
+		return fmt.Errorf("fila não encontrada: %s", queueName)
+	}
+
+	if !jsonOutput {
+		mode := "cópia (os consumers não são afetados)"
+		if consume {
+			mode = fmt.Sprintf("consumo (%s)", ackMode)
+		}
+		fmt.Print(ui.SubMenuHeader("📡", "Tail", fmt.Sprintf("Acompanhando '%s' (Ctrl+C para sair)", queueName)))
+		fmt.Print(ui.SubMenuKeyValue("Modo:", mode, true))
+		if !filter.IsEmpty() {
+			fmt.Print(ui.SubMenuKeyValue("Filtro:", "ativo", false))
+		}
+		fmt.Println()
+	}
+
+	if consume && ackMode != rabbitmq.AckModeRequeue && !skipConfirm {
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Consumir mensagens da fila?").
+					Description(fmt.Sprintf("As mensagens exibidas sairão de '%s' (%s)", queueName, ackMode)).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	var buffered []amqp.Delivery
+	received, seen := 0, 0
+
+	show := func(d amqp.Delivery) {
+		received++
+		if jsonOutput {
+			encoder.Encode(rabbitmq.NewMessageRecord(d))
+			return
+		}
+		printQueueMessage(fmt.Sprintf("#%d  %s", received, time.Now().Format("15:04:05")), queueMessageFromDelivery(d))
+	}
+
+	shown, err := client.TailQueue(ctx, queueName, bindings, rabbitmq.TailOptions{
+		Filter:  filter,
+		Max:     maxCount,
+		Consume: consume,
+		AckMode: ackMode,
+	}, func(d amqp.Delivery) {
+		if last <= 0 {
+			show(d)
+			return
+		}
+		// --last: guarda só as últimas N para exibir ao encerrar
+		seen++
+		buffered = append(buffered, d)
+		if len(buffered) > last {
+			buffered = buffered[1:]
+		}
+		if !jsonOutput {
+			fmt.Printf("\r  ⏳ %d mensagem(ns) recebida(s)", seen)
+		}
+	})
+
+	if last > 0 {
+		if !jsonOutput {
+			fmt.Println()
+		}
+		received = shown - len(buffered)
+		for _, d := range buffered {
+			show(d)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !jsonOutput {
+		fmt.Println()
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d mensagem(ns) recebida(s)", shown)))
+	}
+	return nil
+}
+
+// checkTailAckMode valida --consume, --ack-mode e --last do tail em conjunto
+func checkTailAckMode(consume bool, ackMode string, ackModeSet bool, last int) error {
+	if !rabbitmq.ValidAckMode(ackMode) {
+		return fmt.Errorf("--ack-mode inválido: %s (use ack, nack ou requeue)", ackMode)
+	}
+	if ackModeSet && !consume {
+		return fmt.Errorf("--ack-mode só se aplica com --consume")
+	}
+	// Com --last só as últimas N são exibidas: as demais seriam removidas sem aparecer
+	if last > 0 && consume && ackMode != rabbitmq.AckModeRequeue {
+		return fmt.Errorf("--last com --consume exige --ack-mode requeue (as mensagens não exibidas seriam removidas da fila)")
+	}
+	return nil
+}
+
+// grepMatch é uma mensagem encontrada pelo grep (formato da saída JSON)
+type grepMatch struct {
+	Position int                    `json:"position"`
+	Message  rabbitmq.MessageRecord `json:"message"`
+}
+
+func runQueueGrep(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	maxMatches, _ := cmd.Flags().GetInt("max")
+	limit, _ := cmd.Flags().GetInt("limit")
+	full, _ := cmd.Flags().GetBool("full")
+	force, _ := cmd.Flags().GetBool("force")
+
+	filter, err := messageFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if filter.IsEmpty() {
+		return fmt.Errorf("informe ao menos um critério de busca (--body-regex, --jsonpath, --header...)")
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	jsonOutput := outputFmt == "json"
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	queue, err := mgmtClient.GetQueue(cfg.RabbitMQ.VHost, queueName)
+	if err != nil {
+		return fmt.Errorf("fila não encontrada: %s", queueName)
+	}
+
+	if !jsonOutput {
+		fmt.Print(ui.SubMenuHeader("🔎", "Grep", fmt.Sprintf("Procurando em '%s' (%d msgs)", queueName, queue.MessagesReady)))
+	}
+
+	warning, err := grepDeliveryLimitCheck(queue, force)
+	if err != nil {
+		if !jsonOutput {
+			fmt.Println(ui.SubMenuError("Fila quorum com delivery-limit"))
+		}
+		return err
+	}
+	if warning != "" {
+		if jsonOutput {
+			fmt.Fprintf(os.Stderr, "aviso: %s\n", warning)
+		} else {
+			fmt.Println(ui.SubMenuWarning(warning))
+			fmt.Println()
+		}
+	}
+
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	encoder := json.NewEncoder(os.Stdout)
+	var rows [][]string
+
+	opts := rabbitmq.ScanOptions{Filter: filter, MaxMatches: maxMatches, Limit: limit}
+	if !jsonOutput {
+		opts.OnProgress = func(result rabbitmq.ScanResult) {
+			if result.Scanned%100 == 0 {
+				fmt.Printf("\r  ⏳ Lidas: %d │ Encontradas: %d", result.Scanned, result.Matched)
+			}
+		}
+	}
+
+	result, err := client.ScanQueue(ctx, queueName, opts, func(position int, d amqp.Delivery) {
+		switch {
+		case jsonOutput:
+			encoder.Encode(grepMatch{Position: position, Message: rabbitmq.NewMessageRecord(d)})
+		case full:
+			printQueueMessage(fmt.Sprintf("Posição %d", position), queueMessageFromDelivery(d))
+		default:
+			rows = append(rows, grepRow(position, d))
+		}
+	})
+	if err != nil {
+		if !jsonOutput {
+			fmt.Println()
+			fmt.Println(ui.SubMenuError(fmt.Sprintf("Busca interrompida após %d mensagem(ns)", result.Scanned)))
+		}
+		return err
+	}
+	if jsonOutput {
+		return nil
+	}
+
+	fmt.Printf("\r%s\r", strings.Repeat(" ", 50))
+	if len(rows) > 0 {
+		fmt.Print(ui.SubMenuTable([]string{"POS", "MESSAGE ID", "ROUTING KEY", "MOTIVO", "BODY"}, rows))
+	}
+	fmt.Println()
+	if result.Matched == 0 {
+		fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Nenhuma de %d mensagem(ns) casou com os critérios", result.Scanned)))
+		return nil
+	}
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d de %d mensagem(ns) casaram; todas continuam na fila", result.Matched, result.Scanned)))
+	return nil
+}
+
+// grepDeliveryLimitCheck avalia o efeito do grep no delivery-count de uma fila
+// quorum: cada mensagem lida volta com requeue e conta uma entrega. Recusa a
+// busca se houver delivery-limit definido (a menos que force) e retorna um
+// aviso quando o limite é o padrão do broker.
+func grepDeliveryLimitCheck(queue *rabbitmq.QueueInfoManagement, force bool) (string, error) {
+	if queue.Type != "quorum" {
+		return "", nil
+	}
+
+	limit, explicit := queue.DeliveryLimit()
+	switch {
+	case !explicit:
+		return fmt.Sprintf("Cada mensagem lida conta uma entrega; no RabbitMQ 4 filas quorum sem delivery-limit usam %d", rabbitmq.DefaultQuorumDeliveryLimit), nil
+	case limit < 0:
+		return "", nil
+	case !force:
+		return "", fmt.Errorf("a fila '%s' tem delivery-limit %d e cada grep conta uma entrega para as mensagens lidas; ao passar do limite elas vão para o DLX ou são descartadas (use --force para procurar mesmo assim)", queue.Name, limit)
+	}
+	return fmt.Sprintf("Cada mensagem lida conta uma entrega para o delivery-limit %d da fila", limit), nil
+}
+
+// grepRow resume uma mensagem encontrada em uma linha da tabela
+func grepRow(position int, d amqp.Delivery) []string {
+	id := d.MessageId
+	if id == "" {
+		id = "-"
+	}
+	reason := "-"
+	if r, ok := d.Headers["x-first-death-reason"]; ok {
+		reason = fmt.Sprint(r)
+	}
+
+	preview := "<binário>"
+	if utf8.Valid(d.Body) {
+		preview = truncateStr(strings.Join(strings.Fields(string(d.Body)), " "), 60)
+	}
+
+	return []string{strconv.Itoa(position), id, d.RoutingKey, reason, preview}
+}
+
+// queueMessageFromDelivery adapta uma mensagem recebida via AMQP ao formato exibido pelo peek
+func queueMessageFromDelivery(d amqp.Delivery) rabbitmq.QueueMessage {
+	record := rabbitmq.NewMessageRecord(d)
+	msg := rabbitmq.QueueMessage{
+		Exchange:     record.Exchange,
+		RoutingKey:   record.RoutingKey,
+		Redelivered:  record.Redelivered,
+		PayloadBytes: len(record.Body),
+		Properties:   record.Properties,
+	}
+	if len(d.Headers) > 0 {
+		msg.Properties.Headers = d.Headers
+	}
+	if utf8.Valid(record.Body) {
+		msg.Payload = string(record.Body)
+		msg.PayloadEncoding = "string"
+	} else {
+		msg.Payload = base64.StdEncoding.EncodeToString(record.Body)
+		msg.PayloadEncoding = "base64"
+	}
+	return msg
+}
+
+// resolveImportTarget identifica se o destino da importação é uma fila ou um exchange
+func resolveImportTarget(mgmt *rabbitmq.ManagementClient, vhost, target string, forceExchange bool) (string, error) {
+	if !forceExchange {
+		if _, err := mgmt.GetQueue(vhost, target); err == nil {
+			return "queue", nil
+		}
+	}
+	if _, err := mgmt.GetExchange(vhost, target); err == nil {
+		return "exchange", nil
+	}
+	if forceExchange {
+		return "", fmt.Errorf("exchange não encontrado: %s", target)
+	}
+	return "", fmt.Errorf("nenhuma fila ou exchange chamado '%s'", target)
+}
+
+// messageProperties lista as propriedades preenchidas da mensagem como pares chave/valor
+func messageProperties(msg rabbitmq.QueueMessage) [][2]string {
+	props := msg.Properties
+	exchange := msg.Exchange
+	if exchange == "" {
+		exchange = "(default)"
+	}
+
+	pairs := [][2]string{
+		{"Exchange", exchange},
+		{"Routing key", msg.RoutingKey},
+		{"Redelivered", strconv.FormatBool(msg.Redelivered)},
+	}
+	optional := []struct {
+		key   string
+		value string
+	}{
+		{"Content type", props.ContentType},
+		{"Content encoding", props.ContentEncoding},
+		{"Message ID", props.MessageId},
+		{"Correlation ID", props.CorrelationId},
+		{"Reply-to", props.ReplyTo},
+		{"Expiration", props.Expiration},
+		{"Type", props.Type},
+		{"App ID", props.AppId},
+		{"User ID", props.UserId},
+	}
+	for _, p := range optional {
+		if p.value != "" {
+			pairs = append(pairs, [2]string{p.key, p.value})
+		}
+	}
+	if props.DeliveryMode == 2 {
+		pairs = append(pairs, [2]string{"Delivery mode", "2 (persistente)"})
+	} else if props.DeliveryMode != 0 {
+		pairs = append(pairs, [2]string{"Delivery mode", strconv.Itoa(props.DeliveryMode)})
+	}
+	if props.Priority != 0 {
+		pairs = append(pairs, [2]string{"Priority", strconv.Itoa(props.Priority)})
+	}
+	if props.Timestamp != 0 {
+		pairs = append(pairs, [2]string{"Timestamp", time.Unix(props.Timestamp, 0).Format(time.RFC3339)})
+	}
+	return pairs
+}
+
+// printQueueMessage exibe propriedades, headers e body de uma mensagem
+func printQueueMessage(title string, msg rabbitmq.QueueMessage) {
+	fmt.Println(ui.SubMenuSection("✉", title))
+	for _, kv := range messageProperties(msg) {
+		fmt.Print(ui.SubMenuKeyValue(kv[0]+":", kv[1], false))
+	}
+
+	if len(msg.Properties.Headers) > 0 {
+		keys := make([]string, 0, len(msg.Properties.Headers))
+		for k := range msg.Properties.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		rows := make([][]string, 0, len(keys))
+		for _, k := range keys {
+			rows = append(rows, []string{k, formatHeaderValue(msg.Properties.Headers[k])})
+		}
+		fmt.Print(ui.SubMenuTable([]string{"HEADER", "VALOR"}, rows))
+	}
+
+	fmt.Println()
+	fmt.Println(formatPeekBody(msg))
+}
+
+// formatHeaderValue formata valores de header (tabelas e listas como JSON)
+func formatHeaderValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, amqp.Table, []interface{}:
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// formatPeekBody formata o body: JSON indentado, texto puro ou resumo de binário
+func formatPeekBody(msg rabbitmq.QueueMessage) string {
+	body, err := msg.Body()
+	if err != nil {
+		return ui.SubMenuError(err.Error())
+	}
+
+	var text string
+	var indented bytes.Buffer
+	switch {
+	case json.Indent(&indented, body, "  ", "  ") == nil:
+		text = "  " + indented.String()
+	case msg.PayloadEncoding == "base64" || !utf8.Valid(body):
+		text = fmt.Sprintf("  <binário, %d bytes; use -o json para o payload em base64>", msg.PayloadBytes)
+	default:
+		text = "  " + string(body)
+	}
+
+	if msg.Truncated() {
+		text += fmt.Sprintf("\n  … truncado (%d de %d bytes)", len(body), msg.PayloadBytes)
+	}
+	return text
+}
+
+// formatThroughput descreve a duração e a vazão de uma publicação
+func formatThroughput(stats rabbitmq.PublishStats) string {
+	text := fmt.Sprintf("em %s (%.0f msg/s)", stats.Elapsed.Round(100*time.Millisecond), stats.Rate())
+	if stats.Retried > 0 {
+		text += fmt.Sprintf(", %d reenvio(s)", stats.Retried)
+	}
+	return text
+}
+
+// printUserIdDropped avisa quando mensagens foram republicadas sem o user-id original
+func printUserIdDropped(count int) {
+	if count > 0 {
+		fmt.Println(ui.SubMenuWarning(fmt.Sprintf("%d mensagem(ns) republicada(s) sem o user-id original: o broker só aceita o usuário da conexão", count)))
+	}
+}
+
 func truncateStr(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -5,6 +5,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,3 +105,119 @@ func TestQueueTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatPeekBody(t *testing.T) {
+	body := formatPeekBody(rabbitmq.QueueMessage{Payload: `{"id":1}`, PayloadBytes: 8, PayloadEncoding: "string"})
+	assert.Contains(t, body, "\"id\": 1")
+
+	body = formatPeekBody(rabbitmq.QueueMessage{Payload: "hello", PayloadBytes: 5, PayloadEncoding: "string"})
+	assert.Equal(t, "  hello", body)
+
+	body = formatPeekBody(rabbitmq.QueueMessage{Payload: "AAEC", PayloadBytes: 3, PayloadEncoding: "base64"})
+	assert.Contains(t, body, "binário, 3 bytes")
+
+	body = formatPeekBody(rabbitmq.QueueMessage{Payload: "hel", PayloadBytes: 5, PayloadEncoding: "string"})
+	assert.Contains(t, body, "truncado (3 de 5 bytes)")
+}
+
+func TestMessageProperties(t *testing.T) {
+	pairs := messageProperties(rabbitmq.QueueMessage{
+		RoutingKey: "orders",
+		Properties: rabbitmq.MessageProperties{ContentType: "application/json", DeliveryMode: 2, Priority: 3},
+	})
+
+	assert.Contains(t, pairs, [2]string{"Exchange", "(default)"})
+	assert.Contains(t, pairs, [2]string{"Content type", "application/json"})
+	assert.Contains(t, pairs, [2]string{"Delivery mode", "2 (persistente)"})
+	assert.Contains(t, pairs, [2]string{"Priority", "3"})
+	for _, pair := range pairs {
+		assert.NotEqual(t, "Correlation ID", pair[0], "propriedades vazias não são exibidas")
+	}
+}
+
+func TestQueueMessageFromDelivery(t *testing.T) {
+	msg := queueMessageFromDelivery(amqp.Delivery{
+		Exchange:   "events",
+		RoutingKey: "orders.created",
+		Headers:    amqp.Table{"x-death": []interface{}{amqp.Table{"count": int64(1)}}},
+		Body:       []byte("hello"),
+	})
+	assert.Equal(t, "events", msg.Exchange)
+	assert.Equal(t, "  hello", formatPeekBody(msg))
+	assert.Equal(t, `[{"count":1}]`, formatHeaderValue(msg.Properties.Headers["x-death"]))
+
+	msg = queueMessageFromDelivery(amqp.Delivery{Body: []byte{0xff, 0x00, 0x01}})
+	assert.Equal(t, "base64", msg.PayloadEncoding)
+	assert.Contains(t, formatPeekBody(msg), "binário, 3 bytes")
+}
+
+func TestCheckTailAckMode(t *testing.T) {
+	assert.NoError(t, checkTailAckMode(false, rabbitmq.AckModeAck, false, 20))
+	assert.NoError(t, checkTailAckMode(true, rabbitmq.AckModeAck, false, 0))
+	assert.NoError(t, checkTailAckMode(true, rabbitmq.AckModeRequeue, true, 20))
+
+	assert.Error(t, checkTailAckMode(true, "drop", true, 0))
+	assert.Error(t, checkTailAckMode(false, rabbitmq.AckModeNack, true, 0), "--ack-mode sem --consume")
+	assert.Error(t, checkTailAckMode(true, rabbitmq.AckModeAck, false, 20), "--last removeria mensagens não exibidas")
+	assert.Error(t, checkTailAckMode(true, rabbitmq.AckModeNack, true, 20))
+}
+
+func TestGrepDeliveryLimitCheck(t *testing.T) {
+	warning, err := grepDeliveryLimitCheck(&rabbitmq.QueueInfoManagement{Type: "classic"}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, warning)
+
+	warning, err = grepDeliveryLimitCheck(&rabbitmq.QueueInfoManagement{Type: "quorum"}, false)
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "20", "sem limite definido, avisa do padrão do RabbitMQ 4")
+
+	limited := &rabbitmq.QueueInfoManagement{
+		Name:      "orders",
+		Type:      "quorum",
+		Arguments: map[string]interface{}{"x-delivery-limit": float64(5)},
+	}
+	_, err = grepDeliveryLimitCheck(limited, false)
+	assert.Error(t, err)
+
+	warning, err = grepDeliveryLimitCheck(limited, true)
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "5")
+
+	unlimited := &rabbitmq.QueueInfoManagement{
+		Type:                      "quorum",
+		EffectivePolicyDefinition: map[string]interface{}{"delivery-limit": float64(-1)},
+	}
+	warning, err = grepDeliveryLimitCheck(unlimited, false)
+	assert.NoError(t, err)
+	assert.Empty(t, warning)
+}
+
+func TestSameVHost(t *testing.T) {
+	base := config.RabbitMQConfig{Host: "rabbit.local", Port: 5672, VHost: "/"}
+
+	tests := []struct {
+		name  string
+		other config.RabbitMQConfig
+		same  bool
+	}{
+		{"mesma configuração", base, true},
+		{"host com outra caixa", config.RabbitMQConfig{Host: "RABBIT.local", Port: 5672, VHost: "/"}, true},
+		{"vhost vazio é o padrão", config.RabbitMQConfig{Host: "rabbit.local", Port: 5672}, true},
+		{"outro usuário no mesmo broker", config.RabbitMQConfig{Host: "rabbit.local", Port: 5672, VHost: "/", Username: "outro"}, true},
+		{"outra porta", config.RabbitMQConfig{Host: "rabbit.local", Port: 5673, VHost: "/"}, false},
+		{"outro vhost", config.RabbitMQConfig{Host: "rabbit.local", Port: 5672, VHost: "orders"}, false},
+		{"outro host", config.RabbitMQConfig{Host: "10.0.0.2", Port: 5672, VHost: "/"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, sameVHost(base, tt.other))
+		})
+	}
+
+	// Endereços resolvidos: nome e IP do mesmo host
+	assert.True(t, sameVHost(
+		config.RabbitMQConfig{Host: "127.0.0.1", Port: 5672},
+		config.RabbitMQConfig{Host: "127.0.0.1", Port: 5672, VHost: "/"},
+	))
+}
@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/davioliveeira/gohop/internal/config"
@@ -24,9 +28,19 @@ var retrySetupCmd = &cobra.Command{
 	Long: `Cria o sistema completo de retry para uma fila:
 
 Arquitetura:
-1. Main Queue -> (reject) -> Wait Exchange -> Wait Queue (TTL) -> Retry Exchange
+1. Main Queue -> (reject) -> Wait Exchange -> Wait Queue N (TTL) -> Retry Exchange
 2. Retry Exchange -> routes back to Main Queue (se retries < MAX_RETRIES)
-3. Retry Exchange -> routes to DLQ (se retries >= MAX_RETRIES)`,
+3. Wait Exchange -> routes to DLQ (se retries >= MAX_RETRIES)
+
+Cada tentativa tem sua própria wait queue e o número da tentativa viaja na
+routing key, então o broker aplica MAX_RETRIES sem lógica no consumer.
+
+Com --backend delayed as wait queues são substituídas por um exchange do
+plugin rabbitmq_delayed_message_exchange: o consumer republica a mensagem
+com x-delay (veja pkg/consumer, Options.Delays).
+
+Com --policy o dead-lettering da fila principal é aplicado por um policy
+via Management API: filas existentes ganham retry sem serem recriadas.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRetrySetup,
 }
@@ -39,15 +53,50 @@ var retryStatusCmd = &cobra.Command{
 	RunE:  runRetryStatus,
 }
 
+var retryRemoveCmd = &cobra.Command{
+	Use:   "remove [queue-name]",
+	Short: "Remover sistema de retry de uma fila",
+	Long: `Remove o sistema de retry sem perder mensagens:
+
+1. Grava as mensagens das wait queues em um spool em disco e as remove
+2. Remove wait exchange e retry exchange
+3. Trata a DLQ conforme --dlq (keep, move ou export)
+4. Grava as mensagens da fila principal no spool e a recria sem
+   x-dead-letter-exchange
+5. Republica as mensagens do spool com confirmação do broker
+
+Cada mensagem só sai de uma fila depois de sincronizada em disco, e as filas
+são removidas com if-empty. Cada etapa concluída é registrada em um journal
+em ~/.gohop/remove; se a execução for interrompida, --resume conclui a
+remoção de onde parou.
+
+A fila principal não pode ter consumers durante a remoção. Se o retry foi
+configurado com --policy, o policy é removido e a fila principal é mantida
+com suas mensagens.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRetryRemove,
+}
+
 func init() {
 	retrySetupCmd.Flags().Int("max-retries", 3, "Número máximo de tentativas")
 	retrySetupCmd.Flags().Int("retry-delay", 5, "Delay entre tentativas (segundos)")
+	retrySetupCmd.Flags().String("backoff", "", "Delay de cada tentativa (ex: 5s,30s,5m,30m)")
+	retrySetupCmd.Flags().Float64("backoff-multiplier", 0, "Multiplica o retry-delay a cada tentativa (ex: 2)")
+	retrySetupCmd.Flags().Int("max-retry-delay", 0, "Delay máximo com backoff-multiplier (segundos, 0 = sem limite)")
 	retrySetupCmd.Flags().Int("dlq-ttl", 604800000, "TTL de mensagens na DLQ (milissegundos)")
 	retrySetupCmd.Flags().Bool("force", false, "Recriar mesmo se já existir")
 	retrySetupCmd.Flags().String("queue-type", "quorum", "Tipo da fila principal (classic|quorum)")
+	retrySetupCmd.Flags().String("backend", retry.BackendTTL, "Backend do delay: ttl (wait queues) ou delayed (plugin x-delayed-message)")
+	retrySetupCmd.Flags().Bool("policy", false, "Aplicar o DLX via policy em vez de recriar a fila principal")
 
 	retryCmd.AddCommand(retrySetupCmd)
 	retryCmd.AddCommand(retryStatusCmd)
+
+	retryRemoveCmd.Flags().String("dlq", "", "O que fazer com a DLQ: keep, move (devolver para a fila) ou export")
+	retryRemoveCmd.Flags().String("export-file", "", "Arquivo NDJSON para --dlq export (padrão: <fila>.dlq.ndjson)")
+	retryRemoveCmd.Flags().Bool("yes", false, "Não pedir confirmação")
+	retryRemoveCmd.Flags().Bool("resume", false, "Concluir uma remoção interrompida")
+	retryCmd.AddCommand(retryRemoveCmd)
 }
 
 func runRetrySetup(cmd *cobra.Command, args []string) error {
@@ -66,23 +115,58 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 	dlqTTL, _ := cmd.Flags().GetInt("dlq-ttl")
 	force, _ := cmd.Flags().GetBool("force")
 	queueType, _ := cmd.Flags().GetString("queue-type")
+	usePolicy, _ := cmd.Flags().GetBool("policy")
+
+	setupOpts := retry.SetupOptions{
+		QueueName:  queueName,
+		QueueType:  queueType,
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+		DLQTTL:     dlqTTL,
+		Force:      force,
+	}
+	if err := readRetryDelayFlags(cmd, &setupOpts); err != nil {
+		return err
+	}
+	maxRetries, backend := setupOpts.MaxRetries, setupOpts.Backend
+	delays := setupOpts.TierDelays()
+
+	// O backend delayed depende do plugin no broker
+	if backend == retry.BackendDelayed {
+		fmt.Println(ui.SubMenuLoading("Verificando plugin rabbitmq_delayed_message_exchange"))
+		if err := retry.CheckDelayedPlugin(rabbitmq.NewManagementClient(cfg.RabbitMQ)); err != nil {
+			fmt.Println(ui.SubMenuError("Plugin indisponível"))
+			return err
+		}
+		fmt.Println(ui.SubMenuDone("Plugin habilitado"))
+		fmt.Println()
+	}
 
 	// Mostrar componentes que serão criados
 	fmt.Println(ui.SubMenuSection("📦", "Componentes do Sistema de Retry"))
 
-	waitQueueName := fmt.Sprintf("%s.wait", queueName)
-	waitExchangeName := fmt.Sprintf("%s.wait.exchange", queueName)
-	retryExchangeName := fmt.Sprintf("%s.retry", queueName)
-	dlqName := fmt.Sprintf("%s.dlq", queueName)
-
 	fmt.Print(ui.SubMenuKeyValue("Main Queue:", queueName, true))
-	fmt.Print(ui.SubMenuKeyValue("Wait Queue:", fmt.Sprintf("%s (TTL: %ds)", waitQueueName, retryDelay), false))
-	fmt.Print(ui.SubMenuKeyValue("Wait Exchange:", waitExchangeName, false))
-	fmt.Print(ui.SubMenuKeyValue("Retry Exchange:", fmt.Sprintf("%s (headers)", retryExchangeName), false))
-	fmt.Print(ui.SubMenuKeyValue("DLQ:", fmt.Sprintf("%s (TTL: %dms)", dlqName, dlqTTL), false))
+	if backend == retry.BackendDelayed {
+		fmt.Print(ui.SubMenuKeyValue("Wait Exchange:", fmt.Sprintf("%s (fanout → DLQ)", retry.WaitExchangeName(queueName)), false))
+		fmt.Print(ui.SubMenuKeyValue("Retry Exchange:", fmt.Sprintf("%s (%s)", retry.RetryExchangeName(queueName), retry.DelayedExchangeType), false))
+		fmt.Print(ui.SubMenuKeyValue("Backoff:", formatTierDelays(delays), false))
+	} else {
+		for attempt := 1; attempt <= maxRetries; attempt++ {
+			fmt.Print(ui.SubMenuKeyValue(fmt.Sprintf("Wait Queue #%d:", attempt),
+				fmt.Sprintf("%s (TTL: %s)", retry.WaitQueueName(queueName, attempt), retry.FormatDelay(delays[attempt-1])), false))
+		}
+		fmt.Print(ui.SubMenuKeyValue("Wait Exchange:", fmt.Sprintf("%s (direct)", retry.WaitExchangeName(queueName)), false))
+		fmt.Print(ui.SubMenuKeyValue("First Exchange:", fmt.Sprintf("%s (fanout)", retry.FirstAttemptExchangeName(queueName)), false))
+		fmt.Print(ui.SubMenuKeyValue("Retry Exchange:", fmt.Sprintf("%s (headers)", retry.RetryExchangeName(queueName)), false))
+	}
+	fmt.Print(ui.SubMenuKeyValue("DLQ:", fmt.Sprintf("%s (TTL: %dms)", retry.DLQName(queueName), dlqTTL), false))
 	fmt.Print(ui.SubMenuKeyValue("Max Retries:", strconv.Itoa(maxRetries), false))
 	fmt.Println()
 
+	if usePolicy {
+		return runRetrySetupWithPolicy(cfg, setupOpts, cmd.Flags().Changed("queue-type"))
+	}
+
 	// Conectar
 	fmt.Println(ui.SubMenuLoading("Conectando ao RabbitMQ"))
 	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
@@ -92,6 +176,16 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 	}
 	defer client.Close()
 
+	vhost := cfg.RabbitMQ.VHost
+	if vhost == "" {
+		vhost = "/"
+	} else if vhost[0] != '/' {
+		vhost = "/" + vhost
+	}
+	if err := checkRetryConfig(rabbitmq.NewManagementClient(cfg.RabbitMQ), vhost, setupOpts); err != nil {
+		return err
+	}
+
 	// Verificar fila principal
 	mainQueueExists, err := client.QueueExists(queueName)
 	if err != nil {
@@ -128,12 +222,6 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 		_, err := client.DeleteQueue(queueName, false, false, false)
 		if err != nil {
 			mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
-			vhost := cfg.RabbitMQ.VHost
-			if vhost == "" {
-				vhost = "/"
-			} else if vhost[0] != '/' {
-				vhost = "/" + vhost
-			}
 			if err := mgmtClient.DeleteQueueViaAPI(vhost, queueName); err != nil {
 				return fmt.Errorf("erro ao deletar fila: %w", err)
 			}
@@ -145,15 +233,6 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println(ui.SubMenuSection("⚙", "Criando Componentes"))
 
-	setupOpts := retry.SetupOptions{
-		QueueName:  queueName,
-		QueueType:  queueType,
-		MaxRetries: maxRetries,
-		RetryDelay: retryDelay,
-		DLQTTL:     dlqTTL,
-		Force:      force,
-	}
-
 	fmt.Println(ui.SubMenuLoading("Criando wait exchange"))
 	if err := retry.SetupRetry(client, setupOpts); err != nil {
 		fmt.Println(ui.SubMenuError("Erro ao configurar retry"))
@@ -161,8 +240,12 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println(ui.SubMenuDone("Wait exchange criado"))
-	fmt.Println(ui.SubMenuDone("Wait queue criada"))
-	fmt.Println(ui.SubMenuDone("Retry exchange criado"))
+	if backend == retry.BackendDelayed {
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("Retry exchange criado (%s)", retry.DelayedExchangeType)))
+	} else {
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d wait queue(s) criada(s)", maxRetries)))
+		fmt.Println(ui.SubMenuDone("Retry exchange criado"))
+	}
 	fmt.Println(ui.SubMenuDone("DLQ criada"))
 	fmt.Println(ui.SubMenuDone("Bindings configurados"))
 
@@ -183,15 +266,153 @@ func runRetrySetup(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	fmt.Println(ui.SubMenuSection("📝", "Próximos Passos"))
-	fmt.Print(ui.SubMenuList([]string{
-		"Configure seu consumer para verificar header 'x-death'",
-		"Se retry_count < MAX_RETRIES, republique na main queue",
-		"Se retry_count >= MAX_RETRIES, trate como erro definitivo",
-	}, "•"))
+	fmt.Print(ui.SubMenuList(retryNextSteps(setupOpts), "•"))
+
+	return nil
+}
+
+// readRetryDelayFlags lê --backoff, --backoff-multiplier, --max-retry-delay e
+// --backend em opts. Sem --max-retries explícito, cada tier do backoff é uma tentativa.
+func readRetryDelayFlags(cmd *cobra.Command, opts *retry.SetupOptions) error {
+	backoffFlag, _ := cmd.Flags().GetString("backoff")
+	backoffMultiplier, _ := cmd.Flags().GetFloat64("backoff-multiplier")
+	maxRetryDelay, _ := cmd.Flags().GetInt("max-retry-delay")
+	backend, _ := cmd.Flags().GetString("backend")
+
+	if !retry.ValidBackend(backend) {
+		return fmt.Errorf("valor inválido para --backend: %s (use ttl ou delayed)", backend)
+	}
+
+	backoff, err := retry.ParseBackoff(backoffFlag)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Backoff inválido"))
+		return err
+	}
+	if len(backoff) > 0 && !cmd.Flags().Changed("max-retries") {
+		opts.MaxRetries = len(backoff)
+	}
+	if opts.MaxRetries < len(backoff) {
+		return fmt.Errorf("valor inválido para --max-retries: %d é menor que os %d tiers de --backoff", opts.MaxRetries, len(backoff))
+	}
+	if backoffMultiplier != 0 && backoffMultiplier <= 1 {
+		return fmt.Errorf("valor inválido para --backoff-multiplier: %g (use um valor maior que 1)", backoffMultiplier)
+	}
+
+	opts.Backoff = backoff
+	opts.BackoffMultiplier = backoffMultiplier
+	opts.MaxRetryDelay = maxRetryDelay
+	opts.Backend = backend
+	return nil
+}
+
+// runRetrySetupWithPolicy configura o retry sem recriar a fila principal:
+// o dead-lettering vem de um policy aplicado via Management API
+func runRetrySetupWithPolicy(cfg *config.Config, setupOpts retry.SetupOptions, queueTypeChanged bool) error {
+	queueName := setupOpts.QueueName
+
+	vhost := cfg.RabbitMQ.VHost
+	if vhost == "" {
+		vhost = "/"
+	} else if vhost[0] != '/' {
+		vhost = "/" + vhost
+	}
+
+	fmt.Println(ui.SubMenuLoading("Conectando ao RabbitMQ"))
+	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao conectar"))
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	mainQueue, err := mgmtClient.GetQueue(vhost, queueName)
+	if err != nil {
+		// Fila ainda não existe: cria sem DLX, o policy cuida do dead-lettering
+		fmt.Println(ui.SubMenuLoading(fmt.Sprintf("Criando fila '%s'", queueName)))
+		if err := client.CreateQueue(rabbitmq.CreateQueueOptions{
+			Name:    queueName,
+			Type:    setupOpts.QueueType,
+			Durable: true,
+		}); err != nil {
+			fmt.Println(ui.SubMenuError("Erro ao criar fila"))
+			return fmt.Errorf("erro ao criar fila: %w", err)
+		}
+		mainQueue = &rabbitmq.QueueInfoManagement{Name: queueName, Type: setupOpts.QueueType}
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("Fila '%s' criada", queueName)))
+	} else if !queueTypeChanged && mainQueue.Type != "" {
+		// Filas auxiliares acompanham o tipo da fila existente
+		setupOpts.QueueType = mainQueue.Type
+	}
+
+	if err := retry.CheckRetryPolicy(mainQueue); err != nil {
+		fmt.Println(ui.SubMenuError("Policy não pode ser aplicado"))
+		return err
+	}
+	if err := checkRetryConfig(mgmtClient, vhost, setupOpts); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuSection("⚙", "Criando Componentes"))
+
+	if err := retry.SetupRetry(client, setupOpts); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao configurar retry"))
+		return fmt.Errorf("erro ao configurar retry: %w", err)
+	}
+	if setupOpts.Backend == retry.BackendDelayed {
+		fmt.Println(ui.SubMenuDone("Exchanges e DLQ criados"))
+	} else {
+		fmt.Println(ui.SubMenuDone(fmt.Sprintf("%d wait queue(s), exchanges e DLQ criados", setupOpts.MaxRetries)))
+	}
+
+	if err := retry.BindRetryExchange(client, queueName); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao configurar bindings"))
+		return err
+	}
+	fmt.Println(ui.SubMenuDone("Bindings configurados"))
+
+	fmt.Println(ui.SubMenuLoading(fmt.Sprintf("Aplicando policy '%s'", retry.RetryPolicyName(queueName))))
+	if err := retry.ApplyRetryPolicy(mgmtClient, vhost, mainQueue); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao aplicar policy"))
+		return err
+	}
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Policy aplicado; fila '%s' mantida com %d mensagem(ns)", queueName, mainQueue.MessagesReady)))
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone("Sistema de retry configurado com sucesso!"))
+	fmt.Println()
+
+	fmt.Println(ui.SubMenuSection("📝", "Próximos Passos"))
+	fmt.Print(ui.SubMenuList(append(retryNextSteps(setupOpts),
+		fmt.Sprintf("Consulte com 'gohop retry status %s'; o policy aparece como '%s'", queueName, retry.RetryPolicyName(queueName)),
+	), "•"))
 
 	return nil
 }
 
+// retryNextSteps orienta a implementação do consumer conforme o backend
+func retryNextSteps(opts retry.SetupOptions) []string {
+	delays := formatTierDelays(opts.TierDelays())
+	dlq := fmt.Sprintf("Depois da última tentativa a mensagem vai para '%s'", retry.DLQName(opts.QueueName))
+
+	if opts.Backend == retry.BackendDelayed {
+		return []string{
+			fmt.Sprintf("Em caso de falha, republique em '%s' com o header x-delay da tentativa (%s) e confirme a original", retry.RetryExchangeName(opts.QueueName), delays),
+			"Em Go, use pkg/consumer com Options.Delays iguais aos do setup",
+			"Rejeite (requeue=false) quando as tentativas acabarem",
+			dlq,
+		}
+	}
+
+	return []string{
+		"No consumer, rejeite mensagens com falha (nack/reject com requeue=false)",
+		"Em Go, use o pacote github.com/davioliveeira/gohop/pkg/consumer (ack, retry, DLQ e x-death prontos)",
+		fmt.Sprintf("O broker reenvia para '%s' até %d vezes (%s)", opts.QueueName, opts.MaxRetries, delays),
+		dlq,
+	}
+}
+
 func runRetryStatus(cmd *cobra.Command, args []string) error {
 	queueName := args[0]
 
@@ -222,9 +443,20 @@ func runRetryStatus(cmd *cobra.Command, args []string) error {
 		fmt.Print(ui.SubMenuKeyValue("Main Queue:", ui.SubMenuStatus("Não encontrada", "error"), false))
 	}
 
-	// Wait Queue
-	if retryInfo.WaitQueue {
-		fmt.Print(ui.SubMenuKeyValue("Wait Queue:", ui.SubMenuStatus(fmt.Sprintf("OK (%d msgs)", retryInfo.WaitQueueMsgs), "success"), false))
+	delayed := retryInfo.Backend == retry.BackendDelayed
+
+	// Wait Queues (um tier por tentativa); o backend delayed não usa wait queues
+	if delayed {
+		fmt.Print(ui.SubMenuKeyValue("Wait Queue:", ui.SubMenuStatus(fmt.Sprintf("não usada (%s)", retry.DelayedExchangeType), "info"), false))
+	} else if retryInfo.WaitQueue {
+		for _, tier := range retryInfo.Tiers {
+			status := "success"
+			if tier.Messages > 0 {
+				status = "warning"
+			}
+			fmt.Print(ui.SubMenuKeyValue(fmt.Sprintf("Wait #%d (%s):", tier.Attempt, retry.FormatDelay(tier.Delay)),
+				ui.SubMenuStatus(fmt.Sprintf("OK (%d msgs)", tier.Messages), status), false))
+		}
 	} else {
 		fmt.Print(ui.SubMenuKeyValue("Wait Queue:", ui.SubMenuStatus("Não encontrada", "error"), false))
 	}
@@ -236,6 +468,15 @@ func runRetryStatus(cmd *cobra.Command, args []string) error {
 		fmt.Print(ui.SubMenuKeyValue("Wait Exchange:", ui.SubMenuStatus("Não encontrado", "error"), false))
 	}
 
+	// First Attempt Exchange (alternate exchange do wait exchange, só no backend ttl)
+	if !delayed {
+		if retryInfo.FirstExchange {
+			fmt.Print(ui.SubMenuKeyValue("First Exchange:", ui.SubMenuStatus("OK", "success"), false))
+		} else {
+			fmt.Print(ui.SubMenuKeyValue("First Exchange:", ui.SubMenuStatus("Não encontrado", "error"), false))
+		}
+	}
+
 	// Retry Exchange
 	if retryInfo.RetryExchange {
 		fmt.Print(ui.SubMenuKeyValue("Retry Exchange:", ui.SubMenuStatus("OK", "success"), false))
@@ -257,16 +498,64 @@ func runRetryStatus(cmd *cobra.Command, args []string) error {
 		fmt.Print(ui.SubMenuKeyValue("DLQ:", ui.SubMenuStatus("Não encontrada", "error"), false))
 	}
 
+	// Bindings esperados entre exchanges e filas
+	if len(retryInfo.Bindings) > 0 {
+		fmt.Println(ui.SubMenuSection("🔗", "Bindings"))
+		rows := make([][]string, 0, len(retryInfo.Bindings))
+		for _, binding := range retryInfo.Bindings {
+			status := "✓ OK"
+			if !binding.Present {
+				status = "✗ AUSENTE"
+			}
+			rows = append(rows, []string{binding.Source, binding.RoutingKey, binding.Destination, status})
+		}
+		fmt.Print(ui.SubMenuTable([]string{"EXCHANGE", "ROUTING KEY", "DESTINO", "STATUS"}, rows))
+	}
+
 	// Configurações
 	fmt.Println(ui.SubMenuSection("⚙", "Configurações"))
+	fmt.Print(ui.SubMenuKeyValue("Backend:", retryInfo.Backend, false))
+	fmt.Print(ui.SubMenuKeyValue("Dead-lettering:", formatDLXSource(retryInfo), false))
 	fmt.Print(ui.SubMenuKeyValue("Max Retries:", strconv.Itoa(retryInfo.MaxRetries), false))
-	fmt.Print(ui.SubMenuKeyValue("Retry Delay:", fmt.Sprintf("%ds", retryInfo.RetryDelay), false))
-	fmt.Print(ui.SubMenuKeyValue("DLQ TTL:", fmt.Sprintf("%dms", retryInfo.DLQTTL), false))
+	if delayed && retryInfo.Metadata != nil {
+		fmt.Print(ui.SubMenuKeyValue("Backoff:", formatTierDelays(retryInfo.Metadata.Delays)+" (x-delay)", false))
+	} else if len(retryInfo.Tiers) > 0 {
+		tierDelays := make([]int, len(retryInfo.Tiers))
+		for i, tier := range retryInfo.Tiers {
+			tierDelays[i] = tier.Delay
+		}
+		fmt.Print(ui.SubMenuKeyValue("Backoff:", formatTierDelays(tierDelays), false))
+	} else {
+		fmt.Print(ui.SubMenuKeyValue("Retry Delay:", fmt.Sprintf("%ds", retryInfo.RetryDelay), false))
+	}
+	dlqTTL := "sem expiração"
+	if retryInfo.DLQTTL > 0 {
+		dlqTTL = fmt.Sprintf("%dms", retryInfo.DLQTTL)
+	}
+	fmt.Print(ui.SubMenuKeyValue("DLQ TTL:", dlqTTL, false))
+	if retryInfo.Metadata != nil {
+		fmt.Print(ui.SubMenuKeyValue("Metadata:", fmt.Sprintf("%s (%s)", retry.WaitExchangeName(queueName), retryInfo.Metadata.QueueType), false))
+	} else {
+		fmt.Print(ui.SubMenuKeyValue("Metadata:", ui.SubMenuStatus("ausente", "warning"), false))
+	}
+
+	// Divergências entre componentes e configuração
+	if len(retryInfo.Drift) > 0 {
+		fmt.Println(ui.SubMenuSection("⚠", "Divergências"))
+		fmt.Print(ui.SubMenuList(retryInfo.Drift, "•"))
+	}
 
 	// Resumo
 	fmt.Println()
-	if retryInfo.MainQueue && retryInfo.WaitQueue && retryInfo.WaitExchange && retryInfo.RetryExchange && retryInfo.DLQ {
+	complete := retryInfo.MainQueue && retryInfo.WaitQueue && retryInfo.WaitExchange && retryInfo.FirstExchange && retryInfo.RetryExchange && retryInfo.DLQ
+	if delayed {
+		complete = retryInfo.MainQueue && retryInfo.WaitExchange && retryInfo.RetryExchange && retryInfo.DLQ
+	}
+	if complete && len(retryInfo.Drift) == 0 {
 		fmt.Println(ui.SubMenuDone("Sistema de retry completo e funcionando"))
+	} else if complete {
+		fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Sistema de retry com %d divergência(s)", len(retryInfo.Drift))))
+		fmt.Println(ui.SubMenuHelp("Execute 'gohop retry setup --force' para reaplicar a configuração"))
 	} else {
 		fmt.Println(ui.SubMenuWarning("Sistema de retry incompleto"))
 		fmt.Println(ui.SubMenuHelp("Execute 'gohop retry setup' para configurar"))
@@ -274,3 +563,242 @@ func runRetryStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// formatDLXSource descreve de onde vem o DLX da fila principal
+func formatDLXSource(info *retry.RetrySystemInfo) string {
+	switch info.DLXSource {
+	case retry.DLXSourceArguments:
+		return "argumentos da fila (x-dead-letter-exchange)"
+	case retry.DLXSourcePolicy:
+		return fmt.Sprintf("policy '%s'", info.Policy)
+	}
+	return ui.SubMenuStatus("não configurado", "warning")
+}
+
+// checkRetryConfig recusa, sem --force, aplicar outra configuração a um retry
+// existente: o broker aceitaria a redeclaração mantendo a metadata antiga
+func checkRetryConfig(mgmtClient *rabbitmq.ManagementClient, vhost string, setupOpts retry.SetupOptions) error {
+	if setupOpts.Force {
+		return nil
+	}
+	if err := retry.CheckExistingConfig(mgmtClient, vhost, setupOpts); err != nil {
+		fmt.Println(ui.SubMenuError("Retry já configurado com outra configuração"))
+		return err
+	}
+	return nil
+}
+
+// formatTierDelays formata os delays dos tiers ("5s → 30s → 5m")
+func formatTierDelays(delays []int) string {
+	if len(delays) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(delays))
+	for i, delay := range delays {
+		parts[i] = retry.FormatDelay(delay)
+	}
+	return strings.Join(parts, " → ")
+}
+
+func runRetryRemove(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+
+	fmt.Print(ui.SubMenuHeader("🧹", "Remover Retry", fmt.Sprintf("Removendo sistema de retry de '%s'", queueName)))
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao carregar configuração"))
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	dlqAction, _ := cmd.Flags().GetString("dlq")
+	exportFile, _ := cmd.Flags().GetString("export-file")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	resume, _ := cmd.Flags().GetBool("resume")
+
+	if dlqAction != "" && !retry.ValidDLQAction(dlqAction) {
+		return fmt.Errorf("valor inválido para --dlq: %s (use keep, move ou export)", dlqAction)
+	}
+
+	vhost := cfg.RabbitMQ.VHost
+	if vhost == "" {
+		vhost = "/"
+	} else if vhost[0] != '/' {
+		vhost = "/" + vhost
+	}
+	dir := retry.RemoveDir()
+
+	if resume {
+		journal, err := retry.LoadRemoveJournal(dir, vhost, queueName)
+		if err != nil {
+			fmt.Println(ui.SubMenuError("Nenhuma remoção pendente"))
+			return err
+		}
+
+		done := make([]string, len(journal.Steps))
+		for i, step := range journal.Steps {
+			done[i] = step.Title()
+		}
+		if len(done) == 0 {
+			done = []string{"nenhuma"}
+		}
+		fmt.Print(ui.SubMenuKeyValue("Iniciada em:", journal.StartedAt.Format("2006-01-02 15:04:05"), true))
+		fmt.Print(ui.SubMenuKeyValue("Etapas concluídas:", strings.Join(done, ", "), false))
+		fmt.Print(ui.SubMenuKeyValue("Spool:", fmt.Sprintf("%s (%d msgs)", journal.SpoolPath(), journal.Spooled), false))
+		fmt.Println()
+
+		return executeRetryRemove(cfg.RabbitMQ, journal)
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	mainQueue, err := mgmtClient.GetQueue(vhost, queueName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Fila não encontrada"))
+		return fmt.Errorf("fila não encontrada: %s", queueName)
+	}
+
+	retryInfo, err := retry.GetRetrySystemInfo(mgmtClient, cfg.RabbitMQ, queueName)
+	if err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao obter informações"))
+		return fmt.Errorf("erro ao obter informações: %w", err)
+	}
+
+	if !retryInfo.WaitQueue && !retryInfo.WaitExchange && !retryInfo.RetryExchange && !retryInfo.DLQ {
+		fmt.Println(ui.SubMenuWarning("Fila não tem sistema de retry configurado"))
+		return nil
+	}
+
+	// Situação atual
+	fmt.Println(ui.SubMenuSection("📊", "Situação Atual"))
+	fmt.Print(ui.SubMenuKeyValue("Fila principal:", fmt.Sprintf("%d msgs", mainQueue.MessagesReady), true))
+	if retryInfo.Backend == retry.BackendDelayed {
+		fmt.Print(ui.SubMenuKeyValue("Retry exchange:", retry.DelayedExchangeType, false))
+	} else {
+		fmt.Print(ui.SubMenuKeyValue("Wait queues:", fmt.Sprintf("%d (%d msgs aguardando retry)", len(retryInfo.Tiers), retryInfo.WaitQueueMsgs), false))
+	}
+	if retryInfo.DLQ {
+		fmt.Print(ui.SubMenuKeyValue("DLQ:", fmt.Sprintf("%d msgs", retryInfo.DLQMsgs), retryInfo.DLQMsgs > 0))
+	}
+	fmt.Println()
+
+	// Retry via policy: a fila principal não precisa ser recriada
+	byPolicy := retryInfo.DLXSource == retry.DLXSourcePolicy
+	if byPolicy && retryInfo.Policy != retry.RetryPolicyName(queueName) {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("O DLX vem do policy '%s', que não foi criado pelo gohop", retryInfo.Policy)))
+		return fmt.Errorf("remova \"dead-letter-exchange\" do policy '%s' antes de remover o retry", retryInfo.Policy)
+	}
+
+	// O plugin guarda as mensagens agendadas no próprio exchange, fora de qualquer fila
+	if retryInfo.Backend == retry.BackendDelayed {
+		fmt.Println(ui.SubMenuWarning(fmt.Sprintf("Mensagens aguardando x-delay em '%s' serão perdidas ao remover o exchange; pare os consumers e aguarde o maior delay", retry.RetryExchangeName(queueName))))
+		fmt.Println()
+	}
+
+	// Um consumer rejeitando mensagens depois que os exchanges saem as perderia,
+	// e a fila não pode ser removida enquanto ele segura mensagens
+	if mainQueue.Consumers > 0 && !byPolicy {
+		fmt.Println(ui.SubMenuError(fmt.Sprintf("A fila tem %d consumer(s) ativo(s)", mainQueue.Consumers)))
+		return fmt.Errorf("pare os consumers de '%s' antes de remover o retry", queueName)
+	}
+
+	// Perguntar o que fazer com a DLQ
+	if dlqAction == "" {
+		dlqAction = retry.DLQKeep
+		if retryInfo.DLQ && retryInfo.DLQMsgs > 0 {
+			dlqForm := huh.NewForm(
+				huh.NewGroup(
+					huh.NewSelect[string]().
+						Title(fmt.Sprintf("📭 A DLQ tem %d mensagem(ns). O que fazer?", retryInfo.DLQMsgs)).
+						Options(
+							huh.NewOption("Manter a DLQ como fila comum", retry.DLQKeep),
+							huh.NewOption("Devolver para a fila principal", retry.DLQMove),
+							huh.NewOption("Exportar para arquivo e remover a DLQ", retry.DLQExport),
+						).
+						Value(&dlqAction),
+				),
+			)
+			dlqForm.WithTheme(ui.GetCharmTheme())
+
+			if err := dlqForm.Run(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if dlqAction == retry.DLQExport && exportFile == "" {
+		exportFile = retry.DLQName(queueName) + ".ndjson"
+	}
+
+	if !skipConfirm {
+		description := fmt.Sprintf("A fila '%s' será recriada sem Dead Letter Exchange", queueName)
+		if byPolicy {
+			description = fmt.Sprintf("O policy '%s' será removido; a fila '%s' será mantida", retryInfo.Policy, queueName)
+		}
+
+		var confirm bool
+		confirmForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("⚠️  Remover sistema de retry?").
+					Description(description).
+					Value(&confirm),
+			),
+		)
+		confirmForm.WithTheme(ui.GetCharmTheme())
+
+		if err := confirmForm.Run(); err != nil {
+			return err
+		}
+
+		if !confirm {
+			fmt.Println(ui.SubMenuError("Operação cancelada"))
+			return nil
+		}
+	}
+
+	journal, err := retry.StartRemove(dir, vhost, mainQueue, retryInfo, dlqAction, exportFile)
+	if err != nil {
+		return err
+	}
+
+	return executeRetryRemove(cfg.RabbitMQ, journal)
+}
+
+// executeRetryRemove executa as etapas pendentes da remoção mostrando o andamento
+func executeRetryRemove(cfg config.RabbitMQConfig, journal *retry.RemoveJournal) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Println(ui.SubMenuSection("⚙", "Removendo"))
+	hooks := retry.RemoveHooks{
+		OnStep: func(step retry.RemoveStep, index int) {
+			fmt.Println()
+			fmt.Println(ui.SubMenuLoading(step.Title()))
+		},
+		OnProgress: func(step retry.RemoveStep, count int) {
+			fmt.Printf("\r  ⏳ %d mensagem(ns)", count)
+		},
+	}
+
+	if err := retry.RunRemove(ctx, cfg, journal, hooks); err != nil {
+		fmt.Println()
+		fmt.Println(ui.SubMenuError("Remoção interrompida"))
+		fmt.Println(ui.SubMenuInfo(fmt.Sprintf("As mensagens estão salvas em %s", journal.SpoolPath())))
+		fmt.Println(ui.SubMenuInfo("Para concluir, repita o comando com --resume"))
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Sistema de retry removido com sucesso! (%d mensagem(ns) republicada(s))", journal.Spooled)))
+	printUserIdDropped(journal.UserIdDropped)
+	if journal.DLQ {
+		switch journal.DLQAction {
+		case retry.DLQKeep:
+			fmt.Println(ui.SubMenuInfo(fmt.Sprintf("A DLQ '%s' foi mantida como fila comum", retry.DLQName(journal.Queue))))
+		case retry.DLQExport:
+			fmt.Println(ui.SubMenuInfo(fmt.Sprintf("%d mensagem(ns) da DLQ exportada(s) para %s", journal.Exported, journal.ExportFile)))
+		}
+	}
+
+	return nil
+}
@@ -46,7 +46,14 @@ func init() {
 	// Comandos filhos
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(queueCmd)
+	rootCmd.AddCommand(exchangeCmd)
+	rootCmd.AddCommand(bindingCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(topologyCmd)
+	rootCmd.AddCommand(definitionsCmd)
 	rootCmd.AddCommand(retryCmd)
+	rootCmd.AddCommand(dlqCmd)
+	rootCmd.AddCommand(messageCmd)
 	rootCmd.AddCommand(monitorCmd)
 
 	// Customização do help será feita via Glamour (a implementar)
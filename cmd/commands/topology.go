@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/topology"
+	"github.com/davioliveeira/gohop/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var topologyCmd = &cobra.Command{
+	Use:   "topology",
+	Short: "Exportar a topologia de um vhost",
+	Long:  "Comandos para trabalhar com a topologia declarativa consumida por 'gohop apply'",
+}
+
+var topologyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exportar filas, exchanges, bindings e policies em YAML",
+	Long: `Lê o vhost pela Management API e gera o YAML no formato de 'gohop apply'.
+
+Sistemas de retry do gohop (wait queues, wait/first/retry exchanges, DLQ e
+policy) viram um bloco retry: na fila principal. Filas exclusivas e os
+exchanges amq.* ficam de fora; filas stream são ignoradas com um aviso.
+
+Sem --file, o YAML vai para a saída padrão e os avisos para stderr.
+
+Exemplos:
+  gohop topology export > topology.yaml
+  gohop topology export -f topology.yaml --vhost /prod
+  gohop apply -f topology.yaml --plan  # Deve mostrar nenhuma mudança`,
+	RunE: runTopologyExport,
+}
+
+func init() {
+	topologyExportCmd.Flags().StringP("file", "f", "", "Arquivo de saída (padrão: saída padrão)")
+	topologyExportCmd.Flags().String("vhost", "", "VHost a exportar (padrão: o do perfil)")
+
+	topologyCmd.AddCommand(topologyExportCmd)
+}
+
+func runTopologyExport(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	vhost, _ := cmd.Flags().GetString("vhost")
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	if vhost != "" {
+		cfg.RabbitMQ.VHost = vhost
+	}
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	spec, warnings, err := topology.ExportSpec(mgmtClient, cfg.RabbitMQ)
+	if err != nil {
+		return err
+	}
+
+	data, err := spec.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "aviso: %s\n", warning)
+		}
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	fmt.Print(ui.SubMenuHeader("📐", "Exportar Topologia", fmt.Sprintf("vhost '%s'", spec.VHost)))
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		fmt.Println(ui.SubMenuError("Erro ao gravar o arquivo"))
+		return fmt.Errorf("erro ao gravar %s: %w", file, err)
+	}
+
+	retries := 0
+	for _, q := range spec.Queues {
+		if q.Retry != nil {
+			retries++
+		}
+	}
+	fmt.Print(ui.SubMenuKeyValue("Exchanges:", fmt.Sprintf("%d", len(spec.Exchanges)), false))
+	fmt.Print(ui.SubMenuKeyValue("Filas:", fmt.Sprintf("%d", len(spec.Queues)), false))
+	fmt.Print(ui.SubMenuKeyValue("Com retry:", fmt.Sprintf("%d", retries), false))
+	fmt.Print(ui.SubMenuKeyValue("Bindings:", fmt.Sprintf("%d", len(spec.Bindings)), false))
+	fmt.Print(ui.SubMenuKeyValue("Policies:", fmt.Sprintf("%d", len(spec.Policies)), false))
+	fmt.Println()
+
+	for _, warning := range warnings {
+		fmt.Println(ui.SubMenuWarning(warning))
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+	}
+
+	fmt.Println(ui.SubMenuDone(fmt.Sprintf("Topologia exportada para %s", file)))
+	fmt.Println(ui.SubMenuHelp(fmt.Sprintf("Use 'gohop apply -f %s --plan' para comparar com o vhost", file)))
+	return nil
+}
@@ -0,0 +1,78 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tipos de destino de um binding
+const (
+	DestinationQueue    = "queue"
+	DestinationExchange = "exchange"
+)
+
+// BindingOptions descrevem um binding de um exchange para uma fila ou outro exchange
+type BindingOptions struct {
+	Source          string
+	Destination     string
+	DestinationType string // queue (padrão) ou exchange
+	RoutingKey      string
+	Arguments       map[string]interface{} // Em exchanges headers: os headers e o x-match
+}
+
+// Validate verifica se o binding pode ser declarado
+func (o BindingOptions) Validate() error {
+	if o.Source == "" {
+		return fmt.Errorf("o default exchange não aceita bindings explícitos")
+	}
+	if o.Destination == "" {
+		return fmt.Errorf("destino do binding é obrigatório")
+	}
+	if o.DestinationType != DestinationQueue && o.DestinationType != DestinationExchange {
+		return fmt.Errorf("tipo de destino inválido: %s (use queue ou exchange)", o.DestinationType)
+	}
+	return nil
+}
+
+// Matches indica se o binding existente corresponde às opções: mesma origem,
+// destino, routing key e argumentos
+func (o BindingOptions) Matches(b BindingInfoManagement) bool {
+	if b.Source != o.Source || b.Destination != o.Destination || b.DestinationType != o.DestinationType || b.RoutingKey != o.RoutingKey {
+		return false
+	}
+	return ArgumentsEqual(o.Arguments, b.Arguments)
+}
+
+// BindingFilter seleciona bindings por origem e destino (vazio = qualquer um)
+type BindingFilter struct {
+	Source      string
+	Destination string
+	// IncludeDefault inclui os bindings implícitos do default exchange
+	IncludeDefault bool
+}
+
+// Apply retorna os bindings que casam com o filtro, na ordem original
+func (f BindingFilter) Apply(bindings []BindingInfoManagement) []BindingInfoManagement {
+	var result []BindingInfoManagement
+	for _, b := range bindings {
+		if b.Source == "" && !f.IncludeDefault {
+			continue
+		}
+		if f.Source != "" && b.Source != f.Source {
+			continue
+		}
+		if f.Destination != "" && b.Destination != f.Destination {
+			continue
+		}
+		result = append(result, b)
+	}
+	return result
+}
+
+// destinationCode é o segmento da API para o tipo de destino ("q" ou "e")
+func destinationCode(destinationType string) string {
+	if strings.EqualFold(destinationType, DestinationExchange) {
+		return "e"
+	}
+	return "q"
+}
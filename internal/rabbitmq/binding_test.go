@@ -0,0 +1,64 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindingOptions_Validate(t *testing.T) {
+	assert.NoError(t, BindingOptions{Source: "orders.events", Destination: "orders", DestinationType: DestinationQueue}.Validate())
+	assert.NoError(t, BindingOptions{Source: "orders.events", Destination: "audit", DestinationType: DestinationExchange}.Validate())
+	assert.Error(t, BindingOptions{Source: "", Destination: "orders", DestinationType: DestinationQueue}.Validate())
+	assert.Error(t, BindingOptions{Source: "orders.events", DestinationType: DestinationQueue}.Validate())
+	assert.Error(t, BindingOptions{Source: "orders.events", Destination: "orders", DestinationType: "stream"}.Validate())
+}
+
+func TestBindingOptions_Matches(t *testing.T) {
+	opts := BindingOptions{
+		Source:          "orders.headers",
+		Destination:     "orders",
+		DestinationType: DestinationQueue,
+		Arguments:       map[string]interface{}{"x-match": "all", "priority": int64(5)},
+	}
+	binding := BindingInfoManagement{
+		Source:          "orders.headers",
+		Destination:     "orders",
+		DestinationType: "queue",
+		Arguments:       map[string]interface{}{"x-match": "all", "priority": float64(5)},
+	}
+	assert.True(t, opts.Matches(binding))
+
+	other := binding
+	other.RoutingKey = "orders.*"
+	assert.False(t, opts.Matches(other))
+
+	other = binding
+	other.Arguments = map[string]interface{}{"x-match": "any", "priority": float64(5)}
+	assert.False(t, opts.Matches(other))
+
+	other = binding
+	other.DestinationType = "exchange"
+	assert.False(t, opts.Matches(other))
+}
+
+func TestBindingFilter_Apply(t *testing.T) {
+	bindings := []BindingInfoManagement{
+		{Source: "", Destination: "orders", DestinationType: "queue", RoutingKey: "orders"},
+		{Source: "orders.events", Destination: "orders", DestinationType: "queue", RoutingKey: "orders.*"},
+		{Source: "orders.events", Destination: "audit", DestinationType: "exchange", RoutingKey: "#"},
+		{Source: "billing.events", Destination: "orders", DestinationType: "queue"},
+	}
+
+	assert.Len(t, BindingFilter{}.Apply(bindings), 3)
+	assert.Len(t, BindingFilter{IncludeDefault: true}.Apply(bindings), 4)
+	assert.Len(t, BindingFilter{Source: "orders.events"}.Apply(bindings), 2)
+
+	toOrders := BindingFilter{Destination: "orders"}.Apply(bindings)
+	assert.Len(t, toOrders, 2)
+	assert.Equal(t, "orders.events", toOrders[0].Source)
+
+	both := BindingFilter{Source: "orders.events", Destination: "audit"}.Apply(bindings)
+	assert.Len(t, both, 1)
+	assert.Equal(t, "exchange", both[0].DestinationType)
+}
@@ -97,6 +97,11 @@ func (c *Client) GetConnection() *amqp.Connection {
 	return c.conn
 }
 
+// GetUsername retorna o usuário da conexão (o único user-id aceito nas publicações)
+func (c *Client) GetUsername() string {
+	return c.config.Username
+}
+
 // IsConnected verifica se está conectado
 func (c *Client) IsConnected() bool {
 	return c.conn != nil && !c.conn.IsClosed()
@@ -0,0 +1,269 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Padrões do pipeline de confirmações
+const (
+	defaultConfirmWindow  = 256
+	defaultConfirmRetries = 3
+)
+
+// PipelineOptions são opções do pipeline de publicações confirmadas
+type PipelineOptions struct {
+	Window  int           // Máximo de publicações aguardando confirmação (padrão: 256)
+	Retries int           // Reenvios de uma mensagem recusada (NACK) ou sem rota (padrão: 3)
+	Timeout time.Duration // Timeout de cada confirmação (padrão: 30s)
+	User    string        // Usuário da conexão: outro user-id é removido (veja fitUserId)
+}
+
+// PublishStats resume as publicações de um pipeline
+type PublishStats struct {
+	Confirmed int           // Mensagens confirmadas e roteadas pelo broker
+	Retried   int           // Reenvios após NACK ou basic.return
+	Elapsed   time.Duration // Tempo desde a criação do pipeline
+
+	UserIdDropped int // Mensagens publicadas sem o user-id original (veja fitUserId)
+}
+
+// Rate retorna a vazão em mensagens confirmadas por segundo
+func (s PublishStats) Rate() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Confirmed) / s.Elapsed.Seconds()
+}
+
+// pipelineItem é uma publicação aguardando confirmação
+type pipelineItem struct {
+	exchange     string
+	routingKey   string
+	msg          amqp.Publishing
+	onConfirm    func() error
+	attempts     int
+	confirmation *amqp.DeferredConfirmation
+	returned     *amqp.Return
+}
+
+// ConfirmPipeline publica com mandatory=true mantendo até Window mensagens
+// em voo, em vez de aguardar a confirmação de cada uma antes da próxima.
+//
+// Cada publicação é correlacionada pela delivery tag da sua confirmação. O
+// broker envia o basic.return antes do ack da mesma mensagem, então uma
+// devolução é atribuída à publicação pendente com o mesmo destino e conteúdo.
+// Mensagens recusadas ou devolvidas são reenviadas até Retries vezes.
+//
+// As confirmações são processadas na ordem de publicação: o onConfirm de uma
+// mensagem só é chamado depois do de todas as anteriores. Não é seguro para
+// uso concorrente.
+type ConfirmPipeline struct {
+	channel *amqp.Channel
+	returns chan amqp.Return
+	opts    PipelineOptions
+	pending []*pipelineItem
+	stats   PublishStats
+	started time.Time
+}
+
+// NewConfirmPipeline coloca o canal em modo confirm e cria o pipeline.
+// O canal deve ser usado para publicar apenas pelo pipeline.
+func NewConfirmPipeline(channel *amqp.Channel, opts PipelineOptions) (*ConfirmPipeline, error) {
+	if opts.Window <= 0 {
+		opts.Window = defaultConfirmWindow
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = defaultConfirmRetries
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		return nil, fmt.Errorf("erro ao habilitar modo de confirmação: %w", err)
+	}
+
+	return &ConfirmPipeline{
+		channel: channel,
+		returns: channel.NotifyReturn(make(chan amqp.Return, opts.Window)),
+		opts:    opts,
+		started: time.Now(),
+	}, nil
+}
+
+// Publish envia a mensagem sem aguardar a confirmação; bloqueia apenas quando
+// a janela está cheia. onConfirm (opcional) é chamado quando o broker
+// confirma a mensagem; um erro retornado por ele interrompe o pipeline.
+func (p *ConfirmPipeline) Publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing, onConfirm func() error) error {
+	for len(p.pending) >= p.opts.Window {
+		if err := p.settle(ctx); err != nil {
+			return err
+		}
+	}
+
+	if fitUserId(&msg, p.opts.User) {
+		p.stats.UserIdDropped++
+	}
+
+	item := &pipelineItem{exchange: exchange, routingKey: routingKey, msg: msg, onConfirm: onConfirm}
+	if err := p.send(ctx, item); err != nil {
+		return err
+	}
+	p.pending = append(p.pending, item)
+	p.drainReturns()
+
+	// Processa sem bloquear o que já foi confirmado, liberando os callbacks cedo
+	for len(p.pending) > 0 && isDone(p.pending[0].confirmation) {
+		if err := p.settle(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fitUserId mantém o user-id da mensagem só se ele for o usuário da conexão.
+// O broker recusa um user-id diferente com PRECONDITION_FAILED e fecha o
+// canal: a republicação pararia sempre na mesma mensagem, inclusive ao
+// retomar. Retorna true se o user-id foi removido.
+func fitUserId(msg *amqp.Publishing, user string) bool {
+	if msg.UserId == "" || msg.UserId == user {
+		return false
+	}
+	msg.UserId = ""
+	return true
+}
+
+// Flush aguarda a confirmação de todas as mensagens em voo
+func (p *ConfirmPipeline) Flush(ctx context.Context) error {
+	for len(p.pending) > 0 {
+		if err := p.settle(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending retorna quantas mensagens aguardam confirmação
+func (p *ConfirmPipeline) Pending() int {
+	return len(p.pending)
+}
+
+// Stats retorna o resumo das publicações até o momento
+func (p *ConfirmPipeline) Stats() PublishStats {
+	stats := p.stats
+	stats.Elapsed = time.Since(p.started)
+	return stats
+}
+
+// send publica (ou republica) o item e registra a nova confirmação
+func (p *ConfirmPipeline) send(ctx context.Context, item *pipelineItem) error {
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(ctx, item.exchange, item.routingKey, true, false, item.msg)
+	if err != nil {
+		return fmt.Errorf("erro ao publicar: %w", err)
+	}
+	item.attempts++
+	item.confirmation = confirmation
+	item.returned = nil
+	return nil
+}
+
+// settle aguarda a confirmação da mensagem mais antiga em voo e a conclui,
+// reenviando-a se foi recusada ou devolvida
+func (p *ConfirmPipeline) settle(ctx context.Context) error {
+	head := p.pending[0]
+
+	timer := time.NewTimer(p.opts.Timeout)
+	defer timer.Stop()
+
+	for !isDone(head.confirmation) {
+		select {
+		case ret, ok := <-p.returns:
+			if !ok {
+				p.returns = nil
+				continue
+			}
+			p.markReturned(ret)
+		case <-head.confirmation.Done():
+		case <-timer.C:
+			return fmt.Errorf("timeout aguardando confirmação (delivery tag %d)", head.confirmation.DeliveryTag)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// O basic.return da mensagem chega antes do seu ack: já está no buffer
+	p.drainReturns()
+
+	if head.confirmation.Acked() && head.returned == nil {
+		p.pending = p.pending[1:]
+		p.stats.Confirmed++
+		if head.onConfirm != nil {
+			return head.onConfirm()
+		}
+		return nil
+	}
+
+	reason := "mensagem não foi confirmada pelo broker (NACK)"
+	if head.returned != nil {
+		reason = fmt.Sprintf("mensagem sem rota para exchange '%s' com routing key '%s': %s",
+			head.exchange, head.routingKey, head.returned.ReplyText)
+	}
+	if head.attempts > p.opts.Retries {
+		return fmt.Errorf("%s (após %d tentativa(s))", reason, head.attempts)
+	}
+
+	p.stats.Retried++
+	return p.send(ctx, head)
+}
+
+// drainReturns processa as devoluções já recebidas, sem bloquear
+func (p *ConfirmPipeline) drainReturns() {
+	for {
+		select {
+		case ret, ok := <-p.returns:
+			if !ok {
+				p.returns = nil
+				return
+			}
+			p.markReturned(ret)
+		default:
+			return
+		}
+	}
+}
+
+// markReturned atribui a devolução à publicação pendente mais antiga com o
+// mesmo destino e conteúdo. Mensagens idênticas são intercambiáveis: reenviar
+// qualquer uma delas tem o mesmo efeito.
+func (p *ConfirmPipeline) markReturned(ret amqp.Return) {
+	for _, item := range p.pending {
+		if item.returned == nil && returnMatches(item, ret) {
+			item.returned = &ret
+			return
+		}
+	}
+}
+
+// returnMatches verifica se a devolução corresponde à publicação
+func returnMatches(item *pipelineItem, ret amqp.Return) bool {
+	return item.exchange == ret.Exchange &&
+		item.routingKey == ret.RoutingKey &&
+		item.msg.MessageId == ret.MessageId &&
+		item.msg.CorrelationId == ret.CorrelationId &&
+		bytes.Equal(item.msg.Body, ret.Body)
+}
+
+// isDone indica se a confirmação já chegou (ack ou nack)
+func isDone(confirmation *amqp.DeferredConfirmation) bool {
+	select {
+	case <-confirmation.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmPipeline_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_pipeline_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	channel, err := client.GetConnection().Channel()
+	require.NoError(t, err)
+	defer channel.Close()
+
+	pipeline, err := NewConfirmPipeline(channel, PipelineOptions{Window: 50})
+	require.NoError(t, err)
+
+	// Os callbacks seguem a ordem de publicação
+	var confirmed []int
+	for i := 0; i < 1000; i++ {
+		i := i
+		err := pipeline.Publish(context.Background(), "", queueName, amqp.Publishing{Body: []byte(fmt.Sprint(i))}, func() error {
+			confirmed = append(confirmed, i)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, pipeline.Pending(), 50)
+	}
+	require.NoError(t, pipeline.Flush(context.Background()))
+
+	require.Len(t, confirmed, 1000)
+	for i, n := range confirmed {
+		require.Equal(t, i, n)
+	}
+	stats := pipeline.Stats()
+	assert.Equal(t, 1000, stats.Confirmed)
+	assert.Equal(t, 0, stats.Retried)
+	assert.Greater(t, stats.Rate(), 0.0)
+
+	time.Sleep(500 * time.Millisecond)
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, info.Messages)
+
+	// Sem rota: reenvia até Retries vezes e então falha
+	err = pipeline.Publish(context.Background(), "", queueName+".inexistente", amqp.Publishing{Body: []byte("x")}, nil)
+	require.NoError(t, err)
+	err = pipeline.Flush(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sem rota")
+	assert.Equal(t, defaultConfirmRetries, pipeline.Stats().Retried)
+}
@@ -0,0 +1,66 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishStats_Rate(t *testing.T) {
+	assert.Equal(t, 0.0, PublishStats{Confirmed: 10}.Rate())
+	assert.Equal(t, 500.0, PublishStats{Confirmed: 1000, Elapsed: 2 * time.Second}.Rate())
+}
+
+func TestFitUserId(t *testing.T) {
+	msg := amqp.Publishing{UserId: "test_user"}
+	assert.False(t, fitUserId(&msg, "test_user"))
+	assert.Equal(t, "test_user", msg.UserId, "o usuário da conexão é mantido")
+
+	msg = amqp.Publishing{UserId: "guest"}
+	assert.True(t, fitUserId(&msg, "test_user"))
+	assert.Empty(t, msg.UserId, "o broker recusaria outro usuário")
+
+	msg = amqp.Publishing{}
+	assert.False(t, fitUserId(&msg, "test_user"))
+}
+
+func TestConfirmPipeline_MarkReturned(t *testing.T) {
+	msg := func(id, body string) amqp.Publishing {
+		return amqp.Publishing{MessageId: id, Body: []byte(body)}
+	}
+	first := &pipelineItem{exchange: "orders", routingKey: "created", msg: msg("1", "a")}
+	same := &pipelineItem{exchange: "orders", routingKey: "created", msg: msg("1", "a")}
+	other := &pipelineItem{exchange: "orders", routingKey: "created", msg: msg("2", "b")}
+	p := &ConfirmPipeline{pending: []*pipelineItem{first, other, same}}
+
+	ret := amqp.Return{Exchange: "orders", RoutingKey: "created", MessageId: "2", Body: []byte("b"), ReplyText: "NO_ROUTE"}
+	p.markReturned(ret)
+	assert.Nil(t, first.returned)
+	assert.NotNil(t, other.returned)
+
+	// Devoluções de mensagens idênticas vão para as pendentes mais antigas primeiro
+	dup := amqp.Return{Exchange: "orders", RoutingKey: "created", MessageId: "1", Body: []byte("a")}
+	p.markReturned(dup)
+	assert.NotNil(t, first.returned)
+	assert.Nil(t, same.returned)
+	p.markReturned(dup)
+	assert.NotNil(t, same.returned)
+
+	// Sem correspondente: ignorada
+	p.markReturned(amqp.Return{Exchange: "orders", RoutingKey: "other", MessageId: "1", Body: []byte("a")})
+}
+
+func TestReturnMatches(t *testing.T) {
+	item := &pipelineItem{
+		exchange:   "",
+		routingKey: "orders",
+		msg:        amqp.Publishing{MessageId: "m1", CorrelationId: "c1", Body: []byte("x")},
+	}
+
+	assert.True(t, returnMatches(item, amqp.Return{RoutingKey: "orders", MessageId: "m1", CorrelationId: "c1", Body: []byte("x")}))
+	assert.False(t, returnMatches(item, amqp.Return{RoutingKey: "orders", MessageId: "m1", CorrelationId: "c1", Body: []byte("y")}))
+	assert.False(t, returnMatches(item, amqp.Return{RoutingKey: "orders", MessageId: "m2", CorrelationId: "c1", Body: []byte("x")}))
+	assert.False(t, returnMatches(item, amqp.Return{Exchange: "amq.direct", RoutingKey: "orders", MessageId: "m1", CorrelationId: "c1", Body: []byte("x")}))
+}
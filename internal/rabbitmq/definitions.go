@@ -0,0 +1,311 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Definitions é o formato de /api/definitions, usado para backup e migração
+// do broker. Os itens de cada seção ficam como vieram da API, para que
+// campos que o gohop não conhece sobrevivam ao export/import.
+type Definitions struct {
+	RabbitVersion    string       `json:"rabbit_version,omitempty"`
+	RabbitMQVersion  string       `json:"rabbitmq_version,omitempty"`
+	ProductName      string       `json:"product_name,omitempty"`
+	ProductVersion   string       `json:"product_version,omitempty"`
+	Users            []Definition `json:"users,omitempty"`
+	VHosts           []Definition `json:"vhosts,omitempty"`
+	Permissions      []Definition `json:"permissions,omitempty"`
+	TopicPermissions []Definition `json:"topic_permissions,omitempty"`
+	Parameters       []Definition `json:"parameters,omitempty"`
+	GlobalParameters []Definition `json:"global_parameters,omitempty"`
+	Policies         []Definition `json:"policies,omitempty"`
+	Queues           []Definition `json:"queues,omitempty"`
+	Exchanges        []Definition `json:"exchanges,omitempty"`
+	Bindings         []Definition `json:"bindings,omitempty"`
+}
+
+// Definition é um item de uma seção das definições (usuário, fila, binding...)
+type Definition map[string]interface{}
+
+// Field retorna um campo texto do item (vazio se ausente)
+func (d Definition) Field(key string) string {
+	value, _ := d[key].(string)
+	return value
+}
+
+// DefinitionCount é o número de itens de uma seção
+type DefinitionCount struct {
+	Section string
+	Count   int
+}
+
+// definitionSection descreve uma seção: os campos obrigatórios, os que
+// identificam um item (para achar duplicados e mesclar arquivos) e se os
+// itens pertencem a um vhost
+type definitionSection struct {
+	name     string
+	items    *[]Definition
+	required []string
+	key      []string
+	perVHost bool
+}
+
+func (d *Definitions) sections() []definitionSection {
+	return []definitionSection{
+		{"users", &d.Users, []string{"name"}, []string{"name"}, false},
+		{"vhosts", &d.VHosts, []string{"name"}, []string{"name"}, false},
+		{"permissions", &d.Permissions, []string{"user", "vhost"}, []string{"user", "vhost"}, false},
+		{"topic_permissions", &d.TopicPermissions, []string{"user", "vhost", "exchange"}, []string{"user", "vhost", "exchange"}, false},
+		{"parameters", &d.Parameters, []string{"component", "name"}, []string{"vhost", "component", "name"}, true},
+		{"global_parameters", &d.GlobalParameters, []string{"name"}, []string{"name"}, false},
+		{"policies", &d.Policies, []string{"name", "pattern"}, []string{"vhost", "name"}, true},
+		{"queues", &d.Queues, []string{"name"}, []string{"vhost", "name"}, true},
+		{"exchanges", &d.Exchanges, []string{"name", "type"}, []string{"vhost", "name"}, true},
+		{"bindings", &d.Bindings, []string{"destination", "destination_type"}, []string{"vhost", "source", "destination", "destination_type", "routing_key", "arguments"}, true},
+	}
+}
+
+// LoadDefinitions lê um arquivo de definições (JSON)
+func LoadDefinitions(path string) (*Definitions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+
+	var defs Definitions
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("arquivo de definições inválido %s: %w", path, err)
+	}
+	return &defs, nil
+}
+
+// Validate verifica campos obrigatórios, itens duplicados e valores que o
+// broker recusaria. vhost é o destino do import: vazio (broker inteiro)
+// exige o campo vhost nos itens de filas, exchanges, bindings, policies e
+// parâmetros.
+func (d *Definitions) Validate(vhost string) error {
+	for _, section := range d.sections() {
+		seen := map[string]bool{}
+		for i, item := range *section.items {
+			for _, field := range section.required {
+				if _, ok := item[field]; !ok {
+					return fmt.Errorf("%s[%d]: campo %s ausente", section.name, i, field)
+				}
+			}
+			if section.perVHost && vhost == "" && item.Field("vhost") == "" {
+				return fmt.Errorf("%s[%d]: campo vhost ausente (use --vhost para importar definições de um vhost)", section.name, i)
+			}
+
+			key := section.itemKey(item)
+			if seen[key] {
+				return fmt.Errorf("%s[%d]: item duplicado (%s)", section.name, i, describeDefinition(section, item))
+			}
+			seen[key] = true
+		}
+	}
+
+	for i, ex := range d.Exchanges {
+		if IsBuiltinExchange(ex.Field("name")) {
+			return fmt.Errorf("exchanges[%d]: o prefixo amq. é reservado (%s)", i, ex.Field("name"))
+		}
+	}
+	for i, b := range d.Bindings {
+		switch b.Field("destination_type") {
+		case DestinationQueue, DestinationExchange:
+		default:
+			return fmt.Errorf("bindings[%d]: destination_type inválido %q (use queue ou exchange)", i, b.Field("destination_type"))
+		}
+	}
+
+	return nil
+}
+
+// Counts retorna o número de itens de cada seção não vazia
+func (d *Definitions) Counts() []DefinitionCount {
+	var counts []DefinitionCount
+	for _, section := range d.sections() {
+		if n := len(*section.items); n > 0 {
+			counts = append(counts, DefinitionCount{Section: section.name, Count: n})
+		}
+	}
+	return counts
+}
+
+// Empty indica se não há nenhum item em nenhuma seção
+func (d *Definitions) Empty() bool {
+	return len(d.Counts()) == 0
+}
+
+// FilterByName mantém apenas filas, exchanges, policies e parâmetros cujo
+// nome casa com re, e os bindings entre eles (ou a partir do default
+// exchange e dos amq.*). Usuários, vhosts e permissões não são afetados.
+func (d *Definitions) FilterByName(re *regexp.Regexp) {
+	keep := func(items []Definition) []Definition {
+		var kept []Definition
+		for _, item := range items {
+			if re.MatchString(item.Field("name")) {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+
+	d.Queues = keep(d.Queues)
+	d.Exchanges = keep(d.Exchanges)
+	d.Policies = keep(d.Policies)
+	d.Parameters = keep(d.Parameters)
+
+	var bindings []Definition
+	for _, b := range d.Bindings {
+		source, destination := b.Field("source"), b.Field("destination")
+		sourceKept := source == "" || IsBuiltinExchange(source) || re.MatchString(source)
+		if sourceKept && re.MatchString(destination) {
+			bindings = append(bindings, b)
+		}
+	}
+	d.Bindings = bindings
+}
+
+// StripUsers remove usuários e permissões
+func (d *Definitions) StripUsers() {
+	d.Users = nil
+	d.Permissions = nil
+	d.TopicPermissions = nil
+}
+
+// StripPasswords remove o hash de senha (e o algoritmo) dos usuários. No
+// import esses usuários são ignorados, a menos que sejam liberados com
+// AllowPasswordlessUsers (veja PasswordlessUsers).
+func (d *Definitions) StripPasswords() {
+	for _, user := range d.Users {
+		delete(user, "password_hash")
+		delete(user, "hashing_algorithm")
+	}
+}
+
+// hasPassword indica se o usuário tem hash de senha (ou senha em texto,
+// aceita pelo import do broker)
+func hasPassword(user Definition) bool {
+	return user.Field("password_hash") != "" || user.Field("password") != ""
+}
+
+// PasswordlessUsers lista os usuários sem senha, como os exportados com
+// StripPasswords
+func (d *Definitions) PasswordlessUsers() []string {
+	var names []string
+	for _, user := range d.Users {
+		if !hasPassword(user) {
+			names = append(names, user.Field("name"))
+		}
+	}
+	return names
+}
+
+// DropPasswordlessUsers remove os usuários sem senha e as permissões deles,
+// que o broker recusaria sem o usuário
+func (d *Definitions) DropPasswordlessUsers() {
+	dropped := map[string]bool{}
+	var users []Definition
+	for _, user := range d.Users {
+		if hasPassword(user) {
+			users = append(users, user)
+		} else {
+			dropped[user.Field("name")] = true
+		}
+	}
+	d.Users = users
+
+	keep := func(items []Definition) []Definition {
+		var kept []Definition
+		for _, item := range items {
+			if !dropped[item.Field("user")] {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+	d.Permissions = keep(d.Permissions)
+	d.TopicPermissions = keep(d.TopicPermissions)
+}
+
+// AllowPasswordlessUsers grava o hash vazio nos usuários sem senha. O broker
+// os cria sem senha: só é possível autenticar depois que uma senha for
+// definida (ex: rabbitmqctl change_password). Um usuário que já existe
+// perde a senha atual.
+func (d *Definitions) AllowPasswordlessUsers() {
+	for _, user := range d.Users {
+		if !hasPassword(user) {
+			user["password_hash"] = ""
+		}
+	}
+}
+
+// MergeDefinitions junta arquivos de definições parciais. Itens com a mesma
+// identidade (ex: fila com mesmo vhost e nome) são substituídos pelos dos
+// arquivos seguintes, mantendo a posição da primeira ocorrência.
+func MergeDefinitions(all ...*Definitions) *Definitions {
+	merged := &Definitions{}
+	for _, defs := range all {
+		if merged.RabbitVersion == "" {
+			merged.RabbitVersion = defs.RabbitVersion
+		}
+		if merged.RabbitMQVersion == "" {
+			merged.RabbitMQVersion = defs.RabbitMQVersion
+		}
+		if merged.ProductName == "" {
+			merged.ProductName = defs.ProductName
+			merged.ProductVersion = defs.ProductVersion
+		}
+	}
+
+	target := merged.sections()
+	for _, defs := range all {
+		for i, section := range defs.sections() {
+			for _, item := range *section.items {
+				mergeDefinition(target[i], item)
+			}
+		}
+	}
+	return merged
+}
+
+func mergeDefinition(section definitionSection, item Definition) {
+	key := section.itemKey(item)
+	for i, existing := range *section.items {
+		if section.itemKey(existing) == key {
+			(*section.items)[i] = item
+			return
+		}
+	}
+	*section.items = append(*section.items, item)
+}
+
+// itemKey identifica o item na seção; argumentos entram serializados
+// (json.Marshal ordena as chaves de mapas)
+func (s definitionSection) itemKey(item Definition) string {
+	values := make([]interface{}, len(s.key))
+	for i, field := range s.key {
+		values[i] = item[field]
+	}
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+// describeDefinition descreve o item pelos campos que o identificam
+func describeDefinition(section definitionSection, item Definition) string {
+	description := ""
+	for _, field := range section.key {
+		value := item.Field(field)
+		if value == "" || field == "arguments" {
+			continue
+		}
+		if description != "" {
+			description += ", "
+		}
+		description += fmt.Sprintf("%s=%s", field, value)
+	}
+	return description
+}
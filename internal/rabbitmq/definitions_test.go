@@ -0,0 +1,127 @@
+package rabbitmq
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDefinitions = `{
+  "rabbitmq_version": "3.13.0",
+  "users": [{"name": "admin", "password_hash": "abc", "tags": ["administrator"]}],
+  "vhosts": [{"name": "/"}],
+  "permissions": [{"user": "admin", "vhost": "/", "configure": ".*", "write": ".*", "read": ".*"}],
+  "policies": [{"vhost": "/", "name": "orders-limit", "pattern": "^orders$", "apply-to": "queues", "priority": 0, "definition": {"max-length": 1000}}],
+  "queues": [
+    {"vhost": "/", "name": "orders", "durable": true, "auto_delete": false, "arguments": {"x-queue-type": "quorum"}},
+    {"vhost": "/", "name": "audit", "durable": true, "auto_delete": false, "arguments": {}}
+  ],
+  "exchanges": [{"vhost": "/", "name": "orders.events", "type": "topic", "durable": true, "auto_delete": false, "internal": false, "arguments": {}}],
+  "bindings": [
+    {"vhost": "/", "source": "orders.events", "destination": "orders", "destination_type": "queue", "routing_key": "orders.*", "arguments": {}},
+    {"vhost": "/", "source": "orders.events", "destination": "audit", "destination_type": "queue", "routing_key": "#", "arguments": {}},
+    {"vhost": "/", "source": "amq.topic", "destination": "orders", "destination_type": "queue", "routing_key": "legacy", "arguments": {}}
+  ]
+}`
+
+func loadSampleDefinitions(t *testing.T) *Definitions {
+	path := filepath.Join(t.TempDir(), "definitions.json")
+	require.NoError(t, os.WriteFile(path, []byte(sampleDefinitions), 0644))
+
+	defs, err := LoadDefinitions(path)
+	require.NoError(t, err)
+	return defs
+}
+
+func TestDefinitions_Validate(t *testing.T) {
+	defs := loadSampleDefinitions(t)
+	require.NoError(t, defs.Validate(""))
+	assert.Equal(t, []DefinitionCount{
+		{"users", 1}, {"vhosts", 1}, {"permissions", 1}, {"policies", 1},
+		{"queues", 2}, {"exchanges", 1}, {"bindings", 3},
+	}, defs.Counts())
+
+	defs.Queues = append(defs.Queues, Definition{"vhost": "/", "name": "orders"})
+	assert.EqualError(t, defs.Validate(""), "queues[2]: item duplicado (vhost=/, name=orders)")
+
+	defs = loadSampleDefinitions(t)
+	delete(defs.Queues[0], "vhost")
+	assert.ErrorContains(t, defs.Validate(""), "queues[0]: campo vhost ausente")
+	assert.NoError(t, defs.Validate("/"), "no import de um vhost o campo é opcional")
+
+	defs.Bindings[0]["destination_type"] = "topic"
+	assert.ErrorContains(t, defs.Validate("/"), "bindings[0]: destination_type inválido")
+}
+
+func TestDefinitions_FilterByName(t *testing.T) {
+	defs := loadSampleDefinitions(t)
+	defs.FilterByName(regexp.MustCompile("^orders"))
+
+	assert.Len(t, defs.Queues, 1)
+	assert.Len(t, defs.Exchanges, 1)
+	assert.Len(t, defs.Policies, 1)
+	require.Len(t, defs.Bindings, 2, "bindings para audit saem; os de amq.* ficam")
+	assert.Equal(t, "orders", defs.Bindings[1].Field("destination"))
+	assert.Len(t, defs.Users, 1)
+}
+
+func TestDefinitions_Strip(t *testing.T) {
+	defs := loadSampleDefinitions(t)
+	defs.StripPasswords()
+	assert.NotContains(t, defs.Users[0], "password_hash")
+
+	defs.StripUsers()
+	assert.Nil(t, defs.Users)
+	assert.Nil(t, defs.Permissions)
+}
+
+func TestDefinitions_PasswordlessUsers(t *testing.T) {
+	defs := loadSampleDefinitions(t)
+	assert.Empty(t, defs.PasswordlessUsers())
+
+	defs.Users = append(defs.Users,
+		Definition{"name": "ci", "password": "secret"},
+		Definition{"name": "app", "password_hash": ""},
+	)
+	defs.Permissions = append(defs.Permissions, Definition{"user": "app", "vhost": "/"})
+	defs.TopicPermissions = []Definition{{"user": "app", "vhost": "/", "exchange": "amq.topic"}}
+	assert.Equal(t, []string{"app"}, defs.PasswordlessUsers())
+
+	defs.StripPasswords()
+	assert.Equal(t, []string{"admin", "app"}, defs.PasswordlessUsers(), "senha em texto continua valendo")
+
+	allowed := loadSampleDefinitions(t)
+	allowed.StripPasswords()
+	allowed.AllowPasswordlessUsers()
+	assert.Equal(t, "", allowed.Users[0]["password_hash"])
+	assert.Contains(t, allowed.Users[0], "password_hash")
+
+	defs.DropPasswordlessUsers()
+	require.Len(t, defs.Users, 1)
+	assert.Equal(t, "ci", defs.Users[0].Field("name"))
+	assert.Empty(t, defs.Permissions)
+	assert.Empty(t, defs.TopicPermissions)
+}
+
+func TestMergeDefinitions(t *testing.T) {
+	base := loadSampleDefinitions(t)
+	patch := &Definitions{
+		Queues: []Definition{
+			{"vhost": "/", "name": "audit", "durable": false},
+			{"vhost": "/", "name": "payments", "durable": true},
+		},
+	}
+
+	merged := MergeDefinitions(base, patch)
+	require.NoError(t, merged.Validate(""))
+	assert.Equal(t, "3.13.0", merged.RabbitMQVersion)
+	require.Len(t, merged.Queues, 3)
+	assert.Equal(t, "audit", merged.Queues[1].Field("name"))
+	assert.Equal(t, false, merged.Queues[1]["durable"])
+	assert.Equal(t, "payments", merged.Queues[2].Field("name"))
+	assert.Len(t, merged.Bindings, 3)
+}
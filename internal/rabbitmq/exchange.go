@@ -0,0 +1,158 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ExchangeTypes são os tipos de exchange nativos do RabbitMQ
+var ExchangeTypes = []string{"direct", "topic", "fanout", "headers"}
+
+// CreateExchangeOptions são opções para criar um exchange
+type CreateExchangeOptions struct {
+	Name              string
+	Type              string // direct, topic, fanout, headers ou tipo de plugin (x-...)
+	Durable           bool
+	AutoDelete        bool
+	Internal          bool   // Só recebe mensagens de outros exchanges
+	AlternateExchange string // Recebe as mensagens sem rota (argumento alternate-exchange)
+	Arguments         map[string]interface{}
+}
+
+// EffectiveArguments retorna os argumentos da declaração, incluindo o alternate-exchange
+func (o CreateExchangeOptions) EffectiveArguments() map[string]interface{} {
+	args := make(map[string]interface{}, len(o.Arguments)+1)
+	for k, v := range o.Arguments {
+		args[k] = v
+	}
+	if o.AlternateExchange != "" {
+		args["alternate-exchange"] = o.AlternateExchange
+	}
+	return args
+}
+
+// Matches indica se o exchange existente tem a mesma declaração das opções
+// (redeclarar um exchange com outra configuração é recusado pelo broker)
+func (o CreateExchangeOptions) Matches(ex ExchangeInfoManagement) bool {
+	if ex.Type != o.Type || ex.Durable != o.Durable || ex.AutoDelete != o.AutoDelete || ex.Internal != o.Internal {
+		return false
+	}
+
+	return ArgumentsEqual(o.EffectiveArguments(), ex.Arguments)
+}
+
+// ArgumentsEqual compara argumentos de filas, exchanges, bindings ou policies
+// pelo valor formatado (veja ArgumentString)
+func ArgumentsEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		other, ok := b[k]
+		if !ok || ArgumentString(other) != ArgumentString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ArgumentString formata um argumento para comparação; a API devolve
+// números como float64, que viram inteiros quando não têm parte decimal
+func ArgumentString(v interface{}) string {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return fmt.Sprint(v)
+}
+
+// IsBuiltinExchange indica se o exchange é criado pelo próprio broker
+// (default exchange e amq.*) e não pode ser removido
+func IsBuiltinExchange(name string) bool {
+	return name == "" || strings.HasPrefix(name, "amq.")
+}
+
+// ParseArguments converte flags "chave=valor" em argumentos de declaração.
+// Inteiros (sem zeros à esquerda) e true/false são convertidos; os demais
+// ficam como texto. O tipo pode ser explícito com "chave:tipo=valor" (string,
+// int, bool ou float).
+func ParseArguments(values []string) (map[string]interface{}, error) {
+	return parseArguments(values, true)
+}
+
+// ParseBindingArguments converte flags "chave=valor" em argumentos de binding.
+// Os valores ficam como texto, já que o exchange headers os compara com os
+// headers das mensagens (zip=01310 não pode virar 1310); outros tipos só com
+// "chave:int=5", "chave:bool=true" ou "chave:float=1.5".
+func ParseBindingArguments(values []string) (map[string]interface{}, error) {
+	return parseArguments(values, false)
+}
+
+// argumentTypes são os tipos aceitos em "chave:tipo=valor"
+var argumentTypes = []string{"string", "int", "bool", "float"}
+
+func parseArguments(values []string, infer bool) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key, argType := splitArgumentType(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("argumento inválido %q: use chave=valor ou chave:tipo=valor", value)
+		}
+
+		parsed, err := parseArgumentValue(val, argType, infer)
+		if err != nil {
+			return nil, fmt.Errorf("argumento inválido %q: %w", value, err)
+		}
+		args[key] = parsed
+	}
+	return args, nil
+}
+
+// splitArgumentType separa "chave:tipo" quando o sufixo é um tipo conhecido;
+// outras chaves com ":" ficam inteiras
+func splitArgumentType(key string) (string, string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 || !slices.Contains(argumentTypes, key[i+1:]) {
+		return key, ""
+	}
+	return key[:i], key[i+1:]
+}
+
+func parseArgumentValue(value, argType string, infer bool) (interface{}, error) {
+	switch argType {
+	case "string":
+		return value, nil
+	case "int":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q não é um inteiro", value)
+		}
+		return n, nil
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q não é um booleano", value)
+		}
+		return b, nil
+	case "float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q não é um número", value)
+		}
+		return f, nil
+	}
+
+	if !infer {
+		return value, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(n, 10) == value {
+		return n, nil
+	}
+	if value == "true" || value == "false" {
+		return value == "true", nil
+	}
+	return value, nil
+}
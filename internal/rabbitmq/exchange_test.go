@@ -0,0 +1,87 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArguments(t *testing.T) {
+	args, err := ParseArguments([]string{"x-delayed-type=direct", "x-max-length=1000", "x-flag=true", "x-id=1", "x-expr=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"x-delayed-type": "direct",
+		"x-max-length":   int64(1000),
+		"x-flag":         true,
+		"x-id":           int64(1),
+		"x-expr":         "a=b",
+	}, args)
+
+	_, err = ParseArguments([]string{"invalid"})
+	assert.Error(t, err)
+	_, err = ParseArguments([]string{"=value"})
+	assert.Error(t, err)
+
+	// Só inteiros canônicos e true/false são convertidos
+	args, err = ParseArguments([]string{"zip=01310", "flag=T", "x-ttl:string=60"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"zip": "01310", "flag": "T", "x-ttl": "60"}, args)
+}
+
+func TestParseBindingArguments(t *testing.T) {
+	args, err := ParseBindingArguments([]string{"x-match=all", "zip=01310", "flag=T", "count=5", "priority:int=5", "vip:bool=true", "score:float=1.5", "ns:id=7"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"x-match":  "all",
+		"zip":      "01310",
+		"flag":     "T",
+		"count":    "5",
+		"priority": int64(5),
+		"vip":      true,
+		"score":    1.5,
+		"ns:id":    "7",
+	}, args)
+
+	for _, value := range []string{"priority:int=cinco", "vip:bool=talvez", "score:float=x", ":int=5"} {
+		_, err := ParseBindingArguments([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestIsBuiltinExchange(t *testing.T) {
+	assert.True(t, IsBuiltinExchange(""))
+	assert.True(t, IsBuiltinExchange("amq.topic"))
+	assert.False(t, IsBuiltinExchange("orders"))
+	assert.False(t, IsBuiltinExchange("orders.amq.x"))
+}
+
+func TestCreateExchangeOptions_Matches(t *testing.T) {
+	opts := CreateExchangeOptions{
+		Name:              "orders",
+		Type:              "direct",
+		Durable:           true,
+		AlternateExchange: "orders.unrouted",
+		Arguments:         map[string]interface{}{"x-ttl": int64(604800000)},
+	}
+	existing := ExchangeInfoManagement{
+		Name:    "orders",
+		Type:    "direct",
+		Durable: true,
+		// A API devolve números como float64
+		Arguments: map[string]interface{}{"alternate-exchange": "orders.unrouted", "x-ttl": float64(604800000)},
+	}
+	assert.True(t, opts.Matches(existing))
+
+	other := existing
+	other.Type = "topic"
+	assert.False(t, opts.Matches(other))
+
+	other = existing
+	other.Arguments = map[string]interface{}{"x-ttl": float64(604800000)}
+	assert.False(t, opts.Matches(other))
+
+	other = existing
+	other.Internal = true
+	assert.False(t, opts.Matches(other))
+}
@@ -0,0 +1,93 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+)
+
+// exportSyncEvery é a quantidade de mensagens gravadas entre sincronizações
+// do arquivo; no modo Remove as mensagens só são confirmadas após o sync
+const exportSyncEvery = 100
+
+// ExportOptions são opções para exportar mensagens de uma fila
+type ExportOptions struct {
+	Remove bool // Remove as mensagens da fila (padrão: copia e devolve)
+	Max    int  // Máximo de mensagens exportadas (0 = todas)
+
+	// OnProgress é chamado após cada mensagem gravada
+	OnProgress func(exported int)
+}
+
+// ExportQueue grava as mensagens da fila em w, uma por linha (NDJSON),
+// sem carregá-las em memória.
+//
+// As mensagens são lidas com basic.get. No modo cópia elas ficam pendentes
+// (unacked) até o fim e voltam para a fila na ordem original; no modo Remove
+// são confirmadas em lotes, sempre depois de gravadas e sincronizadas em disco.
+func (c *Client) ExportQueue(ctx context.Context, queueName string, w *MessageWriter, opts ExportOptions) (int, error) {
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	// Pendentes (não confirmadas) voltam para a fila ao final
+	var lastUnacked uint64
+	defer func() {
+		if lastUnacked > 0 {
+			channel.Nack(lastUnacked, true, true)
+		}
+	}()
+
+	// commit grava o arquivo em disco e, no modo Remove, confirma as mensagens gravadas
+	commit := func() error {
+		if err := w.Sync(); err != nil {
+			return err
+		}
+		if opts.Remove && lastUnacked > 0 {
+			if err := channel.Ack(lastUnacked, true); err != nil {
+				return fmt.Errorf("erro ao confirmar mensagens exportadas: %w", err)
+			}
+			lastUnacked = 0
+		}
+		return nil
+	}
+
+	exported := 0
+	for opts.Max <= 0 || exported < opts.Max {
+		if err := ctx.Err(); err != nil {
+			if commitErr := commit(); commitErr != nil {
+				return exported, commitErr
+			}
+			return exported, err
+		}
+
+		d, ok, err := channel.Get(queueName, false)
+		if err != nil {
+			return exported, fmt.Errorf("erro ao ler mensagem %d: %w", exported+1, err)
+		}
+		if !ok {
+			break
+		}
+
+		if err := w.Write(NewMessageRecord(d)); err != nil {
+			// A mensagem atual não foi gravada e volta para a fila
+			channel.Nack(d.DeliveryTag, false, true)
+			return exported, err
+		}
+		lastUnacked = d.DeliveryTag
+		exported++
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(exported)
+		}
+
+		if exported%exportSyncEvery == 0 {
+			if err := commit(); err != nil {
+				return exported, err
+			}
+		}
+	}
+
+	return exported, commit()
+}
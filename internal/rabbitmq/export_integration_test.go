@@ -0,0 +1,53 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportQueue_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_export_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	_, err := client.Publish(context.Background(), PublishOptions{RoutingKey: queueName, Count: 3}, amqp.Publishing{Body: []byte("hello")}, nil)
+	require.NoError(t, err)
+
+	// Cópia: as mensagens continuam na fila
+	var buf bytes.Buffer
+	exported, err := client.ExportQueue(context.Background(), queueName, NewMessageWriter(&buf), ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, exported)
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 3)
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.Messages)
+
+	// Remoção com limite
+	buf.Reset()
+	exported, err = client.ExportQueue(context.Background(), queueName, NewMessageWriter(&buf), ExportOptions{Remove: true, Max: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, exported)
+
+	info, err = client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.Messages)
+}
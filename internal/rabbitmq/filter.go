@@ -0,0 +1,90 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// MessageFilter seleciona mensagens por headers e conteúdo do body.
+// Todos os critérios informados precisam casar; um filtro vazio casa com tudo.
+type MessageFilter struct {
+	Headers      map[string]string  // Header igual ao valor (comparado como texto)
+	BodyContains string             // Body contém o texto
+	BodyRegex    *regexp.Regexp     // Body casa com a expressão regular
+	JSONPath     *JSONPathPredicate // Body JSON atende ao predicado
+	DeathReason  string             // Algum dead-letter (x-death) teve este motivo
+}
+
+// ParseHeaderFilters converte flags "chave=valor" em um mapa de headers
+func ParseHeaderFilters(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("filtro de header inválido %q: use chave=valor", value)
+		}
+		headers[key] = val
+	}
+	return headers, nil
+}
+
+// IsEmpty indica se o filtro não tem nenhum critério
+func (f MessageFilter) IsEmpty() bool {
+	return len(f.Headers) == 0 && f.BodyContains == "" && f.BodyRegex == nil &&
+		f.JSONPath == nil && f.DeathReason == ""
+}
+
+// Match verifica se a mensagem atende ao filtro
+func (f MessageFilter) Match(headers amqp.Table, body []byte) bool {
+	for key, expected := range f.Headers {
+		value, ok := headers[key]
+		if !ok || fmt.Sprint(value) != expected {
+			return false
+		}
+	}
+
+	if f.BodyContains != "" && !bytes.Contains(body, []byte(f.BodyContains)) {
+		return false
+	}
+
+	if f.BodyRegex != nil && !f.BodyRegex.Match(body) {
+		return false
+	}
+
+	if f.JSONPath != nil && !f.JSONPath.Match(body) {
+		return false
+	}
+
+	if f.DeathReason != "" && !hasDeathReason(headers, f.DeathReason) {
+		return false
+	}
+
+	return true
+}
+
+// hasDeathReason verifica se alguma entrada do x-death (ou o
+// x-first-death-reason) tem o motivo informado (rejected, expired, maxlen...)
+func hasDeathReason(headers amqp.Table, reason string) bool {
+	if first, ok := headers["x-first-death-reason"]; ok && fmt.Sprint(first) == reason {
+		return true
+	}
+
+	entries, _ := headers["x-death"].([]interface{})
+	for _, entry := range entries {
+		var fields map[string]interface{}
+		switch e := entry.(type) {
+		case amqp.Table:
+			fields = e
+		case map[string]interface{}:
+			fields = e
+		}
+		if fields != nil && fmt.Sprint(fields["reason"]) == reason {
+			return true
+		}
+	}
+	return false
+}
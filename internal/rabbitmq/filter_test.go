@@ -0,0 +1,96 @@
+package rabbitmq
+
+import (
+	"regexp"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageFilter_Match(t *testing.T) {
+	headers := amqp.Table{"tenant": "acme", "version": int32(2)}
+	body := []byte(`{"order_id": 42, "status": "failed"}`)
+
+	tests := []struct {
+		name     string
+		filter   MessageFilter
+		expected bool
+	}{
+		{"empty filter", MessageFilter{}, true},
+		{"header match", MessageFilter{Headers: map[string]string{"tenant": "acme"}}, true},
+		{"numeric header match", MessageFilter{Headers: map[string]string{"version": "2"}}, true},
+		{"header mismatch", MessageFilter{Headers: map[string]string{"tenant": "other"}}, false},
+		{"missing header", MessageFilter{Headers: map[string]string{"region": "us"}}, false},
+		{"body contains", MessageFilter{BodyContains: `"failed"`}, true},
+		{"body does not contain", MessageFilter{BodyContains: "ok"}, false},
+		{"body regex", MessageFilter{BodyRegex: regexp.MustCompile(`"order_id": \d+`)}, true},
+		{"all criteria", MessageFilter{
+			Headers:      map[string]string{"tenant": "acme"},
+			BodyContains: "failed",
+			BodyRegex:    regexp.MustCompile(`42`),
+		}, true},
+		{"one criterion fails", MessageFilter{
+			Headers:      map[string]string{"tenant": "acme"},
+			BodyContains: "shipped",
+		}, false},
+		{"jsonpath match", MessageFilter{JSONPath: mustJSONPath(t, "$.order_id==42")}, true},
+		{"jsonpath mismatch", MessageFilter{JSONPath: mustJSONPath(t, "$.status==ok")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(headers, body))
+		})
+	}
+}
+
+func TestMessageFilter_DeathReason(t *testing.T) {
+	filter := MessageFilter{DeathReason: "expired"}
+	assert.False(t, filter.IsEmpty())
+
+	first := amqp.Table{"x-first-death-reason": "expired"}
+	assert.True(t, filter.Match(first, nil))
+
+	// Mensagem rejeitada primeiro e expirada depois
+	history := amqp.Table{"x-death": []interface{}{
+		amqp.Table{"reason": "rejected", "queue": "orders"},
+		amqp.Table{"reason": "expired", "queue": "orders.wait"},
+	}}
+	assert.True(t, filter.Match(history, nil))
+
+	rejected := amqp.Table{"x-death": []interface{}{amqp.Table{"reason": "rejected"}}}
+	assert.False(t, filter.Match(rejected, nil))
+	assert.False(t, filter.Match(nil, nil))
+}
+
+func mustJSONPath(t *testing.T, expr string) *JSONPathPredicate {
+	t.Helper()
+	p, err := ParseJSONPathPredicate(expr)
+	require.NoError(t, err)
+	return p
+}
+
+func TestParseHeaderFilters(t *testing.T) {
+	headers, err := ParseHeaderFilters([]string{"tenant=acme", "query=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tenant": "acme", "query": "a=b"}, headers)
+
+	_, err = ParseHeaderFilters([]string{"invalid"})
+	assert.Error(t, err)
+
+	_, err = ParseHeaderFilters([]string{"=value"})
+	assert.Error(t, err)
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"tenant=acme", "trace=a=b", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, amqp.Table{"tenant": "acme", "trace": "a=b", "empty": ""}, headers)
+
+	_, err = ParseHeaders([]string{"=x"})
+	assert.Error(t, err)
+	_, err = ParseHeaders([]string{"tenant"})
+	assert.Error(t, err)
+}
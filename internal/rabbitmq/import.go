@@ -0,0 +1,153 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// importDefaultBatch é o tamanho padrão da janela de publicações aguardando confirmação
+const importDefaultBatch = 100
+
+// ImportOptions são opções para importar mensagens de um arquivo NDJSON
+type ImportOptions struct {
+	Exchange   string // Exchange de destino (vazio = default exchange)
+	RoutingKey string // Routing key fixa (vazio = routing key original do registro)
+
+	FromLine  int           // Primeira linha a importar, 1-based (0 = desde o início)
+	BatchSize int           // Publicações em voo aguardando confirmação (padrão: 100)
+	Timeout   time.Duration // Timeout de cada confirmação (padrão: 30s)
+
+	SetHeaders    amqp.Table // Headers adicionados/sobrescritos em cada mensagem
+	RemoveHeaders []string   // Headers removidos de cada mensagem
+
+	// OnProgress é chamado a cada BatchSize mensagens confirmadas e ao final,
+	// com a última linha confirmada
+	OnProgress func(imported, line int)
+}
+
+// ImportError indica em qual linha a importação deve ser retomada
+type ImportError struct {
+	Line int // Primeira linha não confirmada (use como FromLine)
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("importação interrompida na linha %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// ImportMessages publica os registros lidos de r com mandatory=true por um
+// ConfirmPipeline: até BatchSize mensagens ficam em voo e as recusadas ou
+// devolvidas sem rota são reenviadas.
+//
+// Em caso de falha retorna um *ImportError com a linha a partir da qual a
+// importação pode ser retomada; linhas anteriores já foram confirmadas e
+// mensagens em voo podem ser publicadas de novo na retomada (at-least-once).
+func (c *Client) ImportMessages(ctx context.Context, r *MessageReader, opts ImportOptions) (*PublishStats, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = importDefaultBatch
+	}
+
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return &PublishStats{}, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	pipeline, err := NewConfirmPipeline(channel, PipelineOptions{Window: opts.BatchSize, Timeout: opts.Timeout, User: c.config.Username})
+	if err != nil {
+		return &PublishStats{}, err
+	}
+
+	// As confirmações chegam na ordem de publicação: tudo antes de resume foi confirmado
+	resume := max(opts.FromLine, 1)
+	imported, reported := 0, 0
+	report := func() {
+		if opts.OnProgress != nil && imported > reported {
+			opts.OnProgress(imported, resume-1)
+		}
+		reported = imported
+	}
+
+	// fail aguarda as mensagens em voo (mesmo com ctx cancelado) e indica a linha de retomada
+	fail := func(err error) (*PublishStats, error) {
+		pipeline.Flush(context.Background())
+		report()
+
+		stats := pipeline.Stats()
+		return &stats, &ImportError{Line: resume, Err: err}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+
+		record, line, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if line < opts.FromLine {
+			continue
+		}
+
+		routingKey := opts.RoutingKey
+		if routingKey == "" {
+			routingKey = record.RoutingKey
+		}
+
+		msg := record.Publishing()
+		rewriteHeaders(&msg, opts.SetHeaders, opts.RemoveHeaders)
+
+		err = pipeline.Publish(ctx, opts.Exchange, routingKey, msg, func() error {
+			imported++
+			resume = line + 1
+			if imported-reported >= opts.BatchSize {
+				report()
+			}
+			return nil
+		})
+		if err != nil {
+			stats := pipeline.Stats()
+			return &stats, &ImportError{Line: resume, Err: err}
+		}
+	}
+
+	err = pipeline.Flush(ctx)
+	report()
+	stats := pipeline.Stats()
+	if err != nil {
+		return &stats, &ImportError{Line: resume, Err: err}
+	}
+	return &stats, nil
+}
+
+// rewriteHeaders aplica as alterações de headers da importação na mensagem
+func rewriteHeaders(msg *amqp.Publishing, set amqp.Table, remove []string) {
+	if len(set) == 0 && len(remove) == 0 {
+		return
+	}
+	if msg.Headers == nil {
+		msg.Headers = amqp.Table{}
+	}
+	for _, key := range remove {
+		delete(msg.Headers, key)
+	}
+	for key, value := range set {
+		msg.Headers[key] = value
+	}
+	if len(msg.Headers) == 0 {
+		msg.Headers = nil
+	}
+}
@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportMessages_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_import_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	var buf bytes.Buffer
+	writer := NewMessageWriter(&buf)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, writer.Write(NewMessageRecord(amqp.Delivery{RoutingKey: "original", Body: []byte("hello")})))
+	}
+	require.NoError(t, writer.Sync())
+	data := buf.Bytes()
+
+	// Retomada: importa a partir da linha 3, com janela de 2
+	stats, err := client.ImportMessages(context.Background(), NewMessageReader(bytes.NewReader(data)), ImportOptions{
+		RoutingKey: queueName,
+		FromLine:   3,
+		BatchSize:  2,
+		SetHeaders: amqp.Table{"source": "import"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Confirmed)
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.Messages)
+
+	// Sem rota: reenvia e, esgotadas as tentativas, indica a linha para retomar
+	stats, err = client.ImportMessages(context.Background(), NewMessageReader(bytes.NewReader(data)), ImportOptions{
+		RoutingKey: queueName + ".inexistente",
+	})
+	var importErr *ImportError
+	require.True(t, errors.As(err, &importErr))
+	assert.Equal(t, 1, importErr.Line)
+	assert.Equal(t, 0, stats.Confirmed)
+	assert.Equal(t, 3, stats.Retried)
+}
@@ -229,3 +229,68 @@ func TestClient_Close_Integration(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, client.IsConnected())
 }
+
+func TestDrainAndPublishMessages_PreservesProperties_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_fidelity_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	// Publicação direta no canal: o user-id precisa ser o usuário da conexão
+	original := PublishingFromDelivery(fullDelivery())
+	original.UserId = client.GetUsername()
+	require.NoError(t, client.GetChannel().Publish("", queueName, false, false, original))
+
+	messages, err := client.DrainQueue(queueName, nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, queueName, messages[0].RoutingKey)
+
+	_, err = client.PublishMessages(queueName, messages, nil)
+	require.NoError(t, err)
+
+	delivery, ok, err := client.GetChannel().Get(queueName, true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, original, PublishingFromDelivery(delivery))
+}
+
+func TestPublishMessages_DropsForeignUserId_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_user_id_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	// Mensagem publicada por outro usuário (ex: exportada de outro broker)
+	foreign := NewSavedMessage(fullDelivery())
+	foreign.UserId = "someone-else"
+	own := NewSavedMessage(fullDelivery())
+	own.UserId = client.GetUsername()
+
+	stats, err := client.PublishMessages(queueName, []SavedMessage{foreign, own}, nil)
+	require.NoError(t, err, "o broker fecharia o canal com o user-id de outro usuário")
+	assert.Equal(t, 2, stats.Confirmed)
+	assert.Equal(t, 1, stats.UserIdDropped)
+
+	first, ok, err := client.GetChannel().Get(queueName, true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Empty(t, first.UserId)
+
+	second, ok, err := client.GetChannel().Get(queueName, true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, client.GetUsername(), second.UserId)
+}
@@ -0,0 +1,181 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathPredicate é um predicado sobre bodies JSON, no formato
+// "<caminho>", "<caminho>==<valor>" ou "<caminho>!=<valor>".
+//
+// O caminho usa um subconjunto de JSONPath: $ (raiz), .campo, ['campo'],
+// [índice] e [*] (todos os itens). Sem comparação, basta o caminho existir
+// com valor diferente de null. Valores são comparados como texto: strings
+// sem aspas e demais tipos na forma JSON (ex: 42, true, null).
+// Com [*], basta um dos valores casar.
+type JSONPathPredicate struct {
+	Expr     string
+	segments []pathSegment
+	op       string // "", "==" ou "!="
+	value    string
+}
+
+// pathSegment é um passo do caminho: campo de objeto, índice ou curinga
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParseJSONPathPredicate interpreta a expressão do predicado
+func ParseJSONPathPredicate(expr string) (*JSONPathPredicate, error) {
+	p := &JSONPathPredicate{Expr: expr}
+
+	path := expr
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(expr, op); i >= 0 {
+			path, p.op, p.value = expr[:i], op, expr[i+len(op):]
+			break
+		}
+	}
+
+	segments, err := parseJSONPath(strings.TrimSpace(path))
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath inválido %q: %w", expr, err)
+	}
+	p.segments = segments
+	return p, nil
+}
+
+func parseJSONPath(path string) ([]pathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("o caminho deve começar com $")
+	}
+	rest := path[1:]
+
+	var segments []pathSegment
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("campo vazio")
+			}
+			if key == "*" {
+				segments = append(segments, pathSegment{wildcard: true})
+			} else {
+				segments = append(segments, pathSegment{key: key})
+			}
+			rest = rest[end:]
+
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("colchete sem fechamento")
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			switch {
+			case inner == "*":
+				segments = append(segments, pathSegment{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("índice inválido [%s]", inner)
+				}
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			}
+
+		default:
+			return nil, fmt.Errorf("caractere inesperado %q", rest[0])
+		}
+	}
+	return segments, nil
+}
+
+// Match avalia o predicado no body; bodies que não são JSON nunca casam
+func (p *JSONPathPredicate) Match(body []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return false
+	}
+
+	for _, value := range p.Values(doc) {
+		switch p.op {
+		case "==":
+			if formatJSONValue(value) == p.value {
+				return true
+			}
+		case "!=":
+			if formatJSONValue(value) != p.value {
+				return true
+			}
+		default:
+			if value != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Values retorna os valores do documento alcançados pelo caminho
+func (p *JSONPathPredicate) Values(doc interface{}) []interface{} {
+	current := []interface{}{doc}
+	for _, seg := range p.segments {
+		var next []interface{}
+		for _, node := range current {
+			switch v := node.(type) {
+			case map[string]interface{}:
+				if seg.wildcard {
+					for _, item := range v {
+						next = append(next, item)
+					}
+				} else if item, ok := v[seg.key]; ok && !seg.isIndex {
+					next = append(next, item)
+				}
+			case []interface{}:
+				if seg.wildcard {
+					next = append(next, v...)
+				} else if seg.isIndex {
+					index := seg.index
+					if index < 0 {
+						index += len(v)
+					}
+					if index >= 0 && index < len(v) {
+						next = append(next, v[index])
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// formatJSONValue formata um valor para comparação textual
+func formatJSONValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,67 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPathPredicate_Invalid(t *testing.T) {
+	for _, expr := range []string{"order.id", "$.", "$.items[", "$.items[x]", "$..id", "$order"} {
+		_, err := ParseJSONPathPredicate(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestJSONPathPredicate_Match(t *testing.T) {
+	body := []byte(`{
+		"order": {"id": 98231, "total": 10.5, "paid": false, "coupon": null},
+		"items": [{"sku": "A1"}, {"sku": "B2"}],
+		"meta": {"tenant-id": "acme"}
+	}`)
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"$.order.id", true},
+		{"$.order.missing", false},
+		{"$.order.coupon", false},
+		{"$.order.id==98231", true},
+		{"$.order.id==98232", false},
+		{"$.order.total==10.5", true},
+		{"$.order.paid==false", true},
+		{"$.order.id!=1", true},
+		{"$.items[0].sku==A1", true},
+		{"$.items[-1].sku==B2", true},
+		{"$.items[5].sku", false},
+		{"$.items[*].sku==B2", true},
+		{"$.items[*].sku==C3", false},
+		{"$.*.id==98231", true},
+		{"$['meta']['tenant-id']==acme", true},
+		{"$.items.sku", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := ParseJSONPathPredicate(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.Match(body))
+		})
+	}
+}
+
+func TestJSONPathPredicate_NonJSONBody(t *testing.T) {
+	p, err := ParseJSONPathPredicate("$.id")
+	require.NoError(t, err)
+	assert.False(t, p.Match([]byte("plain text")))
+	assert.False(t, p.Match(nil))
+}
+
+func TestJSONPathPredicate_LargeNumbers(t *testing.T) {
+	// Sem UseNumber o id seria arredondado para float64
+	p, err := ParseJSONPathPredicate("$.id==9007199254740993")
+	require.NoError(t, err)
+	assert.True(t, p.Match([]byte(`{"id": 9007199254740993}`)))
+}
@@ -1,7 +1,10 @@
 package rabbitmq
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -60,6 +63,10 @@ type QueueInfoManagement struct {
 		Ack        int `json:"ack"`
 	} `json:"message_stats"`
 	Arguments map[string]interface{} `json:"arguments"`
+
+	// Policy aplicado à fila e definição efetiva (policies de usuário e de operador)
+	Policy                    string                 `json:"policy"`
+	EffectivePolicyDefinition map[string]interface{} `json:"effective_policy_definition"`
 }
 
 // ListQueues retorna lista de todas as filas via Management API
@@ -122,7 +129,7 @@ func (m *ManagementClient) GetQueue(vhost, queueName string) (*QueueInfoManageme
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("fila não encontrada: %s/%s", vhost, queueName)
+		return nil, notFound("fila não encontrada: %s/%s", vhost, queueName)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -171,3 +178,562 @@ func (m *ManagementClient) DeleteQueueViaAPI(vhost, queueName string) error {
 
 	return nil
 }
+
+// ErrQueueNotEmpty indica que a fila não foi removida porque ainda tem mensagens
+var ErrQueueNotEmpty = errors.New("a fila ainda tem mensagens")
+
+// DeleteQueueIfEmpty remove a fila só se ela estiver vazia (if-empty): uma
+// mensagem publicada depois da última leitura impede a remoção em vez de ser
+// perdida. Retorna ErrQueueNotEmpty nesse caso; não é erro se a fila não existir.
+func (m *ManagementClient) DeleteQueueIfEmpty(vhost, queueName string) error {
+	endpoint := fmt.Sprintf("%s/queues/%s/%s?if-empty=true", m.baseURL, vhostPath(vhost), url.PathEscape(queueName))
+
+	err := m.send("DELETE", endpoint, nil)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.status == http.StatusBadRequest {
+		return fmt.Errorf("erro ao remover fila %s: %w", queueName, ErrQueueNotEmpty)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao remover fila %s: %w", queueName, err)
+	}
+	return nil
+}
+
+// ExchangeInfoManagement representa informações de um exchange via Management API
+type ExchangeInfoManagement struct {
+	Name       string                 `json:"name"`
+	VHost      string                 `json:"vhost"`
+	Type       string                 `json:"type"` // direct, fanout, topic, headers
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+
+	MessageStats struct {
+		PublishIn  int `json:"publish_in"`
+		PublishOut int `json:"publish_out"`
+	} `json:"message_stats"`
+}
+
+// GetExchange retorna informações de um exchange específico
+func (m *ManagementClient) GetExchange(vhost, exchangeName string) (*ExchangeInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/exchanges/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(exchangeName))
+
+	var exchange ExchangeInfoManagement
+	found, err := m.getJSON(endpoint, &exchange)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, notFound("exchange não encontrado: %s/%s", vhost, exchangeName)
+	}
+
+	return &exchange, nil
+}
+
+// ListExchanges retorna os exchanges de um vhost
+func (m *ManagementClient) ListExchanges(vhost string) ([]ExchangeInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/exchanges/%s", m.baseURL, vhostPath(vhost))
+
+	var exchanges []ExchangeInfoManagement
+	found, err := m.getJSON(endpoint, &exchanges)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("vhost não encontrado: %s", vhost)
+	}
+
+	return exchanges, nil
+}
+
+// CreateExchange declara um exchange; não é erro se ele já existir com a
+// mesma configuração (com outra, o broker recusa a declaração)
+func (m *ManagementClient) CreateExchange(vhost string, opts CreateExchangeOptions) error {
+	endpoint := fmt.Sprintf("%s/exchanges/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(opts.Name))
+
+	body := map[string]interface{}{
+		"type":        opts.Type,
+		"durable":     opts.Durable,
+		"auto_delete": opts.AutoDelete,
+		"internal":    opts.Internal,
+		"arguments":   opts.EffectiveArguments(),
+	}
+	if err := m.send("PUT", endpoint, body); err != nil {
+		return fmt.Errorf("erro ao criar exchange %s: %w", opts.Name, err)
+	}
+	return nil
+}
+
+// DeleteExchange remove um exchange; com ifUnused, só remove se não houver
+// bindings com ele como origem. Não é erro se ele não existir.
+func (m *ManagementClient) DeleteExchange(vhost, exchangeName string, ifUnused bool) error {
+	endpoint := fmt.Sprintf("%s/exchanges/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(exchangeName))
+	if ifUnused {
+		endpoint += "?if-unused=true"
+	}
+
+	if err := m.send("DELETE", endpoint, nil); err != nil {
+		return fmt.Errorf("erro ao remover exchange %s: %w", exchangeName, err)
+	}
+	return nil
+}
+
+// BindingInfoManagement representa um binding via Management API
+type BindingInfoManagement struct {
+	Source          string                 `json:"source"` // Vazio = default exchange
+	VHost           string                 `json:"vhost"`
+	Destination     string                 `json:"destination"`
+	DestinationType string                 `json:"destination_type"` // queue, exchange
+	RoutingKey      string                 `json:"routing_key"`
+	Arguments       map[string]interface{} `json:"arguments"`
+	PropertiesKey   string                 `json:"properties_key"` // Identifica o binding na API
+}
+
+// ListBindings retorna todos os bindings de um vhost
+func (m *ManagementClient) ListBindings(vhost string) ([]BindingInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/bindings/%s", m.baseURL, vhostPath(vhost))
+
+	var bindings []BindingInfoManagement
+	found, err := m.getJSON(endpoint, &bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("vhost não encontrado: %s", vhost)
+	}
+
+	return bindings, nil
+}
+
+// GetExchangeBindings retorna os bindings em que o exchange é a origem
+func (m *ManagementClient) GetExchangeBindings(vhost, exchangeName string) ([]BindingInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/exchanges/%s/%s/bindings/source", m.baseURL, vhostPath(vhost), url.PathEscape(exchangeName))
+
+	var bindings []BindingInfoManagement
+	found, err := m.getJSON(endpoint, &bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("exchange não encontrado: %s/%s", vhost, exchangeName)
+	}
+
+	return bindings, nil
+}
+
+// GetExchangeDestinationBindings retorna os bindings em que o exchange é o destino
+func (m *ManagementClient) GetExchangeDestinationBindings(vhost, exchangeName string) ([]BindingInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/exchanges/%s/%s/bindings/destination", m.baseURL, vhostPath(vhost), url.PathEscape(exchangeName))
+
+	var bindings []BindingInfoManagement
+	found, err := m.getJSON(endpoint, &bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("exchange não encontrado: %s/%s", vhost, exchangeName)
+	}
+
+	return bindings, nil
+}
+
+// GetQueueBindings retorna os bindings que entregam mensagens na fila
+// (inclui o binding implícito do default exchange, com Source vazio)
+func (m *ManagementClient) GetQueueBindings(vhost, queueName string) ([]BindingInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/queues/%s/%s/bindings", m.baseURL, vhostPath(vhost), url.PathEscape(queueName))
+
+	var bindings []BindingInfoManagement
+	found, err := m.getJSON(endpoint, &bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("fila não encontrada: %s/%s", vhost, queueName)
+	}
+
+	return bindings, nil
+}
+
+// ListAllBindings retorna os bindings de todos os vhosts
+func (m *ManagementClient) ListAllBindings() ([]BindingInfoManagement, error) {
+	var bindings []BindingInfoManagement
+	if _, err := m.getJSON(m.baseURL+"/bindings", &bindings); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+// GetBindingsBetween retorna os bindings de um exchange para um destino
+// (fila ou exchange, conforme destinationType)
+func (m *ManagementClient) GetBindingsBetween(vhost, source, destination, destinationType string) ([]BindingInfoManagement, error) {
+	endpoint := fmt.Sprintf("%s/bindings/%s/e/%s/%s/%s", m.baseURL, vhostPath(vhost),
+		url.PathEscape(source), destinationCode(destinationType), url.PathEscape(destination))
+
+	var bindings []BindingInfoManagement
+	found, err := m.getJSON(endpoint, &bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("origem ou destino não encontrado: %s -> %s", source, destination)
+	}
+
+	return bindings, nil
+}
+
+// CreateBinding cria um binding; declarar um binding que já existe não tem efeito
+func (m *ManagementClient) CreateBinding(vhost string, opts BindingOptions) error {
+	endpoint := fmt.Sprintf("%s/bindings/%s/e/%s/%s/%s", m.baseURL, vhostPath(vhost),
+		url.PathEscape(opts.Source), destinationCode(opts.DestinationType), url.PathEscape(opts.Destination))
+
+	arguments := opts.Arguments
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+	body := map[string]interface{}{
+		"routing_key": opts.RoutingKey,
+		"arguments":   arguments,
+	}
+
+	if err := m.send("POST", endpoint, body); err != nil {
+		return fmt.Errorf("erro ao criar binding %s -> %s: %w", opts.Source, opts.Destination, err)
+	}
+	return nil
+}
+
+// DeleteBinding remove um binding identificado pelo seu properties_key
+func (m *ManagementClient) DeleteBinding(vhost string, binding BindingInfoManagement) error {
+	endpoint := fmt.Sprintf("%s/bindings/%s/e/%s/%s/%s/%s", m.baseURL, vhostPath(vhost),
+		url.PathEscape(binding.Source), destinationCode(binding.DestinationType),
+		url.PathEscape(binding.Destination), url.PathEscape(binding.PropertiesKey))
+
+	if err := m.send("DELETE", endpoint, nil); err != nil {
+		return fmt.Errorf("erro ao remover binding %s -> %s: %w", binding.Source, binding.Destination, err)
+	}
+	return nil
+}
+
+// ExchangeType é um tipo de exchange disponível no broker (inclui os de plugins)
+type ExchangeType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// GetExchangeTypes retorna os tipos de exchange habilitados no broker
+func (m *ManagementClient) GetExchangeTypes() ([]ExchangeType, error) {
+	endpoint := fmt.Sprintf("%s/overview", m.baseURL)
+
+	var overview struct {
+		ExchangeTypes []ExchangeType `json:"exchange_types"`
+	}
+	found, err := m.getJSON(endpoint, &overview)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("overview não encontrado")
+	}
+
+	return overview.ExchangeTypes, nil
+}
+
+// HasExchangeType indica se o broker suporta o tipo de exchange (ex: x-delayed-message)
+func (m *ManagementClient) HasExchangeType(name string) (bool, error) {
+	types, err := m.GetExchangeTypes()
+	if err != nil {
+		return false, err
+	}
+	for _, t := range types {
+		if t.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MessageProperties são as propriedades AMQP de uma mensagem na Management API
+type MessageProperties struct {
+	ContentType     string                 `json:"content_type,omitempty"`
+	ContentEncoding string                 `json:"content_encoding,omitempty"`
+	Headers         map[string]interface{} `json:"headers,omitempty"`
+	DeliveryMode    int                    `json:"delivery_mode,omitempty"`
+	Priority        int                    `json:"priority,omitempty"`
+	CorrelationId   string                 `json:"correlation_id,omitempty"`
+	ReplyTo         string                 `json:"reply_to,omitempty"`
+	Expiration      string                 `json:"expiration,omitempty"`
+	MessageId       string                 `json:"message_id,omitempty"`
+	Timestamp       int64                  `json:"timestamp,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	UserId          string                 `json:"user_id,omitempty"`
+	AppId           string                 `json:"app_id,omitempty"`
+}
+
+// QueueMessage é uma mensagem lida via Management API (/queues/{vhost}/{name}/get)
+type QueueMessage struct {
+	Exchange        string            `json:"exchange"`
+	RoutingKey      string            `json:"routing_key"`
+	Redelivered     bool              `json:"redelivered"`
+	MessageCount    int               `json:"message_count"` // Mensagens restantes na fila após esta
+	Payload         string            `json:"payload"`
+	PayloadBytes    int               `json:"payload_bytes"`
+	PayloadEncoding string            `json:"payload_encoding"` // string ou base64
+	Properties      MessageProperties `json:"properties"`
+}
+
+// Body decodifica o payload da mensagem
+func (m QueueMessage) Body() ([]byte, error) {
+	if m.PayloadEncoding == "base64" {
+		body, err := base64.StdEncoding.DecodeString(m.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao decodificar payload base64: %w", err)
+		}
+		return body, nil
+	}
+	return []byte(m.Payload), nil
+}
+
+// Truncated indica se o payload foi truncado pela API
+func (m QueueMessage) Truncated() bool {
+	body, err := m.Body()
+	return err == nil && len(body) < m.PayloadBytes
+}
+
+// PeekMessages lê até count mensagens da fila sem removê-las (ackmode
+// ack_requeue_true). As mensagens voltam para a fila marcadas como redelivered.
+// truncate limita o tamanho de cada payload em bytes (0 = sem limite).
+func (m *ManagementClient) PeekMessages(vhost, queueName string, count, truncate int) ([]QueueMessage, error) {
+	endpoint := fmt.Sprintf("%s/queues/%s/%s/get", m.baseURL, vhostPath(vhost), url.PathEscape(queueName))
+
+	request := map[string]interface{}{
+		"count":    count,
+		"ackmode":  "ack_requeue_true",
+		"encoding": "auto",
+	}
+	if truncate > 0 {
+		request["truncate"] = truncate
+	}
+
+	var messages []QueueMessage
+	if err := m.sendJSON("POST", endpoint, request, &messages); err != nil {
+		return nil, fmt.Errorf("erro ao ler mensagens de %s: %w", queueName, err)
+	}
+
+	return messages, nil
+}
+
+// Policy representa um policy do RabbitMQ via Management API
+type Policy struct {
+	Name       string                 `json:"name,omitempty"`
+	VHost      string                 `json:"vhost,omitempty"`
+	Pattern    string                 `json:"pattern"`  // Regex sobre o nome da fila/exchange
+	ApplyTo    string                 `json:"apply-to"` // queues, exchanges, all
+	Priority   int                    `json:"priority"`
+	Definition map[string]interface{} `json:"definition"`
+}
+
+// ListPolicies retorna os policies de um vhost
+func (m *ManagementClient) ListPolicies(vhost string) ([]Policy, error) {
+	endpoint := fmt.Sprintf("%s/policies/%s", m.baseURL, vhostPath(vhost))
+
+	var policies []Policy
+	found, err := m.getJSON(endpoint, &policies)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("vhost não encontrado: %s", vhost)
+	}
+
+	return policies, nil
+}
+
+// GetPolicy retorna um policy pelo nome
+func (m *ManagementClient) GetPolicy(vhost, name string) (*Policy, error) {
+	endpoint := fmt.Sprintf("%s/policies/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(name))
+
+	var policy Policy
+	found, err := m.getJSON(endpoint, &policy)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("policy não encontrado: %s/%s", vhost, name)
+	}
+
+	return &policy, nil
+}
+
+// PutPolicy cria ou atualiza um policy
+func (m *ManagementClient) PutPolicy(vhost, name string, policy Policy) error {
+	endpoint := fmt.Sprintf("%s/policies/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(name))
+
+	policy.Name = ""
+	policy.VHost = ""
+	if err := m.send("PUT", endpoint, policy); err != nil {
+		return fmt.Errorf("erro ao aplicar policy %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeletePolicy remove um policy; não é erro se ele não existir
+func (m *ManagementClient) DeletePolicy(vhost, name string) error {
+	endpoint := fmt.Sprintf("%s/policies/%s/%s", m.baseURL, vhostPath(vhost), url.PathEscape(name))
+
+	if err := m.send("DELETE", endpoint, nil); err != nil {
+		return fmt.Errorf("erro ao remover policy %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetDefinitions exporta as definições do broker inteiro (vhost vazio) ou
+// de um vhost
+func (m *ManagementClient) GetDefinitions(vhost string) (*Definitions, error) {
+	endpoint := m.definitionsEndpoint(vhost)
+
+	var defs Definitions
+	found, err := m.getJSON(endpoint, &defs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao exportar definições: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("vhost não encontrado: %s", vhost)
+	}
+
+	return &defs, nil
+}
+
+// ImportDefinitions envia definições para o broker inteiro (vhost vazio) ou
+// para um vhost. O broker cria ou atualiza os itens; nada é removido.
+func (m *ManagementClient) ImportDefinitions(vhost string, defs *Definitions) error {
+	if err := m.send("POST", m.definitionsEndpoint(vhost), defs); err != nil {
+		return fmt.Errorf("erro ao importar definições: %w", err)
+	}
+	return nil
+}
+
+func (m *ManagementClient) definitionsEndpoint(vhost string) string {
+	if vhost == "" {
+		return m.baseURL + "/definitions"
+	}
+	return fmt.Sprintf("%s/definitions/%s", m.baseURL, vhostPath(vhost))
+}
+
+// ErrNotFound indica que o recurso não existe no vhost (404 na Management API).
+// Use errors.Is para diferenciar de falhas de conexão ou da API.
+var ErrNotFound = errors.New("recurso não encontrado")
+
+// notFoundError mantém a mensagem específica do recurso e casa com ErrNotFound
+type notFoundError struct {
+	message string
+}
+
+func (e *notFoundError) Error() string { return e.message }
+
+func (e *notFoundError) Is(target error) bool { return target == ErrNotFound }
+
+func notFound(format string, args ...interface{}) error {
+	return &notFoundError{message: fmt.Sprintf(format, args...)}
+}
+
+// apiError é uma resposta de erro da Management API (status fora de 2xx)
+type apiError struct {
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("erro na API: status %d, body: %s", e.status, e.body)
+}
+
+// vhostPath escapa o vhost para URLs da API ("/" vira "%2F")
+func vhostPath(vhost string) string {
+	vhost = strings.TrimPrefix(vhost, "/")
+	if vhost == "" {
+		return "%2F"
+	}
+	return url.PathEscape(vhost)
+}
+
+// getJSON faz um GET na API e decodifica a resposta em v.
+// Retorna found=false (sem erro) quando o recurso não existe (404).
+func (m *ManagementClient) getJSON(endpoint string, v interface{}) (bool, error) {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+
+	req.SetBasicAuth(m.username, m.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("erro ao fazer requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("erro na API: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("erro ao decodificar resposta: %w", err)
+	}
+
+	return true, nil
+}
+
+// send faz uma requisição de escrita (PUT/DELETE) com body JSON opcional.
+// 404 em DELETE é ignorado: o recurso já não existe.
+func (m *ManagementClient) send(method, endpoint string, body interface{}) error {
+	return m.sendJSON(method, endpoint, body, nil)
+}
+
+// sendJSON é como send, mas decodifica a resposta em out (se não for nil)
+func (m *ManagementClient) sendJSON(method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar requisição: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+
+	req.SetBasicAuth(m.username, m.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao fazer requisição: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if method == "DELETE" && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &apiError{status: resp.StatusCode, body: string(respBody)}
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("erro ao decodificar resposta: %w", err)
+		}
+	}
+
+	return nil
+}
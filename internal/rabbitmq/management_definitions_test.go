@@ -0,0 +1,45 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagementClient_GetDefinitions(t *testing.T) {
+	var paths []string
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rabbitmq_version":"3.13.0","queues":[{"name":"orders","durable":true,"arguments":{}}]}`))
+	})
+
+	defs, err := client.GetDefinitions("")
+	require.NoError(t, err)
+	require.Len(t, defs.Queues, 1)
+	assert.Equal(t, "orders", defs.Queues[0].Field("name"))
+
+	_, err = client.GetDefinitions("/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/definitions", "/api/definitions/%2F"}, paths)
+}
+
+func TestManagementClient_ImportDefinitions(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/definitions/prod", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body, "queues")
+		assert.NotContains(t, body, "users", "seções vazias não são enviadas")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.ImportDefinitions("prod", &Definitions{Queues: []Definition{{"name": "orders"}}})
+	require.NoError(t, err)
+}
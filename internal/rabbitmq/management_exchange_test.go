@@ -0,0 +1,226 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestManagementClient cria um ManagementClient apontando para um servidor de teste
+func newTestManagementClient(t *testing.T, handler http.HandlerFunc) *ManagementClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &ManagementClient{
+		baseURL:  server.URL + "/api",
+		username: "guest",
+		password: "guest",
+		client:   server.Client(),
+	}
+}
+
+func TestManagementClient_GetExchange(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/exchanges/%2F/orders.wait.exchange", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"orders.wait.exchange","vhost":"/","type":"direct","durable":true,"arguments":{"alternate-exchange":"orders.wait.first"}}`))
+	})
+
+	exchange, err := client.GetExchange("/", "orders.wait.exchange")
+	require.NoError(t, err)
+	assert.Equal(t, "direct", exchange.Type)
+	assert.True(t, exchange.Durable)
+	assert.Equal(t, "orders.wait.first", exchange.Arguments["alternate-exchange"])
+}
+
+func TestManagementClient_GetExchange_NotFound(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Object Not Found"}`, http.StatusNotFound)
+	})
+
+	_, err := client.GetExchange("/", "missing")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "não encontrado"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManagementClient_GetExchangeBindings(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/exchanges/prod/orders.retry/bindings/source", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"source":"orders.retry","vhost":"prod","destination":"orders","destination_type":"queue","routing_key":"","arguments":{"x-match":"all"},"properties_key":"~"}]`))
+	})
+
+	bindings, err := client.GetExchangeBindings("/prod", "orders.retry")
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "orders", bindings[0].Destination)
+	assert.Equal(t, "queue", bindings[0].DestinationType)
+	assert.Equal(t, "all", bindings[0].Arguments["x-match"])
+}
+
+func TestManagementClient_GetQueueBindings(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/queues/%2F/orders/bindings", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"source":"","destination":"orders","destination_type":"queue","routing_key":"orders"},{"source":"events","destination":"orders","destination_type":"queue","routing_key":"orders.*"}]`))
+	})
+
+	bindings, err := client.GetQueueBindings("/", "orders")
+	require.NoError(t, err)
+	require.Len(t, bindings, 2)
+	assert.Equal(t, "events", bindings[1].Source)
+	assert.Equal(t, "orders.*", bindings[1].RoutingKey)
+}
+
+func TestManagementClient_ListBindings(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/bindings/%2F", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"source":"","destination":"orders","destination_type":"queue","routing_key":"orders"},{"source":"orders.wait.first","destination":"orders.wait","destination_type":"queue","routing_key":""}]`))
+	})
+
+	bindings, err := client.ListBindings("/")
+	require.NoError(t, err)
+	require.Len(t, bindings, 2)
+	assert.Equal(t, "orders.wait.first", bindings[1].Source)
+}
+
+func TestManagementClient_ListExchanges_APIError(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+
+	_, err := client.ListExchanges("/")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestManagementClient_HasExchangeType(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/overview", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rabbitmq_version":"3.13.0","exchange_types":[{"name":"direct","enabled":true},{"name":"x-delayed-message","description":"Delayed Message Exchange.","enabled":true}]}`))
+	})
+
+	found, err := client.HasExchangeType("x-delayed-message")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = client.HasExchangeType("x-consistent-hash")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestManagementClient_CreateExchange(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/exchanges/%2F/orders.events", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "topic", body["type"])
+		assert.Equal(t, true, body["durable"])
+		assert.Equal(t, true, body["internal"])
+		assert.Equal(t, map[string]interface{}{"alternate-exchange": "orders.unrouted"}, body["arguments"])
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.CreateExchange("/", CreateExchangeOptions{
+		Name:              "orders.events",
+		Type:              "topic",
+		Durable:           true,
+		Internal:          true,
+		AlternateExchange: "orders.unrouted",
+	})
+	require.NoError(t, err)
+}
+
+func TestManagementClient_CreateExchange_Inequivalent(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"bad_request","reason":"inequivalent arg 'type'"}`, http.StatusBadRequest)
+	})
+
+	err := client.CreateExchange("/", CreateExchangeOptions{Name: "orders", Type: "fanout"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inequivalent arg")
+}
+
+func TestManagementClient_DeleteExchange(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/exchanges/prod/orders", r.URL.EscapedPath())
+		assert.Equal(t, "true", r.URL.Query().Get("if-unused"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, client.DeleteExchange("/prod", "orders", true))
+}
+
+func TestManagementClient_GetExchangeDestinationBindings(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/exchanges/%2F/orders/bindings/destination", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"source":"events","destination":"orders","destination_type":"exchange","routing_key":"orders.#"}]`))
+	})
+
+	bindings, err := client.GetExchangeDestinationBindings("/", "orders")
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "events", bindings[0].Source)
+}
+
+func TestManagementClient_CreateBinding(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/bindings/%2F/e/orders.events/e/audit.events", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "orders.#", body["routing_key"])
+		assert.Equal(t, map[string]interface{}{}, body["arguments"])
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.CreateBinding("/", BindingOptions{
+		Source:          "orders.events",
+		Destination:     "audit.events",
+		DestinationType: DestinationExchange,
+		RoutingKey:      "orders.#",
+	})
+	require.NoError(t, err)
+}
+
+func TestManagementClient_GetBindingsBetween(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/bindings/prod/e/orders.events/q/orders", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"source":"orders.events","destination":"orders","destination_type":"queue","routing_key":"orders.*","properties_key":"orders.*"}]`))
+	})
+
+	bindings, err := client.GetBindingsBetween("/prod", "orders.events", "orders", DestinationQueue)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "orders.*", bindings[0].PropertiesKey)
+}
+
+func TestManagementClient_DeleteBinding(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/bindings/%2F/e/orders.headers/q/orders/~Xq2h4A", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteBinding("/", BindingInfoManagement{
+		Source:          "orders.headers",
+		Destination:     "orders",
+		DestinationType: "queue",
+		PropertiesKey:   "~Xq2h4A",
+	})
+	require.NoError(t, err)
+}
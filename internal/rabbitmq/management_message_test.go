@@ -0,0 +1,52 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagementClient_PeekMessages(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/queues/%2F/orders/get", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "ack_requeue_true", body["ackmode"])
+		assert.Equal(t, float64(2), body["count"])
+		assert.Equal(t, float64(100), body["truncate"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"exchange":"","routing_key":"orders","redelivered":false,"payload":"{\"id\":1}","payload_bytes":8,"payload_encoding":"string",
+			 "properties":{"content_type":"application/json","delivery_mode":2,"headers":{"tenant":"acme"}}},
+			{"exchange":"events","routing_key":"orders.created","redelivered":true,"payload":"AAEC","payload_bytes":3,"payload_encoding":"base64","properties":{}}
+		]`))
+	})
+
+	messages, err := client.PeekMessages("/", "orders", 2, 100)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, "application/json", messages[0].Properties.ContentType)
+	assert.Equal(t, 2, messages[0].Properties.DeliveryMode)
+	assert.Equal(t, "acme", messages[0].Properties.Headers["tenant"])
+	body, err := messages[0].Body()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":1}`), body)
+	assert.False(t, messages[0].Truncated())
+
+	body, err = messages[1].Body()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2}, body)
+	assert.True(t, messages[1].Redelivered)
+}
+
+func TestQueueMessage_Truncated(t *testing.T) {
+	msg := QueueMessage{Payload: "abc", PayloadBytes: 10, PayloadEncoding: "string"}
+	assert.True(t, msg.Truncated())
+}
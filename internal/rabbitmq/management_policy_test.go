@@ -0,0 +1,68 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagementClient_PutPolicy(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/policies/%2F/gohop-retry-orders", r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "^orders$", body["pattern"])
+		assert.Equal(t, "queues", body["apply-to"])
+		assert.Equal(t, map[string]interface{}{"dead-letter-exchange": "orders.wait.exchange"}, body["definition"])
+		assert.NotContains(t, body, "name")
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.PutPolicy("/", "gohop-retry-orders", Policy{
+		Name:       "ignored",
+		Pattern:    "^orders$",
+		ApplyTo:    "queues",
+		Priority:   10,
+		Definition: map[string]interface{}{"dead-letter-exchange": "orders.wait.exchange"},
+	})
+	require.NoError(t, err)
+}
+
+func TestManagementClient_DeletePolicy_NotFound(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		http.Error(w, `{"error":"Object Not Found"}`, http.StatusNotFound)
+	})
+
+	assert.NoError(t, client.DeletePolicy("/", "gohop-retry-orders"))
+}
+
+func TestManagementClient_PutPolicy_APIError(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"bad_request"}`, http.StatusBadRequest)
+	})
+
+	err := client.PutPolicy("/", "p", Policy{Pattern: "["})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestManagementClient_ListPolicies(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/policies/prod", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"gohop-retry-orders","vhost":"prod","pattern":"^orders$","apply-to":"queues","priority":10,"definition":{"dead-letter-exchange":"orders.wait.exchange"}}]`))
+	})
+
+	policies, err := client.ListPolicies("/prod")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "queues", policies[0].ApplyTo)
+	assert.Equal(t, 10, policies[0].Priority)
+}
@@ -0,0 +1,70 @@
+package rabbitmq
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagementClient_GetQueue_NotFound(t *testing.T) {
+	client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Object Not Found"}`, http.StatusNotFound)
+	})
+
+	_, err := client.GetQueue("/", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManagementClient_DeleteQueueIfEmpty(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		check  func(t *testing.T, err error)
+	}{
+		"removida": {http.StatusNoContent, func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		}},
+		"não vazia": {http.StatusBadRequest, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, ErrQueueNotEmpty)
+		}},
+		"inexistente": {http.StatusNotFound, func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		}},
+		"falha da API": {http.StatusInternalServerError, func(t *testing.T, err error) {
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, ErrQueueNotEmpty)
+		}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestManagementClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "DELETE", r.Method)
+				assert.Equal(t, "/api/queues/prod/orders", r.URL.EscapedPath())
+				assert.Equal(t, "true", r.URL.Query().Get("if-empty"))
+				w.WriteHeader(tt.status)
+			})
+
+			tt.check(t, client.DeleteQueueIfEmpty("/prod", "orders"))
+		})
+	}
+}
+
+func TestQueueInfoManagement_DeliveryLimit(t *testing.T) {
+	limit, explicit := (&QueueInfoManagement{Type: "quorum"}).DeliveryLimit()
+	assert.False(t, explicit)
+	assert.Zero(t, limit)
+
+	limit, explicit = (&QueueInfoManagement{
+		Arguments: map[string]interface{}{"x-delivery-limit": float64(10)},
+	}).DeliveryLimit()
+	assert.True(t, explicit)
+	assert.Equal(t, 10, limit)
+
+	limit, _ = (&QueueInfoManagement{
+		Arguments:                 map[string]interface{}{"x-delivery-limit": float64(10)},
+		EffectivePolicyDefinition: map[string]interface{}{"delivery-limit": float64(3)},
+	}).DeliveryLimit()
+	assert.Equal(t, 3, limit, "vale o menor entre argumento e policy")
+}
@@ -0,0 +1,345 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// MessageRecord é uma linha do arquivo de exportação (NDJSON): propriedades
+// AMQP completas, headers com o tipo de cada valor e o body em base64.
+//
+// Os headers ficam fora de Properties; Properties.Headers só é lido de
+// arquivos antigos, gravados sem os tipos.
+type MessageRecord struct {
+	Exchange    string            `json:"exchange"`
+	RoutingKey  string            `json:"routing_key"`
+	Redelivered bool              `json:"redelivered,omitempty"`
+	Properties  MessageProperties `json:"properties"`
+	Headers     HeaderTable       `json:"headers,omitempty"`
+	Body        []byte            `json:"body"`
+}
+
+// NewMessageRecord converte uma delivery AMQP em registro de exportação
+func NewMessageRecord(d amqp.Delivery) MessageRecord {
+	record := MessageRecord{
+		Exchange:    d.Exchange,
+		RoutingKey:  d.RoutingKey,
+		Redelivered: d.Redelivered,
+		Properties: MessageProperties{
+			ContentType:     d.ContentType,
+			ContentEncoding: d.ContentEncoding,
+			DeliveryMode:    int(d.DeliveryMode),
+			Priority:        int(d.Priority),
+			CorrelationId:   d.CorrelationId,
+			ReplyTo:         d.ReplyTo,
+			Expiration:      d.Expiration,
+			MessageId:       d.MessageId,
+			Type:            d.Type,
+			UserId:          d.UserId,
+			AppId:           d.AppId,
+		},
+		Body: d.Body,
+	}
+	if !d.Timestamp.IsZero() {
+		record.Properties.Timestamp = d.Timestamp.Unix()
+	}
+	if len(d.Headers) > 0 {
+		record.Headers = HeaderTable(d.Headers)
+	}
+	return record
+}
+
+// MessageWriter grava registros NDJSON em um arquivo, um por linha
+type MessageWriter struct {
+	file    io.Writer
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewMessageWriter cria um writer de registros NDJSON
+func NewMessageWriter(w io.Writer) *MessageWriter {
+	buf := bufio.NewWriter(w)
+	return &MessageWriter{file: w, buf: buf, encoder: json.NewEncoder(buf)}
+}
+
+// Write grava um registro (bufferizado; veja Sync)
+func (w *MessageWriter) Write(record MessageRecord) error {
+	if err := w.encoder.Encode(record); err != nil {
+		return fmt.Errorf("erro ao gravar mensagem: %w", err)
+	}
+	return nil
+}
+
+// Sync descarrega o buffer e, se o destino for um arquivo, força a gravação em disco
+func (w *MessageWriter) Sync() error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if syncer, ok := w.file.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil {
+			return fmt.Errorf("erro ao sincronizar arquivo: %w", err)
+		}
+	}
+	return nil
+}
+
+// Publishing recria a mensagem AMQP a partir do registro (veja PublishingFromDelivery)
+func (r MessageRecord) Publishing() amqp.Publishing {
+	props := r.Properties
+	msg := amqp.Publishing{
+		ContentType:     props.ContentType,
+		ContentEncoding: props.ContentEncoding,
+		DeliveryMode:    uint8(props.DeliveryMode),
+		Priority:        uint8(props.Priority),
+		CorrelationId:   props.CorrelationId,
+		ReplyTo:         props.ReplyTo,
+		Expiration:      props.Expiration,
+		MessageId:       props.MessageId,
+		Type:            props.Type,
+		UserId:          props.UserId,
+		AppId:           props.AppId,
+		Body:            r.Body,
+	}
+	if props.Timestamp != 0 {
+		msg.Timestamp = time.Unix(props.Timestamp, 0)
+	}
+	if len(r.Headers) > 0 {
+		msg.Headers = amqp.Table(r.Headers)
+	} else if len(props.Headers) > 0 {
+		msg.Headers = toTable(props.Headers)
+	}
+	return msg
+}
+
+// HeaderTable são headers AMQP gravados com o tipo de cada valor, ex:
+// {"count":{"type":"long","value":3}}. Em JSON puro inteiros viram float64 e
+// timestamps viram texto; com o tipo, o import devolve int32, int64,
+// time.Time e tabelas aninhadas exatamente como foram lidos (o x-death,
+// por exemplo, continua legível pelo consumer).
+type HeaderTable amqp.Table
+
+// headerValue é um valor de header com o seu tipo AMQP
+type headerValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MarshalJSON grava cada header com o seu tipo
+func (t HeaderTable) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]headerValue, len(t))
+	for k, v := range t {
+		value, err := encodeHeaderValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", k, err)
+		}
+		fields[k] = value
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON lê headers gravados por MarshalJSON
+func (t *HeaderTable) UnmarshalJSON(data []byte) error {
+	var fields map[string]headerValue
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	table := make(HeaderTable, len(fields))
+	for k, field := range fields {
+		value, err := field.decode()
+		if err != nil {
+			return fmt.Errorf("header %s: %w", k, err)
+		}
+		table[k] = value
+	}
+	*t = table
+	return nil
+}
+
+func encodeHeaderValue(v interface{}) (headerValue, error) {
+	var typ string
+	switch value := v.(type) {
+	case nil:
+		return headerValue{Type: "void"}, nil
+	case bool:
+		typ = "bool"
+	case byte:
+		typ = "byte"
+	case int8:
+		typ = "int8"
+	case int16:
+		typ = "short"
+	case int:
+		// O driver envia int como inteiro de 32 bits
+		typ, v = "int", int32(value)
+	case int32:
+		typ = "int"
+	case int64:
+		typ = "long"
+	case float32:
+		typ = "float"
+	case float64:
+		typ = "double"
+	case amqp.Decimal:
+		typ = "decimal"
+	case string:
+		typ = "string"
+	case []byte:
+		typ = "bytes"
+	case time.Time:
+		typ = "timestamp"
+	case amqp.Table:
+		typ, v = "table", HeaderTable(value)
+	case map[string]interface{}:
+		typ, v = "table", HeaderTable(value)
+	case []interface{}:
+		list := make([]headerValue, len(value))
+		for i, item := range value {
+			encoded, err := encodeHeaderValue(item)
+			if err != nil {
+				return headerValue{}, err
+			}
+			list[i] = encoded
+		}
+		typ, v = "array", list
+	default:
+		return headerValue{}, fmt.Errorf("tipo %T não suportado", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return headerValue{}, err
+	}
+	return headerValue{Type: typ, Value: data}, nil
+}
+
+// decode converte o valor de volta para o tipo Go que o driver AMQP usa
+func (h headerValue) decode() (interface{}, error) {
+	var target interface{}
+	switch h.Type {
+	case "void":
+		return nil, nil
+	case "bool":
+		target = new(bool)
+	case "byte":
+		target = new(byte)
+	case "int8":
+		target = new(int8)
+	case "short":
+		target = new(int16)
+	case "int":
+		target = new(int32)
+	case "long":
+		target = new(int64)
+	case "float":
+		target = new(float32)
+	case "double":
+		target = new(float64)
+	case "decimal":
+		target = new(amqp.Decimal)
+	case "string":
+		target = new(string)
+	case "bytes":
+		target = new([]byte)
+	case "timestamp":
+		var ts time.Time
+		if err := json.Unmarshal(h.Value, &ts); err != nil {
+			return nil, fmt.Errorf("valor inválido para o tipo %s: %w", h.Type, err)
+		}
+		// Timestamps AMQP têm precisão de segundos; o driver os entrega no fuso local
+		return time.Unix(ts.Unix(), 0), nil
+	case "table":
+		var table HeaderTable
+		if err := json.Unmarshal(h.Value, &table); err != nil {
+			return nil, err
+		}
+		return amqp.Table(table), nil
+	case "array":
+		var items []headerValue
+		if err := json.Unmarshal(h.Value, &items); err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			value, err := item.decode()
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("tipo %q desconhecido", h.Type)
+	}
+
+	if err := json.Unmarshal(h.Value, target); err != nil {
+		return nil, fmt.Errorf("valor inválido para o tipo %s: %w", h.Type, err)
+	}
+	return reflect.ValueOf(target).Elem().Interface(), nil
+}
+
+// toTable converte mapas decodificados de JSON em amqp.Table (recursivamente),
+// já que o driver não aceita map[string]interface{} em headers
+func toTable(m map[string]interface{}) amqp.Table {
+	table := make(amqp.Table, len(m))
+	for k, v := range m {
+		table[k] = toTableValue(v)
+	}
+	return table
+}
+
+func toTableValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return toTable(value)
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = toTableValue(item)
+		}
+		return list
+	}
+	return v
+}
+
+// MessageReader lê registros de um arquivo NDJSON, linha a linha
+type MessageReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+// NewMessageReader cria um leitor de registros NDJSON
+func NewMessageReader(r io.Reader) *MessageReader {
+	return &MessageReader{reader: bufio.NewReader(r)}
+}
+
+// Next retorna o próximo registro e o número da sua linha (1-based).
+// Linhas em branco são ignoradas; retorna io.EOF no fim do arquivo.
+func (r *MessageReader) Next() (MessageRecord, int, error) {
+	for {
+		data, err := r.reader.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return MessageRecord{}, r.line, err
+		}
+		r.line++
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			if err != nil {
+				return MessageRecord{}, r.line, err
+			}
+			continue
+		}
+
+		var record MessageRecord
+		if jsonErr := json.Unmarshal(data, &record); jsonErr != nil {
+			return MessageRecord{}, r.line, fmt.Errorf("linha %d inválida: %w", r.line, jsonErr)
+		}
+		return record, r.line, nil
+	}
+}
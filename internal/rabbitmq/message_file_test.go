@@ -0,0 +1,203 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageRecord(t *testing.T) {
+	d := amqp.Delivery{
+		Exchange:      "events",
+		RoutingKey:    "orders.created",
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		Priority:      3,
+		CorrelationId: "corr-1",
+		ReplyTo:       "replies",
+		Expiration:    "60000",
+		MessageId:     "msg-1",
+		Timestamp:     time.Unix(1700000000, 0),
+		Type:          "order",
+		AppId:         "shop",
+		Headers:       amqp.Table{"tenant": "acme"},
+		Body:          []byte{0xff, 0x00},
+	}
+
+	var buf bytes.Buffer
+	writer := NewMessageWriter(&buf)
+	require.NoError(t, writer.Write(NewMessageRecord(d)))
+	assert.Empty(t, buf.String(), "gravação é bufferizada até o Sync")
+	require.NoError(t, writer.Sync())
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	assert.Equal(t, "/wA=", raw["body"], "body em base64")
+
+	var record MessageRecord
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "events", record.Exchange)
+	assert.Equal(t, "orders.created", record.RoutingKey)
+	assert.Equal(t, 2, record.Properties.DeliveryMode)
+	assert.Equal(t, 3, record.Properties.Priority)
+	assert.Equal(t, "corr-1", record.Properties.CorrelationId)
+	assert.Equal(t, "replies", record.Properties.ReplyTo)
+	assert.Equal(t, "60000", record.Properties.Expiration)
+	assert.Equal(t, int64(1700000000), record.Properties.Timestamp)
+	assert.Equal(t, "shop", record.Properties.AppId)
+	assert.Equal(t, "acme", record.Headers["tenant"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "value": "acme"},
+		raw["headers"].(map[string]interface{})["tenant"], "headers gravados com o tipo")
+	assert.Equal(t, d.Body, record.Body)
+}
+
+func TestMessageReader_RoundTrip(t *testing.T) {
+	d := amqp.Delivery{
+		RoutingKey:   "orders",
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Priority:     5,
+		Timestamp:    time.Unix(1700000000, 0),
+		Headers:      amqp.Table{"tenant": "acme", "meta": amqp.Table{"region": "br"}},
+		Body:         []byte(`{"id":1}`),
+	}
+
+	var buf bytes.Buffer
+	writer := NewMessageWriter(&buf)
+	require.NoError(t, writer.Write(NewMessageRecord(d)))
+	require.NoError(t, writer.Sync())
+	buf.WriteString("\n")
+	require.NoError(t, writer.Write(NewMessageRecord(amqp.Delivery{Body: []byte("b")})))
+	require.NoError(t, writer.Sync())
+
+	reader := NewMessageReader(&buf)
+
+	record, line, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, line)
+
+	msg := record.Publishing()
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+	assert.Equal(t, uint8(5), msg.Priority)
+	assert.Equal(t, int64(1700000000), msg.Timestamp.Unix())
+	assert.Equal(t, d.Body, msg.Body)
+	assert.Equal(t, amqp.Table{"region": "br"}, msg.Headers["meta"], "mapas aninhados viram amqp.Table")
+	require.NoError(t, msg.Headers.Validate())
+
+	record, line, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, line, "linhas em branco contam na numeração")
+	assert.Equal(t, []byte("b"), record.Body)
+	assert.True(t, record.Publishing().Timestamp.IsZero())
+
+	_, _, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestMessageRecord_TypedHeaders(t *testing.T) {
+	died := time.Unix(1700000000, 0)
+	headers := amqp.Table{
+		"x-death": []interface{}{
+			amqp.Table{
+				"count":        int64(3),
+				"exchange":     "orders.wait.exchange",
+				"queue":        "orders.wait",
+				"reason":       "expired",
+				"routing-keys": []interface{}{"orders.attempt.1"},
+				"time":         died,
+			},
+			amqp.Table{
+				"count":        int64(1),
+				"exchange":     "",
+				"queue":        "orders",
+				"reason":       "rejected",
+				"routing-keys": []interface{}{"orders"},
+				"time":         died,
+			},
+		},
+		"x-first-death-reason": "rejected",
+		"attempt":              int32(2),
+		"small":                int16(7),
+		"tiny":                 int8(-1),
+		"flag":                 true,
+		"ratio":                0.5,
+		"precision":            float32(1.5),
+		"price":                amqp.Decimal{Scale: 2, Value: 1999},
+		"raw":                  []byte{0xff, 0x00},
+		"sent":                 died,
+		"nothing":              nil,
+		"meta":                 amqp.Table{"region": "br", "shard": int64(4)},
+	}
+
+	var buf bytes.Buffer
+	writer := NewMessageWriter(&buf)
+	require.NoError(t, writer.Write(NewMessageRecord(amqp.Delivery{Headers: headers, Body: []byte("x")})))
+	require.NoError(t, writer.Sync())
+
+	record, _, err := NewMessageReader(&buf).Next()
+	require.NoError(t, err)
+
+	msg := record.Publishing()
+	require.NoError(t, msg.Headers.Validate())
+	assert.Equal(t, headers, msg.Headers)
+
+	deaths := msg.Headers["x-death"].([]interface{})
+	assert.IsType(t, int64(0), deaths[0].(amqp.Table)["count"])
+	assert.IsType(t, time.Time{}, deaths[0].(amqp.Table)["time"])
+	assert.IsType(t, int32(0), msg.Headers["attempt"])
+}
+
+func TestMessageRecord_IntHeader(t *testing.T) {
+	data, err := json.Marshal(HeaderTable{"n": 5})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"n":{"type":"int","value":5}}`, string(data), "int vai como inteiro de 32 bits, como no driver")
+
+	_, err = json.Marshal(HeaderTable{"n": uint64(5)})
+	assert.ErrorContains(t, err, "tipo uint64 não suportado")
+
+	var table HeaderTable
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"n":{"type":"uuid","value":"x"}}`), &table), `tipo "uuid" desconhecido`)
+}
+
+func TestMessageRecord_LegacyHeaders(t *testing.T) {
+	reader := NewMessageReader(strings.NewReader(`{"properties":{"headers":{"tenant":"acme","meta":{"region":"br"}}},"body":"YQ=="}` + "\n"))
+
+	record, _, err := reader.Next()
+	require.NoError(t, err)
+	msg := record.Publishing()
+	assert.Equal(t, amqp.Table{"tenant": "acme", "meta": amqp.Table{"region": "br"}}, msg.Headers, "arquivos sem tipos continuam legíveis")
+}
+
+func TestMessageReader_InvalidLine(t *testing.T) {
+	reader := NewMessageReader(strings.NewReader("{\"body\":\"YQ==\"}\nnão é json\n"))
+
+	_, _, err := reader.Next()
+	require.NoError(t, err)
+
+	_, line, err := reader.Next()
+	require.Error(t, err)
+	assert.Equal(t, 2, line)
+	assert.Contains(t, err.Error(), "linha 2")
+}
+
+func TestRewriteHeaders(t *testing.T) {
+	msg := amqp.Publishing{Headers: amqp.Table{"x-death": []interface{}{}, "tenant": "acme"}}
+	rewriteHeaders(&msg, amqp.Table{"source": "replay", "tenant": "globex"}, []string{"x-death"})
+	assert.Equal(t, amqp.Table{"source": "replay", "tenant": "globex"}, msg.Headers)
+
+	msg = amqp.Publishing{Headers: amqp.Table{"x-death": "x"}}
+	rewriteHeaders(&msg, nil, []string{"x-death"})
+	assert.Nil(t, msg.Headers)
+
+	msg = amqp.Publishing{}
+	rewriteHeaders(&msg, amqp.Table{"source": "replay"}, nil)
+	assert.Equal(t, amqp.Table{"source": "replay"}, msg.Headers)
+}
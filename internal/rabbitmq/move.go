@@ -0,0 +1,169 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// moveDefaultBatch é o tamanho padrão da janela de mensagens em voo no destino
+const moveDefaultBatch = 100
+
+// MoveOptions são opções para mover mensagens entre filas
+type MoveOptions struct {
+	Max       int           // Máximo de mensagens movidas (0 = todas)
+	Filter    MessageFilter // Apenas mensagens que casam com o filtro
+	BatchSize int           // Mensagens em voo aguardando confirmação (padrão: 100)
+	Timeout   time.Duration // Timeout de cada confirmação (padrão: 30s)
+
+	// OnProgress é chamado a cada BatchSize mensagens movidas
+	OnProgress func(result MoveResult)
+}
+
+// MoveResult resume uma movimentação
+type MoveResult struct {
+	Scanned int          // Mensagens lidas da origem
+	Moved   int          // Mensagens confirmadas no destino e removidas da origem
+	Stats   PublishStats // Publicações no destino (vazão e reenvios)
+}
+
+// MoveMessages move mensagens da fila source para a fila target de dest,
+// que pode ser o próprio cliente ou uma conexão com outro broker.
+//
+// As publicações no destino passam por um ConfirmPipeline e cada mensagem só
+// é confirmada (ack) na origem depois que o broker de destino confirma a
+// publicação; se o processo cair no meio, as mensagens pendentes voltam para
+// a origem e nada é perdido (no pior caso, as que estavam em voo ficam
+// duplicadas no destino). Mensagens fora do filtro ficam pendentes até o fim
+// e voltam para a origem na ordem original.
+//
+// São lidas no máximo as mensagens que estavam na origem no início: o que
+// chega depois (publicações novas ou mensagens que voltam do destino por
+// DLX ou shovel) fica para a próxima execução.
+func (c *Client) MoveMessages(ctx context.Context, source string, dest *Client, target string, opts MoveOptions) (*MoveResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = moveDefaultBatch
+	}
+
+	result := &MoveResult{}
+
+	srcChannel, err := c.conn.Channel()
+	if err != nil {
+		return result, fmt.Errorf("erro ao abrir canal de origem: %w", err)
+	}
+	defer srcChannel.Close()
+
+	sourceQueue, err := srcChannel.QueueDeclarePassive(source, false, false, false, false, nil)
+	if err != nil {
+		return result, fmt.Errorf("fila de origem não encontrada: %s", source)
+	}
+	available := sourceQueue.Messages
+
+	dstChannel, err := dest.conn.Channel()
+	if err != nil {
+		return result, fmt.Errorf("erro ao abrir canal de destino: %w", err)
+	}
+	defer dstChannel.Close()
+
+	if _, err := dstChannel.QueueDeclarePassive(target, false, false, false, false, nil); err != nil {
+		return result, fmt.Errorf("fila de destino não encontrada: %s", target)
+	}
+	pipeline, err := NewConfirmPipeline(dstChannel, PipelineOptions{Window: opts.BatchSize, Timeout: opts.Timeout, User: dest.config.Username})
+	if err != nil {
+		return result, err
+	}
+
+	// Tudo que não foi confirmado no destino volta para a origem
+	var skipped []uint64
+	var inFlight []amqp.Delivery
+	defer func() {
+		for _, tag := range skipped {
+			srcChannel.Nack(tag, false, true)
+		}
+		for _, d := range inFlight {
+			d.Nack(false, true)
+		}
+		result.Stats = pipeline.Stats()
+	}()
+
+	// As confirmações chegam na ordem de publicação: a mais antiga em voo é a confirmada
+	onConfirm := func() error {
+		d := inFlight[0]
+		inFlight = inFlight[1:]
+		if err := d.Ack(false); err != nil {
+			return fmt.Errorf("mensagem publicada no destino, mas não removida da origem: %w", err)
+		}
+		result.Moved++
+		if opts.OnProgress != nil && result.Moved%opts.BatchSize == 0 {
+			opts.OnProgress(*result)
+		}
+		return nil
+	}
+
+	for result.Scanned < available && (opts.Max <= 0 || result.Moved+len(inFlight) < opts.Max) {
+		if err := ctx.Err(); err != nil {
+			if flushErr := pipeline.Flush(context.Background()); flushErr != nil {
+				return result, flushErr
+			}
+			return result, err
+		}
+
+		d, ok, err := srcChannel.Get(source, false)
+		if err != nil {
+			return result, fmt.Errorf("erro ao ler fila de origem: %w", err)
+		}
+		if !ok {
+			break
+		}
+		result.Scanned++
+
+		if !opts.Filter.Match(d.Headers, d.Body) {
+			skipped = append(skipped, d.DeliveryTag)
+			continue
+		}
+
+		inFlight = append(inFlight, d)
+		if err := pipeline.Publish(ctx, "", target, PublishingFromDelivery(d), onConfirm); err != nil {
+			return result, fmt.Errorf("erro ao publicar no destino: %w", err)
+		}
+	}
+
+	if err := pipeline.Flush(ctx); err != nil {
+		return result, fmt.Errorf("erro ao publicar no destino: %w", err)
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(*result)
+	}
+	return result, nil
+}
+
+// PublishingFromDelivery recria a mensagem recebida com as propriedades
+// originais. É a regra de toda republicação do gohop (move, import, spool,
+// replay), seguida também por MessageRecord.Publishing e SavedMessage.Publishing:
+//
+//   - Expiration é mantida: o TTL é relativo e recomeça a contar na
+//     republicação, então a mensagem não expira antes do que expiraria se
+//     tivesse acabado de ser publicada.
+//   - UserId é mantido aqui, mas o ConfirmPipeline o remove (e conta em
+//     PublishStats.UserIdDropped) quando não é o usuário da conexão, que é o
+//     único aceito pelo broker.
+func PublishingFromDelivery(d amqp.Delivery) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:         d.Headers,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    d.DeliveryMode,
+		Priority:        d.Priority,
+		CorrelationId:   d.CorrelationId,
+		ReplyTo:         d.ReplyTo,
+		Expiration:      d.Expiration,
+		MessageId:       d.MessageId,
+		Timestamp:       d.Timestamp,
+		Type:            d.Type,
+		UserId:          d.UserId,
+		AppId:           d.AppId,
+		Body:            d.Body,
+	}
+}
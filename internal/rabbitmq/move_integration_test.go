@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveMessages_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	suffix := time.Now().Format("20060102150405")
+	source := "test_move_src_" + suffix
+	target := "test_move_dst_" + suffix
+	for _, name := range []string{source, target} {
+		require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: name, Type: "classic", Durable: true}))
+		defer client.DeleteQueue(name, false, false, false)
+	}
+
+	for _, tenant := range []string{"acme", "globex", "acme", "acme"} {
+		_, err := client.Publish(context.Background(), PublishOptions{RoutingKey: source}, amqp.Publishing{
+			Headers: amqp.Table{"tenant": tenant},
+			Body:    []byte("hello"),
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	result, err := client.MoveMessages(context.Background(), source, client, target, MoveOptions{
+		Max:       2,
+		BatchSize: 1,
+		Filter:    MessageFilter{Headers: map[string]string{"tenant": "acme"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Moved)
+
+	info, err := client.GetQueueInfo(source)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Messages, "não movidas e fora do filtro ficam na origem")
+
+	info, err = client.GetQueueInfo(target)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Messages)
+
+	// Destino inexistente: nada sai da origem
+	_, err = client.MoveMessages(context.Background(), source, client, target+".inexistente", MoveOptions{})
+	require.Error(t, err)
+
+	info, err = client.GetQueueInfo(source)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Messages)
+}
+
+func TestMoveMessages_StopsAtInitialCount_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queue := "test_move_loop_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queue, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queue, false, false, false)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Publish(context.Background(), PublishOptions{RoutingKey: queue}, amqp.Publishing{Body: []byte("hello")}, nil)
+		require.NoError(t, err)
+	}
+
+	// As mensagens movidas voltam para a própria origem: sem o limite inicial
+	// o move sem --max nunca terminaria
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := client.MoveMessages(ctx, queue, client, queue, MoveOptions{BatchSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Scanned)
+	assert.Equal(t, 3, result.Moved)
+
+	info, err := client.GetQueueInfo(queue)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.Messages)
+}
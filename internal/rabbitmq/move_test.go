@@ -0,0 +1,38 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishingFromDelivery(t *testing.T) {
+	d := amqp.Delivery{
+		Headers:         amqp.Table{"tenant": "acme"},
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+		DeliveryMode:    amqp.Persistent,
+		Priority:        4,
+		CorrelationId:   "corr-1",
+		ReplyTo:         "replies",
+		Expiration:      "60000",
+		MessageId:       "msg-1",
+		Timestamp:       time.Unix(1700000000, 0),
+		Type:            "order",
+		AppId:           "shop",
+		Body:            []byte("hello"),
+	}
+
+	msg := PublishingFromDelivery(d)
+	assert.Equal(t, d.Headers, msg.Headers)
+	assert.Equal(t, "gzip", msg.ContentEncoding)
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+	assert.Equal(t, uint8(4), msg.Priority)
+	assert.Equal(t, "replies", msg.ReplyTo)
+	assert.Equal(t, "60000", msg.Expiration)
+	assert.Equal(t, d.Timestamp, msg.Timestamp)
+	assert.Equal(t, "order", msg.Type)
+	assert.Equal(t, d.Body, msg.Body)
+}
@@ -0,0 +1,116 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// PublishOptions são opções para publicar uma mensagem avulsa
+type PublishOptions struct {
+	Exchange   string        // Exchange de destino (vazio = default exchange)
+	RoutingKey string        // Routing key (no default exchange, o nome da fila)
+	Count      int           // Quantas vezes publicar a mensagem (padrão: 1)
+	Timeout    time.Duration // Timeout de confirmação por mensagem (padrão: 5s)
+}
+
+// PublishResult resume uma publicação
+type PublishResult struct {
+	Confirmed int    // Mensagens confirmadas e roteadas pelo broker
+	Returned  int    // Mensagens devolvidas sem rota (mandatory)
+	ReplyText string // Motivo da última devolução (ex: NO_ROUTE)
+}
+
+// ParseHeaders converte flags "chave=valor" em headers AMQP (valores como texto)
+func ParseHeaders(values []string) (amqp.Table, error) {
+	headers := make(amqp.Table, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("header inválido %q: use chave=valor", value)
+		}
+		headers[key] = val
+	}
+	return headers, nil
+}
+
+// Publish publica a mensagem opts.Count vezes com mandatory=true, aguardando a
+// confirmação do broker para cada uma. Mensagens sem rota são devolvidas
+// (basic.return) antes do ack e contadas em Returned, sem interromper o envio.
+func (c *Client) Publish(ctx context.Context, opts PublishOptions, msg amqp.Publishing, onProgress func(current, total int)) (*PublishResult, error) {
+	if opts.Count <= 0 {
+		opts.Count = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	result := &PublishResult{}
+
+	// Canal próprio: o modo confirm e o NotifyReturn não afetam o canal compartilhado
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return result, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	if err := channel.Confirm(false); err != nil {
+		return result, fmt.Errorf("erro ao habilitar modo de confirmação: %w", err)
+	}
+	returns := channel.NotifyReturn(make(chan amqp.Return, 1))
+
+	for i := 1; i <= opts.Count; i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		returned, err := publishWithConfirm(ctx, channel, returns, opts, msg)
+		if err != nil {
+			return result, fmt.Errorf("erro ao publicar mensagem %d: %w", i, err)
+		}
+		if returned != nil {
+			result.Returned++
+			result.ReplyText = returned.ReplyText
+		} else {
+			result.Confirmed++
+		}
+
+		if onProgress != nil {
+			onProgress(i, opts.Count)
+		}
+	}
+
+	return result, nil
+}
+
+// publishWithConfirm publica uma mensagem e aguarda a confirmação.
+// Retorna o basic.return recebido quando a mensagem não teve rota.
+func publishWithConfirm(ctx context.Context, channel *amqp.Channel, returns chan amqp.Return, opts PublishOptions, msg amqp.Publishing) (*amqp.Return, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, opts.Exchange, opts.RoutingKey, true, false, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("timeout aguardando confirmação: %w", err)
+	}
+
+	// O broker envia o basic.return antes do ack da mesma mensagem
+	select {
+	case ret := <-returns:
+		return &ret, nil
+	default:
+	}
+
+	if !acked {
+		return nil, fmt.Errorf("mensagem não foi confirmada pelo broker (NACK)")
+	}
+	return nil, nil
+}
@@ -0,0 +1,45 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_publish_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	msg := amqp.Publishing{Body: []byte("hello"), Headers: amqp.Table{"tenant": "acme"}}
+
+	result, err := client.Publish(context.Background(), PublishOptions{RoutingKey: queueName, Count: 3}, msg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Confirmed)
+	assert.Equal(t, 0, result.Returned)
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 3, info.Messages)
+
+	// Sem fila com esse nome a mensagem é devolvida (mandatory)
+	result, err = client.Publish(context.Background(), PublishOptions{RoutingKey: queueName + ".missing"}, msg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Confirmed)
+	assert.Equal(t, 1, result.Returned)
+	assert.Equal(t, "NO_ROUTE", result.ReplyText)
+}
@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -127,8 +128,19 @@ func (c *Client) PurgeQueue(name string, noWait bool) (int, error) {
 }
 
 // QueueExists verifica se uma fila existe
+// Usa um canal temporário: o declare passivo de uma fila inexistente fecha o canal
 func (c *Client) QueueExists(name string) (bool, error) {
-	_, err := c.channel.QueueDeclarePassive(
+	if c.conn == nil || c.conn.IsClosed() {
+		return false, fmt.Errorf("conexão fechada")
+	}
+
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return false, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	_, err = channel.QueueDeclarePassive(
 		name,
 		false,
 		false,
@@ -149,14 +161,83 @@ func (c *Client) QueueExists(name string) (bool, error) {
 	return true, nil
 }
 
-// SavedMessage representa uma mensagem salva para migração
+// SavedMessage representa uma mensagem salva para migração, com todas as
+// propriedades AMQP e a informação de roteamento original
 type SavedMessage struct {
-	Body        []byte
-	ContentType string
-	Headers     map[string]interface{}
-	Priority    uint8
-	MessageId   string
-	Timestamp   int64
+	Body            []byte                 `json:"body"`
+	ContentType     string                 `json:"content_type,omitempty"`
+	ContentEncoding string                 `json:"content_encoding,omitempty"`
+	Headers         map[string]interface{} `json:"headers,omitempty"`
+	DeliveryMode    uint8                  `json:"delivery_mode,omitempty"`
+	Priority        uint8                  `json:"priority,omitempty"`
+	CorrelationId   string                 `json:"correlation_id,omitempty"`
+	ReplyTo         string                 `json:"reply_to,omitempty"`
+	Expiration      string                 `json:"expiration,omitempty"`
+	MessageId       string                 `json:"message_id,omitempty"`
+	Timestamp       int64                  `json:"timestamp,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	UserId          string                 `json:"user_id,omitempty"`
+	AppId           string                 `json:"app_id,omitempty"`
+
+	// Roteamento original (informativo: a republicação vai direto para a fila)
+	Exchange   string `json:"exchange,omitempty"`
+	RoutingKey string `json:"routing_key,omitempty"`
+}
+
+// NewSavedMessage copia a mensagem recebida com todas as suas propriedades
+func NewSavedMessage(d amqp.Delivery) SavedMessage {
+	msg := SavedMessage{
+		Body:            d.Body,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    d.DeliveryMode,
+		Priority:        d.Priority,
+		CorrelationId:   d.CorrelationId,
+		ReplyTo:         d.ReplyTo,
+		Expiration:      d.Expiration,
+		MessageId:       d.MessageId,
+		Type:            d.Type,
+		UserId:          d.UserId,
+		AppId:           d.AppId,
+		Exchange:        d.Exchange,
+		RoutingKey:      d.RoutingKey,
+	}
+	if !d.Timestamp.IsZero() {
+		msg.Timestamp = d.Timestamp.Unix()
+	}
+	if d.Headers != nil {
+		msg.Headers = make(map[string]interface{}, len(d.Headers))
+		for k, v := range d.Headers {
+			msg.Headers[k] = v
+		}
+	}
+	return msg
+}
+
+// Publishing recria a mensagem AMQP com as propriedades originais
+// (veja PublishingFromDelivery)
+func (m SavedMessage) Publishing() amqp.Publishing {
+	msg := amqp.Publishing{
+		ContentType:     m.ContentType,
+		ContentEncoding: m.ContentEncoding,
+		DeliveryMode:    m.DeliveryMode,
+		Priority:        m.Priority,
+		CorrelationId:   m.CorrelationId,
+		ReplyTo:         m.ReplyTo,
+		Expiration:      m.Expiration,
+		MessageId:       m.MessageId,
+		Type:            m.Type,
+		UserId:          m.UserId,
+		AppId:           m.AppId,
+		Body:            m.Body,
+	}
+	if m.Timestamp != 0 {
+		msg.Timestamp = time.Unix(m.Timestamp, 0)
+	}
+	if m.Headers != nil {
+		msg.Headers = toTable(m.Headers)
+	}
+	return msg
 }
 
 // DrainQueue consome todas as mensagens de uma fila e retorna elas
@@ -189,24 +270,8 @@ func (c *Client) DrainQueue(queueName string, onProgress func(current, total int
 
 		count++
 
-		// Salvar mensagem
-		savedMsg := SavedMessage{
-			Body:        msg.Body,
-			ContentType: msg.ContentType,
-			Priority:    msg.Priority,
-			MessageId:   msg.MessageId,
-			Timestamp:   msg.Timestamp.Unix(),
-		}
-
-		// Copiar headers se existirem
-		if msg.Headers != nil {
-			savedMsg.Headers = make(map[string]interface{})
-			for k, v := range msg.Headers {
-				savedMsg.Headers[k] = v
-			}
-		}
-
-		messages = append(messages, savedMsg)
+		// Salvar mensagem com todas as propriedades
+		messages = append(messages, NewSavedMessage(msg))
 
 		// ACK a mensagem (remove da fila)
 		if err := msg.Ack(false); err != nil {
@@ -226,71 +291,50 @@ func (c *Client) DrainQueue(queueName string, onProgress func(current, total int
 	return messages, nil
 }
 
-// PublishMessages republica mensagens em uma fila com confirmação síncrona
-func (c *Client) PublishMessages(queueName string, messages []SavedMessage, onProgress func(current, total int)) error {
+// PublishMessages republica mensagens em uma fila por um ConfirmPipeline,
+// mantendo várias confirmações pendentes em vez de aguardar uma a uma.
+// Mensagens recusadas ou devolvidas são reenviadas; onProgress é chamado a
+// cada mensagem confirmada.
+func (c *Client) PublishMessages(queueName string, messages []SavedMessage, onProgress func(current, total int)) (*PublishStats, error) {
 	total := len(messages)
 	if total == 0 {
-		return nil
+		return &PublishStats{}, nil
 	}
 
-	// Habilitar modo de confirmação para garantir que mensagens sejam persistidas
-	if err := c.channel.Confirm(false); err != nil {
-		return fmt.Errorf("erro ao habilitar modo de confirmação: %w", err)
+	// Canal próprio: o modo confirm não afeta o canal compartilhado
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return &PublishStats{}, fmt.Errorf("erro ao abrir canal: %w", err)
 	}
+	defer channel.Close()
 
-	// Canal para receber confirmações
-	confirms := c.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
-
-	for i, msg := range messages {
-		// Construir headers AMQP
-		var headers amqp.Table
-		if msg.Headers != nil {
-			headers = make(amqp.Table)
-			for k, v := range msg.Headers {
-				headers[k] = v
-			}
-		}
+	pipeline, err := NewConfirmPipeline(channel, PipelineOptions{User: c.config.Username})
+	if err != nil {
+		return &PublishStats{}, err
+	}
 
-		// Garantir ContentType
-		contentType := msg.ContentType
-		if contentType == "" {
-			contentType = "application/octet-stream"
+	confirmed := 0
+	onConfirm := func() error {
+		confirmed++
+		if onProgress != nil {
+			onProgress(confirmed, total)
 		}
+		return nil
+	}
 
+	ctx := context.Background()
+	for _, msg := range messages {
 		// Publicar diretamente na fila (exchange vazio, routing key = nome da fila)
-		err := c.channel.Publish(
-			"",        // exchange (default)
-			queueName, // routing key = nome da fila
-			false,     // mandatory
-			false,     // immediate
-			amqp.Publishing{
-				ContentType:  contentType,
-				Body:         msg.Body,
-				Headers:      headers,
-				Priority:     msg.Priority,
-				MessageId:    msg.MessageId,
-				DeliveryMode: amqp.Persistent, // Mensagens persistentes
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("erro ao publicar mensagem %d: %w", i+1, err)
-		}
-
-		// Aguardar confirmação do broker (síncrono, uma de cada vez)
-		select {
-		case confirmed := <-confirms:
-			if !confirmed.Ack {
-				return fmt.Errorf("mensagem %d não foi confirmada pelo broker (NACK)", i+1)
-			}
-		case <-time.After(5 * time.Second):
-			return fmt.Errorf("timeout aguardando confirmação da mensagem %d", i+1)
-		}
-
-		// Callback de progresso
-		if onProgress != nil {
-			onProgress(i+1, total)
+		if err := pipeline.Publish(ctx, "", queueName, msg.Publishing(), onConfirm); err != nil {
+			stats := pipeline.Stats()
+			return &stats, fmt.Errorf("erro ao publicar mensagem %d: %w", confirmed+1, err)
 		}
 	}
 
-	return nil
+	err = pipeline.Flush(ctx)
+	stats := pipeline.Stats()
+	if err != nil {
+		return &stats, fmt.Errorf("erro ao publicar mensagem %d: %w", confirmed+1, err)
+	}
+	return &stats, nil
 }
@@ -1,9 +1,14 @@
 package rabbitmq
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
+	"time"
 
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateQueueOptions(t *testing.T) {
@@ -41,3 +46,63 @@ func TestQueueInfo(t *testing.T) {
 	assert.Equal(t, 3, info.Unacked)
 	assert.Equal(t, "quorum", info.Type)
 }
+
+// fullDelivery retorna uma mensagem com todas as propriedades AMQP preenchidas
+func fullDelivery() amqp.Delivery {
+	return amqp.Delivery{
+		Headers:         amqp.Table{"tenant": "acme"},
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+		DeliveryMode:    amqp.Transient,
+		Priority:        7,
+		CorrelationId:   "corr-1",
+		ReplyTo:         "replies",
+		Expiration:      "60000",
+		MessageId:       "msg-1",
+		Timestamp:       time.Unix(1700000000, 0),
+		Type:            "order.created",
+		UserId:          "guest",
+		AppId:           "shop",
+		Exchange:        "events",
+		RoutingKey:      "orders.created",
+		Body:            []byte(`{"id":1}`),
+	}
+}
+
+func TestSavedMessage_RoundTripsEveryProperty(t *testing.T) {
+	d := fullDelivery()
+	expected := PublishingFromDelivery(d)
+
+	// Garante que o teste cobre todos os campos de amqp.Publishing
+	value := reflect.ValueOf(expected)
+	for i := 0; i < value.NumField(); i++ {
+		assert.False(t, value.Field(i).IsZero(), "campo %s sem valor no teste", value.Type().Field(i).Name)
+	}
+
+	saved := NewSavedMessage(d)
+	assert.Equal(t, expected, saved.Publishing(), "em memória")
+
+	data, err := json.Marshal(saved)
+	require.NoError(t, err)
+
+	var decoded SavedMessage
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "events", decoded.Exchange)
+	assert.Equal(t, "orders.created", decoded.RoutingKey)
+
+	publishing := decoded.Publishing()
+	assert.Equal(t, expected.Timestamp.Unix(), publishing.Timestamp.Unix())
+	publishing.Timestamp = expected.Timestamp
+	assert.Equal(t, expected, publishing, "após gravar em arquivo")
+}
+
+func TestSavedMessage_ZeroValues(t *testing.T) {
+	saved := NewSavedMessage(amqp.Delivery{Body: []byte("x")})
+	assert.Zero(t, saved.Timestamp, "timestamp ausente não vira data negativa")
+
+	msg := saved.Publishing()
+	assert.True(t, msg.Timestamp.IsZero())
+	assert.Zero(t, msg.DeliveryMode, "delivery mode original é mantido")
+	assert.Empty(t, msg.ContentType)
+	assert.Nil(t, msg.Headers)
+}
@@ -0,0 +1,100 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ScanOptions são opções para procurar mensagens em uma fila
+type ScanOptions struct {
+	Filter     MessageFilter // Critérios de busca
+	MaxMatches int           // Encerra após N mensagens encontradas (0 = sem limite)
+	Limit      int           // Máximo de mensagens lidas (0 = a fila inteira)
+
+	// OnProgress é chamado após cada mensagem lida
+	OnProgress func(result ScanResult)
+}
+
+// ScanResult resume uma busca
+type ScanResult struct {
+	Scanned int // Mensagens lidas
+	Matched int // Mensagens que casaram com o filtro
+}
+
+// ScanQueue lê a fila sem consumir as mensagens e chama onMatch para cada uma
+// que casa com o filtro, com sua posição na fila (1 = a mais antiga).
+//
+// As mensagens são lidas com basic.get e ficam pendentes (unacked) até o fim
+// da busca, quando voltam para a fila na ordem original. Enquanto isso elas
+// não são entregues aos consumers. Em filas quorum a devolução conta uma
+// entrega (delivery-count) para cada mensagem lida; veja DeliveryLimit.
+func (c *Client) ScanQueue(ctx context.Context, queueName string, opts ScanOptions, onMatch func(position int, d amqp.Delivery)) (*ScanResult, error) {
+	result := &ScanResult{}
+
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return result, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	var lastUnacked uint64
+	defer func() {
+		if lastUnacked > 0 {
+			channel.Nack(lastUnacked, true, true)
+		}
+	}()
+
+	for opts.Limit <= 0 || result.Scanned < opts.Limit {
+		if opts.MaxMatches > 0 && result.Matched >= opts.MaxMatches {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		d, ok, err := channel.Get(queueName, false)
+		if err != nil {
+			return result, fmt.Errorf("erro ao ler fila: %w", err)
+		}
+		if !ok {
+			break
+		}
+		lastUnacked = d.DeliveryTag
+		result.Scanned++
+
+		if opts.Filter.Match(d.Headers, d.Body) {
+			result.Matched++
+			onMatch(result.Scanned, d)
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(*result)
+		}
+	}
+
+	return result, nil
+}
+
+// DefaultQuorumDeliveryLimit é o delivery-limit de filas quorum sem limite
+// definido a partir do RabbitMQ 4.0
+const DefaultQuorumDeliveryLimit = 20
+
+// DeliveryLimit retorna o delivery-limit da fila quorum: o menor entre o
+// argumento x-delivery-limit e o do policy, como faz o broker. explicit é
+// false quando nenhum foi definido (no RabbitMQ 4 vale
+// DefaultQuorumDeliveryLimit). Um limite negativo desativa a contagem.
+func (q *QueueInfoManagement) DeliveryLimit() (limit int, explicit bool) {
+	for _, value := range []interface{}{q.Arguments["x-delivery-limit"], q.EffectivePolicyDefinition["delivery-limit"]} {
+		n, ok := value.(float64)
+		if !ok {
+			continue
+		}
+		if !explicit || int(n) < limit {
+			limit = int(n)
+		}
+		explicit = true
+	}
+	return limit, explicit
+}
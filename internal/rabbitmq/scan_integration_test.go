@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanQueue_KeepsMessages_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	queueName := "test_scan_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+
+	channel := client.GetChannel()
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, channel.Publish("", queueName, false, false, amqp.Publishing{
+			MessageId: fmt.Sprintf("msg-%d", i),
+			Body:      []byte(fmt.Sprintf(`{"id": %d, "even": %t}`, i, i%2 == 0)),
+		}))
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	filter := MessageFilter{JSONPath: mustJSONPath(t, "$.even==true")}
+	var positions []int
+	result, err := client.ScanQueue(context.Background(), queueName, ScanOptions{Filter: filter}, func(position int, d amqp.Delivery) {
+		positions = append(positions, position)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, result.Scanned)
+	assert.Equal(t, 5, result.Matched)
+	assert.Equal(t, []int{2, 4, 6, 8, 10}, positions)
+
+	// MaxMatches encerra a leitura cedo
+	result, err = client.ScanQueue(context.Background(), queueName, ScanOptions{Filter: filter, MaxMatches: 2}, func(int, amqp.Delivery) {})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Scanned)
+
+	time.Sleep(500 * time.Millisecond)
+	q, err := channel.QueueDeclarePassive(queueName, true, false, false, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 10, q.Messages)
+
+	// A ordem original é preservada
+	d, ok, err := channel.Get(queueName, true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "msg-1", d.MessageId)
+}
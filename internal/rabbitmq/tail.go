@@ -0,0 +1,168 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// O que fazer com cada mensagem exibida no modo Consume
+const (
+	AckModeAck     = "ack"     // Remove a mensagem da fila
+	AckModeNack    = "nack"    // Rejeita sem devolver (vai para o DLX, se houver)
+	AckModeRequeue = "requeue" // Mantém pendente e devolve para a fila ao terminar
+)
+
+// tailPrefetch é o prefetch do modo Consume quando as mensagens são confirmadas
+const tailPrefetch = 50
+
+// ValidAckMode verifica se o modo de confirmação é suportado
+func ValidAckMode(mode string) bool {
+	switch mode {
+	case AckModeAck, AckModeNack, AckModeRequeue:
+		return true
+	}
+	return false
+}
+
+// TailOptions são opções para acompanhar as mensagens de uma fila
+type TailOptions struct {
+	Filter MessageFilter // Apenas mensagens que casam com o filtro
+	Max    int           // Encerra após Max mensagens exibidas (0 = sem limite)
+
+	// Consume lê da própria fila (tirando as mensagens dos consumers) em vez
+	// de uma fila temporária com os mesmos bindings
+	Consume bool
+	AckMode string // AckModeAck (padrão), AckModeNack ou AckModeRequeue
+}
+
+// TailQueue entrega em onMessage as mensagens que chegam na fila até ctx ser
+// cancelado ou opts.Max ser atingido, retornando quantas foram entregues.
+//
+// Por padrão não afeta os consumers: declara uma fila temporária exclusiva e
+// a liga aos exchanges de origem da fila com os mesmos bindings, recebendo
+// uma cópia de cada mensagem roteada. Mensagens publicadas direto na fila
+// pelo default exchange não podem ser copiadas e não aparecem.
+func (c *Client) TailQueue(ctx context.Context, queueName string, bindings []BindingInfoManagement, opts TailOptions, onMessage func(d amqp.Delivery)) (int, error) {
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	source := queueName
+	if !opts.Consume {
+		source, err = declareTap(channel, bindings)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Sem mensagens mantidas pendentes, limitar o prefetch evita receber
+	// (e devolver fora de ordem) mais mensagens do que as exibidas
+	if opts.Consume && opts.AckMode != AckModeRequeue && opts.Filter.IsEmpty() {
+		prefetch := tailPrefetch
+		if opts.Max > 0 && opts.Max < prefetch {
+			prefetch = opts.Max
+		}
+		if err := channel.Qos(prefetch, 0, false); err != nil {
+			return 0, fmt.Errorf("erro ao configurar prefetch: %w", err)
+		}
+	}
+
+	deliveries, err := channel.Consume(source, "", !opts.Consume, false, false, false, nil)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao consumir '%s': %w", source, err)
+	}
+
+	// Mensagens mantidas pendentes voltam para a fila ao terminar
+	var held []uint64
+	defer func() {
+		for _, tag := range held {
+			channel.Nack(tag, false, true)
+		}
+	}()
+
+	shown := 0
+	for opts.Max <= 0 || shown < opts.Max {
+		var d amqp.Delivery
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return shown, nil
+		case d, ok = <-deliveries:
+			if !ok {
+				return shown, fmt.Errorf("consumo encerrado pelo broker")
+			}
+		}
+
+		if !opts.Filter.Match(d.Headers, d.Body) {
+			if opts.Consume {
+				held = append(held, d.DeliveryTag)
+			}
+			continue
+		}
+
+		shown++
+		onMessage(d)
+
+		if !opts.Consume {
+			continue
+		}
+		switch opts.AckMode {
+		case AckModeNack:
+			err = d.Nack(false, false)
+		case AckModeRequeue:
+			held = append(held, d.DeliveryTag)
+		default:
+			err = d.Ack(false)
+		}
+		if err != nil {
+			return shown, fmt.Errorf("erro ao confirmar mensagem: %w", err)
+		}
+	}
+
+	return shown, nil
+}
+
+// declareTap cria a fila temporária e replica nela os bindings da fila original
+func declareTap(channel *amqp.Channel, bindings []BindingInfoManagement) (string, error) {
+	tap, err := channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar fila temporária: %w", err)
+	}
+
+	bound := 0
+	for _, b := range bindings {
+		// O default exchange não aceita bindings explícitos
+		if b.Source == "" {
+			continue
+		}
+		if err := channel.QueueBind(tap.Name, b.RoutingKey, b.Source, false, bindingArguments(b.Arguments)); err != nil {
+			return "", fmt.Errorf("erro ao ligar fila temporária ao exchange '%s': %w", b.Source, err)
+		}
+		bound++
+	}
+
+	if bound == 0 {
+		return "", fmt.Errorf("a fila não tem bindings com exchanges: mensagens publicadas direto na fila só podem ser lidas com --consume")
+	}
+	return tap.Name, nil
+}
+
+// bindingArguments converte os argumentos de binding da Management API para AMQP.
+// Números chegam como float64; em exchanges headers o tipo importa na comparação.
+func bindingArguments(args map[string]interface{}) amqp.Table {
+	if len(args) == 0 {
+		return nil
+	}
+	table := toTable(args)
+	for k, v := range table {
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			table[k] = int64(f)
+		}
+	}
+	return table
+}
@@ -0,0 +1,76 @@
+//go:build integration
+// +build integration
+
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailQueue_DoesNotStealMessages_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestClient(t)
+	defer client.Close()
+
+	suffix := time.Now().Format("20060102150405")
+	queueName := "test_tail_" + suffix
+	exchange := "test_tail_ex_" + suffix
+	channel := client.GetChannel()
+	require.NoError(t, client.CreateQueue(CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.DeleteQueue(queueName, false, false, false)
+	require.NoError(t, channel.ExchangeDeclare(exchange, "topic", false, true, false, false, nil))
+	defer channel.ExchangeDelete(exchange, false, false)
+	require.NoError(t, channel.QueueBind(queueName, "orders.*", exchange, false, nil))
+
+	bindings := []BindingInfoManagement{
+		{Source: "", Destination: queueName, RoutingKey: queueName},
+		{Source: exchange, Destination: queueName, RoutingKey: "orders.*"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	received := make(chan amqp.Delivery, 2)
+	done := make(chan int)
+	go func() {
+		shown, err := client.TailQueue(ctx, queueName, bindings, TailOptions{
+			Max:    1,
+			Filter: MessageFilter{BodyContains: "acme"},
+		}, func(d amqp.Delivery) { received <- d })
+		assert.NoError(t, err)
+		done <- shown
+	}()
+
+	// Aguarda a fila temporária ser ligada antes de publicar
+	time.Sleep(500 * time.Millisecond)
+	for _, body := range []string{"globex", "acme"} {
+		require.NoError(t, channel.Publish(exchange, "orders.created", false, false, amqp.Publishing{Body: []byte(body)}))
+	}
+
+	assert.Equal(t, 1, <-done)
+	d := <-received
+	assert.Equal(t, "acme", string(d.Body))
+	assert.Equal(t, "orders.created", d.RoutingKey)
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Messages, "as mensagens continuam na fila")
+
+	// --consume com requeue: lê da fila e devolve tudo ao terminar
+	shown, err := client.TailQueue(ctx, queueName, nil, TailOptions{Max: 2, Consume: true, AckMode: AckModeRequeue}, func(amqp.Delivery) {})
+	require.NoError(t, err)
+	assert.Equal(t, 2, shown)
+
+	info, err = client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Messages)
+}
@@ -0,0 +1,33 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidAckMode(t *testing.T) {
+	for _, mode := range []string{AckModeAck, AckModeNack, AckModeRequeue} {
+		assert.True(t, ValidAckMode(mode), mode)
+	}
+	assert.False(t, ValidAckMode("reject"))
+	assert.False(t, ValidAckMode(""))
+}
+
+func TestBindingArguments(t *testing.T) {
+	assert.Nil(t, bindingArguments(nil))
+
+	args := bindingArguments(map[string]interface{}{
+		"x-match": "all",
+		"version": float64(2),
+		"ratio":   1.5,
+		"meta":    map[string]interface{}{"region": "br"},
+	})
+	assert.Equal(t, amqp.Table{
+		"x-match": "all",
+		"version": int64(2),
+		"ratio":   1.5,
+		"meta":    amqp.Table{"region": "br"},
+	}, args)
+}
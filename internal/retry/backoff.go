@@ -0,0 +1,105 @@
+package retry
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TierDelays retorna o delay (em segundos) de cada tentativa, na ordem.
+//
+// Prioridade:
+//  1. Backoff explícito: cada valor é um tier; se houver mais tentativas que
+//     tiers, o último tier se repete.
+//  2. BackoffMultiplier > 1: RetryDelay * multiplier^(tentativa-1), limitado
+//     a MaxRetryDelay quando informado.
+//  3. RetryDelay fixo para todas as tentativas.
+func (opts SetupOptions) TierDelays() []int {
+	maxRetries := opts.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	delays := make([]int, maxRetries)
+	for i := range delays {
+		switch {
+		case len(opts.Backoff) > 0:
+			if i < len(opts.Backoff) {
+				delays[i] = opts.Backoff[i]
+			} else {
+				delays[i] = opts.Backoff[len(opts.Backoff)-1]
+			}
+		case opts.BackoffMultiplier > 1:
+			delay := float64(opts.RetryDelay) * math.Pow(opts.BackoffMultiplier, float64(i))
+			if opts.MaxRetryDelay > 0 && delay > float64(opts.MaxRetryDelay) {
+				delay = float64(opts.MaxRetryDelay)
+			}
+			delays[i] = int(math.Round(delay))
+		default:
+			delays[i] = opts.RetryDelay
+		}
+	}
+
+	return delays
+}
+
+// ParseBackoff converte uma lista separada por vírgulas ("5s,30s,5m,30m")
+// em delays em segundos. Números sem unidade são tratados como segundos.
+func ParseBackoff(value string) ([]int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var delays []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if seconds, err := strconv.Atoi(part); err == nil {
+			if seconds <= 0 {
+				return nil, fmt.Errorf("delay inválido %q: deve ser maior que zero", part)
+			}
+			delays = append(delays, seconds)
+			continue
+		}
+
+		duration, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, fmt.Errorf("delay inválido %q: use valores como 5s, 30s, 5m ou 1h", part)
+		}
+		if duration < time.Second {
+			return nil, fmt.Errorf("delay inválido %q: mínimo de 1s", part)
+		}
+		delays = append(delays, int(duration/time.Second))
+	}
+
+	return delays, nil
+}
+
+// FormatDelay formata um delay em segundos de forma compacta ("30s", "5m", "1h30m")
+func FormatDelay(seconds int) string {
+	if seconds <= 0 {
+		return "0s"
+	}
+
+	hours := seconds / 3600
+	minutes := (seconds % 3600) / 60
+	secs := seconds % 60
+
+	var b strings.Builder
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dh", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dm", minutes)
+	}
+	if secs > 0 {
+		fmt.Fprintf(&b, "%ds", secs)
+	}
+	return b.String()
+}
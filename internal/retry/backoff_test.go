@@ -0,0 +1,80 @@
+package retry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTierDelays(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     SetupOptions
+		expected []int
+	}{
+		{
+			name:     "fixed delay",
+			opts:     SetupOptions{MaxRetries: 3, RetryDelay: 5},
+			expected: []int{5, 5, 5},
+		},
+		{
+			name:     "explicit backoff",
+			opts:     SetupOptions{MaxRetries: 4, RetryDelay: 5, Backoff: []int{5, 30, 300, 1800}},
+			expected: []int{5, 30, 300, 1800},
+		},
+		{
+			name:     "backoff repeats last tier",
+			opts:     SetupOptions{MaxRetries: 4, Backoff: []int{5, 30}},
+			expected: []int{5, 30, 30, 30},
+		},
+		{
+			name:     "multiplier",
+			opts:     SetupOptions{MaxRetries: 4, RetryDelay: 5, BackoffMultiplier: 2},
+			expected: []int{5, 10, 20, 40},
+		},
+		{
+			name:     "multiplier with cap",
+			opts:     SetupOptions{MaxRetries: 4, RetryDelay: 10, BackoffMultiplier: 3, MaxRetryDelay: 60},
+			expected: []int{10, 30, 60, 60},
+		},
+		{
+			name:     "zero retries",
+			opts:     SetupOptions{MaxRetries: 0, RetryDelay: 5},
+			expected: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.opts.TierDelays())
+		})
+	}
+}
+
+func TestParseBackoff(t *testing.T) {
+	delays, err := ParseBackoff("5s, 30s,5m,30m")
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 30, 300, 1800}, delays)
+
+	delays, err = ParseBackoff("10,1h")
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 3600}, delays)
+
+	delays, err = ParseBackoff("")
+	require.NoError(t, err)
+	assert.Nil(t, delays)
+
+	for _, invalid := range []string{"abc", "500ms", "0", "-5"} {
+		_, err := ParseBackoff(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFormatDelay(t *testing.T) {
+	assert.Equal(t, "0s", FormatDelay(0))
+	assert.Equal(t, "30s", FormatDelay(30))
+	assert.Equal(t, "5m", FormatDelay(300))
+	assert.Equal(t, "1h30m", FormatDelay(5400))
+	assert.Equal(t, "1m5s", FormatDelay(65))
+}
@@ -0,0 +1,192 @@
+package retry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeathRecord é uma entrada do header x-death.
+// O broker mantém uma entrada por par (fila, motivo) e incrementa Count.
+type DeathRecord struct {
+	Queue       string
+	Reason      string // rejected, expired, maxlen, delivery_limit
+	Exchange    string
+	RoutingKeys []string
+	Count       int64
+	Time        time.Time // Momento da morte mais recente desta entrada
+}
+
+// DeadMessage é uma mensagem da DLQ com as informações de dead-letter decodificadas
+type DeadMessage struct {
+	MessageId   string
+	ContentType string
+	Timestamp   time.Time
+	RoutingKey  string
+	Body        []byte
+	Headers     amqp.Table
+
+	Deaths             []DeathRecord
+	FirstDeathQueue    string
+	FirstDeathReason   string
+	FirstDeathExchange string
+	FirstDeath         time.Time // Morte mais antiga entre as entradas do x-death
+	LastDeath          time.Time // Morte mais recente entre as entradas do x-death
+
+	// Headers de erro adicionados pela aplicação (ex: x-exception-message, x-gohop-error)
+	ExceptionHeaders map[string]interface{}
+}
+
+// TotalDeaths soma os contadores de todas as entradas do x-death
+func (m DeadMessage) TotalDeaths() int64 {
+	var total int64
+	for _, death := range m.Deaths {
+		total += death.Count
+	}
+	return total
+}
+
+// ParseXDeath decodifica o header x-death
+func ParseXDeath(headers amqp.Table) []DeathRecord {
+	entries, ok := headers["x-death"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var deaths []DeathRecord
+	for _, entry := range entries {
+		table, ok := entry.(amqp.Table)
+		if !ok {
+			continue
+		}
+
+		death := DeathRecord{
+			Queue:    argString(table, "queue"),
+			Reason:   argString(table, "reason"),
+			Exchange: argString(table, "exchange"),
+		}
+
+		switch count := table["count"].(type) {
+		case int64:
+			death.Count = count
+		case int32:
+			death.Count = int64(count)
+		case int:
+			death.Count = int64(count)
+		}
+
+		if t, ok := table["time"].(time.Time); ok {
+			death.Time = t
+		}
+
+		if keys, ok := table["routing-keys"].([]interface{}); ok {
+			for _, key := range keys {
+				if s, ok := key.(string); ok {
+					death.RoutingKeys = append(death.RoutingKeys, s)
+				}
+			}
+		}
+
+		deaths = append(deaths, death)
+	}
+
+	return deaths
+}
+
+// NewDeadMessage decodifica uma delivery da DLQ
+func NewDeadMessage(d amqp.Delivery) DeadMessage {
+	msg := DeadMessage{
+		MessageId:          d.MessageId,
+		ContentType:        d.ContentType,
+		Timestamp:          d.Timestamp,
+		RoutingKey:         d.RoutingKey,
+		Body:               d.Body,
+		Headers:            d.Headers,
+		Deaths:             ParseXDeath(d.Headers),
+		FirstDeathQueue:    argString(d.Headers, "x-first-death-queue"),
+		FirstDeathReason:   argString(d.Headers, "x-first-death-reason"),
+		FirstDeathExchange: argString(d.Headers, "x-first-death-exchange"),
+	}
+
+	for _, death := range msg.Deaths {
+		if death.Time.IsZero() {
+			continue
+		}
+		if msg.FirstDeath.IsZero() || death.Time.Before(msg.FirstDeath) {
+			msg.FirstDeath = death.Time
+		}
+		if death.Time.After(msg.LastDeath) {
+			msg.LastDeath = death.Time
+		}
+	}
+
+	for key, value := range d.Headers {
+		if isExceptionHeader(key) {
+			if msg.ExceptionHeaders == nil {
+				msg.ExceptionHeaders = make(map[string]interface{})
+			}
+			msg.ExceptionHeaders[key] = value
+		}
+	}
+
+	return msg
+}
+
+// isExceptionHeader identifica headers de erro comuns (Spring AMQP, gohop, convenções próprias)
+func isExceptionHeader(key string) bool {
+	lower := strings.ToLower(key)
+	if strings.HasPrefix(lower, "x-death") || strings.HasPrefix(lower, "x-first-death") || strings.HasPrefix(lower, "x-last-death") {
+		return false
+	}
+	return strings.Contains(lower, "exception") || strings.Contains(lower, "error") || strings.Contains(lower, "stacktrace")
+}
+
+// SortedExceptionHeaders retorna as chaves dos headers de erro em ordem alfabética
+func (m DeadMessage) SortedExceptionHeaders() []string {
+	keys := make([]string, 0, len(m.ExceptionHeaders))
+	for key := range m.ExceptionHeaders {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PeekDLQ lê até limit mensagens da DLQ sem removê-las.
+//
+// As mensagens são obtidas com basic.get e devolvidas com nack(requeue=true)
+// ao final, mantendo a ordem. Em filas quorum cada peek conta como uma nova
+// entrega para o delivery-limit da fila.
+func PeekDLQ(client *rabbitmq.Client, queueName string, limit int) ([]DeadMessage, error) {
+	channel, err := client.GetConnection().Channel()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	var messages []DeadMessage
+	var lastTag uint64
+
+	for limit <= 0 || len(messages) < limit {
+		d, ok, err := channel.Get(DLQName(queueName), false)
+		if err != nil {
+			return messages, fmt.Errorf("erro ao ler DLQ: %w", err)
+		}
+		if !ok {
+			break
+		}
+		lastTag = d.DeliveryTag
+		messages = append(messages, NewDeadMessage(d))
+	}
+
+	if lastTag > 0 {
+		if err := channel.Nack(lastTag, true, true); err != nil {
+			return messages, fmt.Errorf("erro ao devolver mensagens para a DLQ: %w", err)
+		}
+	}
+
+	return messages, nil
+}
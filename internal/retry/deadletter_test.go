@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeadMessage(t *testing.T) {
+	first := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	last := first.Add(35 * time.Second)
+
+	d := amqp.Delivery{
+		MessageId:  "msg-1",
+		RoutingKey: "orders.attempt.3",
+		Body:       []byte(`{"id":1}`),
+		Headers: amqp.Table{
+			"x-death": []interface{}{
+				amqp.Table{
+					"queue":        "orders",
+					"reason":       "rejected",
+					"exchange":     "",
+					"count":        int64(3),
+					"time":         last,
+					"routing-keys": []interface{}{"orders"},
+				},
+				amqp.Table{
+					"queue":    "orders.wait",
+					"reason":   "expired",
+					"exchange": "orders.wait.first",
+					"count":    int64(1),
+					"time":     first,
+				},
+			},
+			"x-first-death-queue":    "orders",
+			"x-first-death-reason":   "rejected",
+			"x-first-death-exchange": "",
+			"x-exception-message":    "NullPointerException",
+			"x-gohop-error":          "payload inválido",
+			"tenant":                 "acme",
+		},
+	}
+
+	msg := NewDeadMessage(d)
+
+	require.Len(t, msg.Deaths, 2)
+	assert.Equal(t, "orders", msg.Deaths[0].Queue)
+	assert.Equal(t, "rejected", msg.Deaths[0].Reason)
+	assert.Equal(t, int64(3), msg.Deaths[0].Count)
+	assert.Equal(t, []string{"orders"}, msg.Deaths[0].RoutingKeys)
+	assert.Equal(t, int64(4), msg.TotalDeaths())
+
+	assert.Equal(t, "orders", msg.FirstDeathQueue)
+	assert.Equal(t, "rejected", msg.FirstDeathReason)
+	assert.Equal(t, first, msg.FirstDeath)
+	assert.Equal(t, last, msg.LastDeath)
+
+	assert.Equal(t, []string{"x-exception-message", "x-gohop-error"}, msg.SortedExceptionHeaders())
+}
+
+func TestParseXDeath_Missing(t *testing.T) {
+	assert.Nil(t, ParseXDeath(nil))
+	assert.Nil(t, ParseXDeath(amqp.Table{"x-death": "invalid"}))
+}
@@ -0,0 +1,116 @@
+package retry
+
+import (
+	"fmt"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Backends do sistema de retry.
+//
+// BackendTTL usa uma wait queue com x-message-ttl por tentativa (veja names.go).
+// BackendDelayed usa o plugin rabbitmq_delayed_message_exchange: o retry
+// exchange é do tipo x-delayed-message e o consumer republica a mensagem com
+// o header x-delay da tentativa. A topologia para "orders" é:
+//
+//	orders                 (fila principal, DLX = orders.wait.exchange)
+//	orders.wait.exchange   (fanout, recebe a rejeição final e entrega na DLQ)
+//	orders.retry           (x-delayed-message, devolve para a fila principal após o x-delay)
+//	orders.dlq             (destino final após MaxRetries)
+const (
+	BackendTTL     = "ttl"
+	BackendDelayed = "delayed"
+)
+
+// DelayedExchangeType é o tipo de exchange registrado pelo plugin
+const DelayedExchangeType = "x-delayed-message"
+
+// ValidBackend verifica se o backend é suportado
+func ValidBackend(backend string) bool {
+	switch backend {
+	case BackendTTL, BackendDelayed:
+		return true
+	}
+	return false
+}
+
+// CheckDelayedPlugin verifica via Management API se o plugin está habilitado
+func CheckDelayedPlugin(mgmtClient *rabbitmq.ManagementClient) error {
+	found, err := mgmtClient.HasExchangeType(DelayedExchangeType)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar plugins do broker: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("o broker não tem o exchange %s; habilite com 'rabbitmq-plugins enable rabbitmq_delayed_message_exchange'", DelayedExchangeType)
+	}
+	return nil
+}
+
+// setupDelayedRetry cria os componentes do backend delayed
+func setupDelayedRetry(client *rabbitmq.Client, opts SetupOptions, waitExchangeArgs amqp.Table) error {
+	queueName := opts.QueueName
+	channel := client.GetChannel()
+
+	// Retry exchange: x-delayed-message roteando como headers, para manter o
+	// mesmo bind com a fila principal do backend TTL (veja BindRetryExchange)
+	if err := declareRetryExchange(client, queueName, DelayedExchangeType, amqp.Table{"x-delayed-type": "headers"}, opts.Force); err != nil {
+		return err
+	}
+
+	// Wait exchange: a rejeição só acontece depois da última tentativa
+	waitExchangeArgs[metaBackend] = BackendDelayed
+	if err := channel.ExchangeDeclare(
+		WaitExchangeName(queueName),
+		"fanout",
+		true,  // durable
+		false, // auto-delete
+		false, // internal
+		false, // no-wait
+		waitExchangeArgs,
+	); err != nil {
+		return fmt.Errorf("erro ao criar wait exchange (use --force para recriar componentes antigos): %w", err)
+	}
+
+	if err := channel.QueueBind(DLQName(queueName), "", WaitExchangeName(queueName), false, nil); err != nil {
+		return fmt.Errorf("erro ao fazer bind da DLQ: %w", err)
+	}
+
+	return nil
+}
+
+// declareRetryExchange declara o retry exchange com o tipo do backend.
+// Com force, um exchange de outro tipo (troca de backend) é removido e
+// recriado; o bind com a fila principal é refeito por BindRetryExchange.
+// Usa um canal temporário porque erros de precondição fecham o canal AMQP.
+func declareRetryExchange(client *rabbitmq.Client, queueName, kind string, args amqp.Table, force bool) error {
+	name := RetryExchangeName(queueName)
+
+	channel, err := client.GetConnection().Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	err = channel.ExchangeDeclare(name, kind, true, false, false, false, args)
+	if err == nil {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("erro ao criar retry exchange (use --force para trocar o backend): %w", err)
+	}
+
+	channel, err = client.GetConnection().Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	if err := channel.ExchangeDelete(name, false, false); err != nil {
+		return fmt.Errorf("erro ao remover retry exchange %s: %w", name, err)
+	}
+	if err := channel.ExchangeDeclare(name, kind, true, false, false, false, args); err != nil {
+		return fmt.Errorf("erro ao criar retry exchange: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package retry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidBackend(t *testing.T) {
+	assert.True(t, ValidBackend(BackendTTL))
+	assert.True(t, ValidBackend(BackendDelayed))
+	assert.False(t, ValidBackend("plugin"))
+}
@@ -0,0 +1,262 @@
+package retry
+
+import (
+	"fmt"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+)
+
+// retryComponents agrupa o estado dos componentes lido da Management API
+type retryComponents struct {
+	queueName     string
+	mainQueue     *rabbitmq.QueueInfoManagement
+	waitQueues    []*rabbitmq.QueueInfoManagement // Ordenadas por tentativa
+	dlq           *rabbitmq.QueueInfoManagement
+	waitExchange  *rabbitmq.ExchangeInfoManagement
+	firstExchange *rabbitmq.ExchangeInfoManagement
+	retryExchange *rabbitmq.ExchangeInfoManagement
+	bindings      []rabbitmq.BindingInfoManagement // Bindings com origem nos exchanges do retry
+	metadata      *RetryMetadata
+}
+
+// RetryBindingStatus é um binding esperado do sistema de retry
+type RetryBindingStatus struct {
+	Source      string
+	Destination string
+	RoutingKey  string
+	Present     bool
+}
+
+// exists indica se algum componente do retry foi encontrado
+func (c retryComponents) exists() bool {
+	return len(c.waitQueues) > 0 || c.dlq != nil || c.waitExchange != nil || c.firstExchange != nil || c.retryExchange != nil
+}
+
+// delayed indica se o retry foi criado com BackendDelayed
+func (c retryComponents) delayed() bool {
+	return c.metadata != nil && c.metadata.Backend == BackendDelayed
+}
+
+// findBinding procura um binding de source para a fila destination com a routing key
+func (c retryComponents) findBinding(source, destination, routingKey string) *rabbitmq.BindingInfoManagement {
+	for i, binding := range c.bindings {
+		if binding.Source == source && binding.Destination == destination &&
+			binding.DestinationType == "queue" && binding.RoutingKey == routingKey {
+			return &c.bindings[i]
+		}
+	}
+	return nil
+}
+
+// bindingStatus lista os bindings que SetupRetry e RecreateQueueWithDLX criam
+// para as wait queues existentes, indicando se cada um está no broker
+func (c retryComponents) bindingStatus() []RetryBindingStatus {
+	if !c.exists() {
+		return nil
+	}
+
+	dlqName := DLQName(c.queueName)
+	waitQueues := len(c.waitQueues)
+
+	if c.delayed() {
+		expected := []RetryBindingStatus{
+			{Source: WaitExchangeName(c.queueName), Destination: dlqName},
+			{Source: RetryExchangeName(c.queueName), Destination: c.queueName},
+		}
+		for i, binding := range expected {
+			expected[i].Present = c.findBinding(binding.Source, binding.Destination, binding.RoutingKey) != nil
+		}
+		return expected
+	}
+
+	firstTarget := dlqName
+	if waitQueues > 0 {
+		firstTarget = WaitQueueName(c.queueName, 1)
+	}
+
+	expected := []RetryBindingStatus{
+		{Source: FirstAttemptExchangeName(c.queueName), Destination: firstTarget},
+	}
+	for attempt := 1; attempt <= waitQueues; attempt++ {
+		target := dlqName
+		if attempt < waitQueues {
+			target = WaitQueueName(c.queueName, attempt+1)
+		}
+		expected = append(expected, RetryBindingStatus{
+			Source:      WaitExchangeName(c.queueName),
+			Destination: target,
+			RoutingKey:  AttemptRoutingKey(c.queueName, attempt),
+		})
+	}
+	expected = append(expected, RetryBindingStatus{Source: RetryExchangeName(c.queueName), Destination: c.queueName})
+
+	for i, binding := range expected {
+		expected[i].Present = c.findBinding(binding.Source, binding.Destination, binding.RoutingKey) != nil
+	}
+
+	return expected
+}
+
+// drift compara os componentes entre si e com a metadata persistida,
+// retornando uma descrição de cada divergência encontrada
+func (c retryComponents) drift() []string {
+	var drift []string
+
+	if c.mainQueue != nil {
+		dlx, _ := MainQueueDLX(c.mainQueue)
+		if dlx != WaitExchangeName(c.queueName) {
+			drift = append(drift, fmt.Sprintf("fila principal com x-dead-letter-exchange '%s' (esperado '%s')", dlx, WaitExchangeName(c.queueName)))
+		}
+	}
+
+	for i, waitQueue := range c.waitQueues {
+		attempt := i + 1
+		if dlx := argString(waitQueue.Arguments, "x-dead-letter-exchange"); dlx != RetryExchangeName(c.queueName) {
+			drift = append(drift, fmt.Sprintf("%s com x-dead-letter-exchange '%s' (esperado '%s')", waitQueue.Name, dlx, RetryExchangeName(c.queueName)))
+		}
+		if rk := argString(waitQueue.Arguments, "x-dead-letter-routing-key"); rk != AttemptRoutingKey(c.queueName, attempt) {
+			drift = append(drift, fmt.Sprintf("%s com x-dead-letter-routing-key '%s' (esperado '%s')", waitQueue.Name, rk, AttemptRoutingKey(c.queueName, attempt)))
+		}
+	}
+
+	// Tipo das filas auxiliares deve acompanhar a fila principal
+	if c.mainQueue != nil && c.mainQueue.Type != "" {
+		auxQueues := append([]*rabbitmq.QueueInfoManagement{}, c.waitQueues...)
+		if c.dlq != nil {
+			auxQueues = append(auxQueues, c.dlq)
+		}
+		for _, q := range auxQueues {
+			if q.Type != "" && q.Type != c.mainQueue.Type {
+				drift = append(drift, fmt.Sprintf("%s é %s, mas a fila principal é %s", q.Name, q.Type, c.mainQueue.Type))
+			}
+		}
+	}
+
+	if c.exists() {
+		drift = append(drift, c.exchangeDrift()...)
+	}
+
+	if c.delayed() {
+		return append(drift, c.delayedDrift()...)
+	}
+
+	if c.waitExchange != nil {
+		if c.waitExchange.Type != "direct" {
+			drift = append(drift, fmt.Sprintf("wait exchange é do tipo %s (esperado direct)", c.waitExchange.Type))
+		}
+		if ae := argString(c.waitExchange.Arguments, "alternate-exchange"); ae != FirstAttemptExchangeName(c.queueName) {
+			drift = append(drift, fmt.Sprintf("wait exchange com alternate-exchange '%s' (esperado '%s')", ae, FirstAttemptExchangeName(c.queueName)))
+		}
+	}
+
+	if c.metadata == nil {
+		return drift
+	}
+
+	// Metadata x componentes
+	if c.metadata.MaxRetries != len(c.waitQueues) {
+		drift = append(drift, fmt.Sprintf("metadata indica %d tentativa(s), mas existem %d wait queue(s)", c.metadata.MaxRetries, len(c.waitQueues)))
+	}
+
+	for i, waitQueue := range c.waitQueues {
+		if i >= len(c.metadata.Delays) {
+			break
+		}
+		actual := argInt(waitQueue.Arguments, "x-message-ttl") / 1000
+		if actual != c.metadata.Delays[i] {
+			drift = append(drift, fmt.Sprintf("%s com TTL de %s (metadata: %s)", waitQueue.Name, FormatDelay(actual), FormatDelay(c.metadata.Delays[i])))
+		}
+	}
+
+	if c.dlq != nil {
+		if actual := argInt(c.dlq.Arguments, "x-message-ttl"); actual != c.metadata.DLQTTL {
+			drift = append(drift, fmt.Sprintf("DLQ com TTL de %dms (metadata: %dms)", actual, c.metadata.DLQTTL))
+		}
+	}
+
+	if c.mainQueue != nil && c.metadata.QueueType != "" && c.mainQueue.Type != "" && c.mainQueue.Type != c.metadata.QueueType {
+		drift = append(drift, fmt.Sprintf("fila principal é %s (metadata: %s)", c.mainQueue.Type, c.metadata.QueueType))
+	}
+
+	return drift
+}
+
+// exchangeDrift verifica exchanges e bindings do sistema de retry
+func (c retryComponents) exchangeDrift() []string {
+	var drift []string
+
+	type expectedExchange struct {
+		name     string
+		exchange *rabbitmq.ExchangeInfoManagement
+		kind     string
+	}
+	exchanges := []expectedExchange{
+		{WaitExchangeName(c.queueName), c.waitExchange, "direct"},
+		{FirstAttemptExchangeName(c.queueName), c.firstExchange, "fanout"},
+		{RetryExchangeName(c.queueName), c.retryExchange, "headers"},
+	}
+	if c.delayed() {
+		exchanges = []expectedExchange{
+			{WaitExchangeName(c.queueName), c.waitExchange, "fanout"},
+			{RetryExchangeName(c.queueName), c.retryExchange, DelayedExchangeType},
+		}
+	}
+
+	missing := make(map[string]bool)
+	for _, e := range exchanges {
+		if e.exchange == nil {
+			missing[e.name] = true
+			drift = append(drift, fmt.Sprintf("exchange %s não encontrado", e.name))
+			continue
+		}
+		// O tipo do wait exchange é verificado junto com o alternate-exchange
+		if e.exchange != c.waitExchange && e.exchange.Type != e.kind {
+			drift = append(drift, fmt.Sprintf("exchange %s é do tipo %s (esperado %s)", e.name, e.exchange.Type, e.kind))
+		}
+	}
+
+	if c.dlq == nil {
+		drift = append(drift, fmt.Sprintf("DLQ %s não encontrada", DLQName(c.queueName)))
+	}
+
+	for _, binding := range c.bindingStatus() {
+		if binding.Present || missing[binding.Source] {
+			continue
+		}
+		drift = append(drift, fmt.Sprintf("bind ausente: %s → %s (routing key '%s')", binding.Source, binding.Destination, binding.RoutingKey))
+	}
+
+	// Com x-match any e sem headers no bind, o retry exchange não roteia nada
+	if binding := c.findBinding(RetryExchangeName(c.queueName), c.queueName, ""); binding != nil {
+		if match := argString(binding.Arguments, "x-match"); match != "" && match != "all" {
+			drift = append(drift, fmt.Sprintf("bind %s → %s com x-match '%s' (esperado 'all')", binding.Source, binding.Destination, match))
+		}
+	}
+
+	return drift
+}
+
+// delayedDrift verifica os componentes específicos do backend delayed
+func (c retryComponents) delayedDrift() []string {
+	var drift []string
+
+	if c.waitExchange != nil && c.waitExchange.Type != "fanout" {
+		drift = append(drift, fmt.Sprintf("wait exchange é do tipo %s (esperado fanout)", c.waitExchange.Type))
+	}
+	if c.retryExchange != nil && c.retryExchange.Type == DelayedExchangeType {
+		if kind := argString(c.retryExchange.Arguments, "x-delayed-type"); kind != "headers" {
+			drift = append(drift, fmt.Sprintf("retry exchange com x-delayed-type '%s' (esperado 'headers')", kind))
+		}
+	}
+	if len(c.waitQueues) > 0 {
+		drift = append(drift, fmt.Sprintf("%d wait queue(s) do backend ttl ainda existem; o backend delayed não as usa", len(c.waitQueues)))
+	}
+
+	if c.dlq != nil && c.metadata != nil {
+		if actual := argInt(c.dlq.Arguments, "x-message-ttl"); actual != c.metadata.DLQTTL {
+			drift = append(drift, fmt.Sprintf("DLQ com TTL de %dms (metadata: %dms)", actual, c.metadata.DLQTTL))
+		}
+	}
+
+	return drift
+}
@@ -0,0 +1,267 @@
+package retry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	args := metadataArgs(3, []int{5, 30, 300}, 604800000, "quorum")
+
+	meta := ParseMetadata(args)
+	if assert.NotNil(t, meta) {
+		assert.Equal(t, 3, meta.MaxRetries)
+		assert.Equal(t, []int{5, 30, 300}, meta.Delays)
+		assert.Equal(t, 604800000, meta.DLQTTL)
+		assert.Equal(t, "quorum", meta.QueueType)
+	}
+}
+
+func TestParseMetadata_FromManagementAPI(t *testing.T) {
+	// Management API devolve números JSON como float64
+	meta := ParseMetadata(map[string]interface{}{
+		"alternate-exchange":  "orders.wait.first",
+		"x-gohop-max-retries": float64(2),
+		"x-gohop-backoff":     "5,60",
+		"x-gohop-dlq-ttl":     float64(0),
+		"x-gohop-queue-type":  "classic",
+	})
+
+	if assert.NotNil(t, meta) {
+		assert.Equal(t, 2, meta.MaxRetries)
+		assert.Equal(t, []int{5, 60}, meta.Delays)
+		assert.Equal(t, 0, meta.DLQTTL)
+	}
+
+	if assert.NotNil(t, meta) {
+		assert.Equal(t, BackendTTL, meta.Backend)
+	}
+
+	meta = ParseMetadata(map[string]interface{}{"x-gohop-max-retries": float64(2), "x-gohop-backend": "delayed"})
+	if assert.NotNil(t, meta) {
+		assert.Equal(t, BackendDelayed, meta.Backend)
+	}
+
+	assert.Nil(t, ParseMetadata(map[string]interface{}{"alternate-exchange": "orders.wait.first"}))
+}
+
+func TestMetadata_Changes(t *testing.T) {
+	meta := ParseMetadata(metadataArgs(3, []int{5, 30, 300}, 604800000, "quorum"))
+	opts := SetupOptions{QueueName: "orders", QueueType: "quorum", MaxRetries: 3, Backoff: []int{5, 30, 300}, DLQTTL: 604800000}
+	assert.Empty(t, meta.Changes(opts))
+
+	opts.Backoff = []int{5, 30, 600}
+	opts.Backend = BackendDelayed
+	assert.Equal(t, []string{"delays: 5s, 30s, 5m → 5s, 30s, 10m", "backend: ttl → delayed"}, meta.Changes(opts))
+}
+
+// healthyComponents monta os componentes de "orders" como SetupRetry os cria
+func healthyComponents() retryComponents {
+	waitQueue := func(attempt, ttlSeconds int) *rabbitmq.QueueInfoManagement {
+		return &rabbitmq.QueueInfoManagement{
+			Name: WaitQueueName("orders", attempt),
+			Type: "quorum",
+			Arguments: map[string]interface{}{
+				"x-message-ttl":             float64(ttlSeconds * 1000),
+				"x-dead-letter-exchange":    RetryExchangeName("orders"),
+				"x-dead-letter-routing-key": AttemptRoutingKey("orders", attempt),
+			},
+		}
+	}
+
+	return retryComponents{
+		queueName: "orders",
+		mainQueue: &rabbitmq.QueueInfoManagement{
+			Name:      "orders",
+			Type:      "quorum",
+			Arguments: map[string]interface{}{"x-dead-letter-exchange": WaitExchangeName("orders")},
+		},
+		waitQueues: []*rabbitmq.QueueInfoManagement{waitQueue(1, 5), waitQueue(2, 30)},
+		dlq: &rabbitmq.QueueInfoManagement{
+			Name:      DLQName("orders"),
+			Type:      "quorum",
+			Arguments: map[string]interface{}{"x-message-ttl": float64(604800000)},
+		},
+		waitExchange: &rabbitmq.ExchangeInfoManagement{
+			Name:      WaitExchangeName("orders"),
+			Type:      "direct",
+			Arguments: map[string]interface{}{"alternate-exchange": FirstAttemptExchangeName("orders")},
+		},
+		firstExchange: &rabbitmq.ExchangeInfoManagement{Name: FirstAttemptExchangeName("orders"), Type: "fanout"},
+		retryExchange: &rabbitmq.ExchangeInfoManagement{Name: RetryExchangeName("orders"), Type: "headers"},
+		bindings: []rabbitmq.BindingInfoManagement{
+			queueBinding(FirstAttemptExchangeName("orders"), WaitQueueName("orders", 1), ""),
+			queueBinding(WaitExchangeName("orders"), WaitQueueName("orders", 2), AttemptRoutingKey("orders", 1)),
+			queueBinding(WaitExchangeName("orders"), DLQName("orders"), AttemptRoutingKey("orders", 2)),
+			{
+				Source:          RetryExchangeName("orders"),
+				Destination:     "orders",
+				DestinationType: "queue",
+				Arguments:       map[string]interface{}{"x-match": "all"},
+			},
+		},
+		metadata: &RetryMetadata{MaxRetries: 2, Delays: []int{5, 30}, DLQTTL: 604800000, QueueType: "quorum"},
+	}
+}
+
+func queueBinding(source, destination, routingKey string) rabbitmq.BindingInfoManagement {
+	return rabbitmq.BindingInfoManagement{Source: source, Destination: destination, DestinationType: "queue", RoutingKey: routingKey}
+}
+
+func TestRetryComponents_BindingStatus(t *testing.T) {
+	components := healthyComponents()
+	components.bindings = components.bindings[1:]
+
+	status := components.bindingStatus()
+	if assert.Len(t, status, 4) {
+		assert.Equal(t, RetryBindingStatus{Source: "orders.wait.first", Destination: "orders.wait"}, status[0])
+		assert.Equal(t, RetryBindingStatus{Source: "orders.retry", Destination: "orders", Present: true}, status[3])
+	}
+
+	assert.Nil(t, retryComponents{queueName: "orders"}.bindingStatus())
+}
+
+func TestRetryComponents_NoDrift(t *testing.T) {
+	assert.Empty(t, healthyComponents().drift())
+}
+
+func TestRetryComponents_NoDriftWithPolicy(t *testing.T) {
+	components := healthyComponents()
+	components.mainQueue.Arguments = map[string]interface{}{}
+	components.mainQueue.Policy = RetryPolicyName("orders")
+	components.mainQueue.EffectivePolicyDefinition = RetryPolicy("orders").Definition
+
+	assert.Empty(t, components.drift())
+}
+
+// delayedComponents monta os componentes de "orders" com BackendDelayed
+func delayedComponents() retryComponents {
+	c := healthyComponents()
+	c.waitQueues = nil
+	c.firstExchange = nil
+	c.waitExchange.Type = "fanout"
+	c.waitExchange.Arguments = map[string]interface{}{}
+	c.retryExchange.Type = DelayedExchangeType
+	c.retryExchange.Arguments = map[string]interface{}{"x-delayed-type": "headers"}
+	c.bindings = []rabbitmq.BindingInfoManagement{
+		queueBinding(WaitExchangeName("orders"), DLQName("orders"), ""),
+		c.bindings[3],
+	}
+	c.metadata.Backend = BackendDelayed
+	return c
+}
+
+func TestRetryComponents_Delayed(t *testing.T) {
+	components := delayedComponents()
+	assert.Empty(t, components.drift())
+
+	status := components.bindingStatus()
+	if assert.Len(t, status, 2) {
+		assert.Equal(t, RetryBindingStatus{Source: "orders.wait.exchange", Destination: "orders.dlq", Present: true}, status[0])
+	}
+
+	components.retryExchange.Type = "headers"
+	components.bindings = components.bindings[1:]
+	drift := strings.Join(components.drift(), "\n")
+	assert.Contains(t, drift, "exchange orders.retry é do tipo headers (esperado x-delayed-message)")
+	assert.Contains(t, drift, "bind ausente: orders.wait.exchange → orders.dlq")
+}
+
+func TestRetryComponents_Drift(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(c *retryComponents)
+		contains string
+	}{
+		{
+			name: "main queue without DLX",
+			mutate: func(c *retryComponents) {
+				c.mainQueue.Arguments = map[string]interface{}{}
+			},
+			contains: "fila principal com x-dead-letter-exchange",
+		},
+		{
+			name: "wait queue TTL differs from metadata",
+			mutate: func(c *retryComponents) {
+				c.waitQueues[1].Arguments["x-message-ttl"] = float64(60000)
+			},
+			contains: "TTL de 1m (metadata: 30s)",
+		},
+		{
+			name: "missing tier",
+			mutate: func(c *retryComponents) {
+				c.waitQueues = c.waitQueues[:1]
+			},
+			contains: "metadata indica 2 tentativa(s), mas existem 1 wait queue(s)",
+		},
+		{
+			name: "DLQ TTL differs",
+			mutate: func(c *retryComponents) {
+				c.dlq.Arguments = map[string]interface{}{}
+			},
+			contains: "DLQ com TTL de 0ms",
+		},
+		{
+			name: "queue type mismatch",
+			mutate: func(c *retryComponents) {
+				c.dlq.Type = "classic"
+			},
+			contains: "orders.dlq é classic",
+		},
+		{
+			name: "wrong routing key",
+			mutate: func(c *retryComponents) {
+				c.waitQueues[0].Arguments["x-dead-letter-routing-key"] = "orders"
+			},
+			contains: "x-dead-letter-routing-key 'orders'",
+		},
+		{
+			name: "missing retry exchange",
+			mutate: func(c *retryComponents) {
+				c.retryExchange = nil
+			},
+			contains: "exchange orders.retry não encontrado",
+		},
+		{
+			name: "wrong exchange type",
+			mutate: func(c *retryComponents) {
+				c.firstExchange.Type = "direct"
+			},
+			contains: "exchange orders.wait.first é do tipo direct (esperado fanout)",
+		},
+		{
+			name: "missing tier binding",
+			mutate: func(c *retryComponents) {
+				c.bindings = append(c.bindings[:1], c.bindings[2:]...)
+			},
+			contains: "bind ausente: orders.wait.exchange → orders.wait.2 (routing key 'orders.attempt.1')",
+		},
+		{
+			name: "retry binding with x-match any",
+			mutate: func(c *retryComponents) {
+				c.bindings[3].Arguments = map[string]interface{}{"x-match": "any"}
+			},
+			contains: "x-match 'any'",
+		},
+		{
+			name: "wait queue DLX points elsewhere",
+			mutate: func(c *retryComponents) {
+				c.waitQueues[0].Arguments["x-dead-letter-exchange"] = "orders.wait.exchange"
+			},
+			contains: "orders.wait com x-dead-letter-exchange 'orders.wait.exchange' (esperado 'orders.retry')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := healthyComponents()
+			tt.mutate(&components)
+
+			drift := components.drift()
+			assert.Contains(t, strings.Join(drift, "\n"), tt.contains)
+		})
+	}
+}
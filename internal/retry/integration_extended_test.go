@@ -64,6 +64,24 @@ func TestGetRetrySystemInfo_Integration(t *testing.T) {
 	assert.True(t, info.MainQueue)
 	assert.True(t, info.WaitQueue)
 	assert.True(t, info.DLQ)
+	assert.True(t, info.WaitExchange)
+	assert.True(t, info.FirstExchange)
+	assert.True(t, info.RetryExchange)
+
+	// Bindings reais: primeira tentativa, 3 tiers e retorno à fila principal
+	assert.Len(t, info.Bindings, 5)
+	for _, binding := range info.Bindings {
+		assert.True(t, binding.Present, "bind %s → %s", binding.Source, binding.Destination)
+	}
+
+	// Configuração lida dos componentes e da metadata
+	assert.Equal(t, 3, info.MaxRetries)
+	assert.Equal(t, 5, info.RetryDelay)
+	assert.Equal(t, 604800000, info.DLQTTL)
+	assert.Len(t, info.Tiers, 3)
+	require.NotNil(t, info.Metadata)
+	assert.Equal(t, "classic", info.Metadata.QueueType)
+	assert.Empty(t, info.Drift)
 
 	// Limpar
 	client.DeleteQueue(queueName, false, false, false)
@@ -169,6 +187,18 @@ func TestRecreateQueueWithDLX_DifferentTypes_Integration(t *testing.T) {
 			)
 			require.NoError(t, err)
 
+			// Retry exchange recebe o bind da fila principal
+			err = channel.ExchangeDeclare(
+				queueName+".retry",
+				"headers",
+				true,
+				false,
+				false,
+				false,
+				nil,
+			)
+			require.NoError(t, err)
+
 			// Recriar fila com DLX
 			err = RecreateQueueWithDLX(client, queueName, tt.queueType)
 			require.NoError(t, err)
@@ -95,6 +95,18 @@ func TestRecreateQueueWithDLX_Integration(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	// Retry exchange recebe o bind da fila principal
+	err = channel.ExchangeDeclare(
+		queueName+".retry",
+		"headers",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	require.NoError(t, err)
+
 	// Recriar fila com DLX
 	err = RecreateQueueWithDLX(client, queueName, "classic")
 	require.NoError(t, err)
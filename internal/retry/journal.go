@@ -0,0 +1,251 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+)
+
+// ReconfigureStep é uma etapa da reconfiguração de uma fila com retry
+type ReconfigureStep string
+
+// Etapas da reconfiguração, na ordem em que são executadas
+const (
+	StepSpooled        ReconfigureStep = "spooled"         // Mensagens gravadas no spool em disco
+	StepRetryCreated   ReconfigureStep = "retry_created"   // Sistema de retry criado
+	StepQueueDeleted   ReconfigureStep = "queue_deleted"   // Fila original removida
+	StepQueueRecreated ReconfigureStep = "queue_recreated" // Fila recriada com DLX
+	StepRepublished    ReconfigureStep = "republished"     // Mensagens do spool republicadas
+)
+
+// ReconfigureSteps lista as etapas na ordem de execução
+var ReconfigureSteps = []ReconfigureStep{
+	StepSpooled,
+	StepRetryCreated,
+	StepQueueDeleted,
+	StepQueueRecreated,
+	StepRepublished,
+}
+
+// Title descreve a etapa para exibição
+func (s ReconfigureStep) Title() string {
+	switch s {
+	case StepSpooled:
+		return "Salvando mensagens em disco"
+	case StepRetryCreated:
+		return "Criando sistema de retry"
+	case StepQueueDeleted:
+		return "Removendo a fila original"
+	case StepQueueRecreated:
+		return "Recriando fila com DLX"
+	case StepRepublished:
+		return "Republicando mensagens"
+	}
+	return string(s)
+}
+
+// ErrReconfigurePending indica que já existe uma reconfiguração interrompida para a fila
+var ErrReconfigurePending = errors.New("reconfiguração pendente")
+
+// OriginalQueue guarda a declaração da fila antes da reconfiguração (para rollback)
+type OriginalQueue struct {
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// ReconfigureJournal registra o progresso de uma reconfiguração em disco,
+// permitindo concluir ou desfazer uma execução interrompida.
+//
+// Fica em <config>/reconfigure junto do spool com as mensagens da fila.
+type ReconfigureJournal struct {
+	Queue      string `json:"queue"`
+	VHost      string `json:"vhost"`
+	QueueType  string `json:"queue_type"`
+	MaxRetries int    `json:"max_retries"`
+	RetryDelay int    `json:"retry_delay"`
+	DLQTTL     int    `json:"dlq_ttl"`
+
+	// Backoff e backend do retry recriado (veja SetupOptions)
+	Backoff           []int   `json:"backoff,omitempty"`
+	BackoffMultiplier float64 `json:"backoff_multiplier,omitempty"`
+	MaxRetryDelay     int     `json:"max_retry_delay,omitempty"`
+	Backend           string  `json:"backend,omitempty"`
+
+	Original     OriginalQueue `json:"original"`
+	RetryExisted bool          `json:"retry_existed"` // Componentes de retry já existiam antes
+
+	SpoolState
+	Steps []ReconfigureStep `json:"steps"` // Etapas concluídas
+
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	dir string
+}
+
+// SpoolState é o andamento do spool em disco de uma operação com journal
+type SpoolState struct {
+	Spooled  int `json:"spooled"`   // Mensagens gravadas no spool
+	NextLine int `json:"next_line"` // Próxima linha do spool a republicar
+
+	UserIdDropped int `json:"user_id_dropped,omitempty"` // Republicadas sem o user-id original
+}
+
+// spoolJournal é um journal com spool em disco (reconfiguração ou remoção do retry)
+type spoolJournal interface {
+	SpoolPath() string
+	Save() error
+	spool() *SpoolState
+}
+
+// ReconfigureDir retorna o diretório padrão de journals e spools
+func ReconfigureDir() string {
+	return filepath.Join(config.GetConfigDir(), "reconfigure")
+}
+
+// reconfigureKey gera o prefixo de arquivo da fila (vhost + nome, escapados)
+func reconfigureKey(vhost, queueName string) string {
+	if vhost == "" {
+		vhost = "/"
+	}
+	return url.PathEscape(vhost) + "_" + url.PathEscape(queueName)
+}
+
+// NewReconfigureJournal cria e grava o journal de uma nova reconfiguração.
+// Retorna ErrReconfigurePending se já houver uma execução interrompida para a fila.
+func NewReconfigureJournal(dir string, j ReconfigureJournal) (*ReconfigureJournal, error) {
+	j.dir = dir
+	if _, err := os.Stat(j.path()); err == nil {
+		return nil, fmt.Errorf("%w para a fila '%s': use --resume ou --rollback", ErrReconfigurePending, j.Queue)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+
+	j.Steps = nil
+	j.StartedAt = time.Now()
+	if err := j.Save(); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// LoadReconfigureJournal lê o journal de uma reconfiguração interrompida.
+// Retorna um erro compatível com os.ErrNotExist se não houver nenhuma.
+func LoadReconfigureJournal(dir, vhost, queueName string) (*ReconfigureJournal, error) {
+	j := &ReconfigureJournal{VHost: vhost, Queue: queueName, dir: dir}
+
+	data, err := os.ReadFile(j.path())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("nenhuma reconfiguração pendente para a fila '%s': %w", queueName, err)
+		}
+		return nil, fmt.Errorf("erro ao ler journal: %w", err)
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("journal corrompido (%s): %w", j.path(), err)
+	}
+	return j, nil
+}
+
+func (j *ReconfigureJournal) path() string {
+	return filepath.Join(j.dir, reconfigureKey(j.VHost, j.Queue)+".journal.json")
+}
+
+// SpoolPath retorna o arquivo NDJSON com as mensagens retiradas da fila
+func (j *ReconfigureJournal) SpoolPath() string {
+	return filepath.Join(j.dir, reconfigureKey(j.VHost, j.Queue)+".spool.ndjson")
+}
+
+func (j *ReconfigureJournal) spool() *SpoolState {
+	return &j.SpoolState
+}
+
+// Done indica se a etapa já foi concluída
+func (j *ReconfigureJournal) Done(step ReconfigureStep) bool {
+	return slices.Contains(j.Steps, step)
+}
+
+// Complete marca a etapa como concluída e grava o journal
+func (j *ReconfigureJournal) Complete(step ReconfigureStep) error {
+	if !j.Done(step) {
+		j.Steps = append(j.Steps, step)
+	}
+	return j.Save()
+}
+
+// Save grava o journal de forma atômica (veja writeJournal)
+func (j *ReconfigureJournal) Save() error {
+	j.UpdatedAt = time.Now()
+	return writeJournal(j.dir, j.path(), j)
+}
+
+// Remove apaga o journal e o spool de uma reconfiguração encerrada
+func (j *ReconfigureJournal) Remove() error {
+	return removeFiles(j.SpoolPath(), j.path())
+}
+
+// writeJournal grava o journal de forma atômica (arquivo temporário + fsync + rename)
+func writeJournal(dir, path string, j interface{}) error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar journal: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar journal: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao gravar journal: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao sincronizar journal: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar journal: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("erro ao gravar journal: %w", err)
+	}
+	return syncDir(dir)
+}
+
+// removeFiles apaga os arquivos de uma operação encerrada (ausentes são ignorados)
+func removeFiles(paths ...string) error {
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("erro ao remover %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// syncDir sincroniza o diretório para que criações e renames sobrevivam a uma queda
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir diretório %s: %w", dir, err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("erro ao sincronizar diretório %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package retry
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/pkg/consumer"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconfigureJournal_Lifecycle(t *testing.T) {
+	dir := t.TempDir()
+
+	j, err := NewReconfigureJournal(dir, ReconfigureJournal{
+		Queue:      "orders",
+		VHost:      "/",
+		QueueType:  "quorum",
+		MaxRetries: 3,
+		RetryDelay: 5,
+		Original:   OriginalQueue{Type: "classic", Durable: true, Arguments: map[string]interface{}{"x-max-length": float64(1000)}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dir+"/%2F_orders.spool.ndjson", j.SpoolPath())
+
+	_, err = NewReconfigureJournal(dir, ReconfigureJournal{Queue: "orders", VHost: "/"})
+	assert.ErrorIs(t, err, ErrReconfigurePending)
+
+	j.Spooled = 42
+	require.NoError(t, j.Complete(StepSpooled))
+	require.NoError(t, j.Complete(StepSpooled))
+
+	loaded, err := LoadReconfigureJournal(dir, "/", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []ReconfigureStep{StepSpooled}, loaded.Steps)
+	assert.True(t, loaded.Done(StepSpooled))
+	assert.False(t, loaded.Done(StepRetryCreated))
+	assert.Equal(t, 42, loaded.Spooled)
+	assert.Equal(t, "quorum", loaded.QueueType)
+	assert.Equal(t, "classic", loaded.Original.Type)
+
+	require.NoError(t, os.WriteFile(loaded.SpoolPath(), []byte("{}\n"), 0600))
+	require.NoError(t, loaded.Remove())
+
+	_, err = os.Stat(loaded.SpoolPath())
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	_, err = LoadReconfigureJournal(dir, "/", "orders")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReconfigureJournal_KeepsRetryOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewReconfigureJournal(dir, ReconfigureJournal{
+		Queue:             "orders",
+		VHost:             "/",
+		MaxRetries:        4,
+		RetryDelay:        5,
+		Backoff:           []int{5, 30, 300, 1800},
+		BackoffMultiplier: 2,
+		MaxRetryDelay:     3600,
+		Backend:           BackendDelayed,
+	})
+	require.NoError(t, err)
+
+	loaded, err := LoadReconfigureJournal(dir, "/", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 30, 300, 1800}, loaded.Backoff)
+	assert.Equal(t, 2.0, loaded.BackoffMultiplier)
+	assert.Equal(t, 3600, loaded.MaxRetryDelay)
+	assert.Equal(t, BackendDelayed, loaded.Backend)
+}
+
+func TestReconfigureKey(t *testing.T) {
+	assert.Equal(t, "%2F_orders", reconfigureKey("", "orders"))
+	assert.Equal(t, "prod_orders%2Fv2", reconfigureKey("prod", "orders/v2"))
+}
+
+func TestRepairSpool(t *testing.T) {
+	path := t.TempDir() + "/spool.ndjson"
+	require.NoError(t, os.WriteFile(path, []byte("{\"a\":1}\n{\"b\":2}\n{\"c\":"), 0600))
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0600)
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines, err := repairSpool(file)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lines)
+
+	_, err = file.WriteString("{\"d\":4}\n")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"a\":1}\n{\"b\":2}\n{\"d\":4}\n", string(data))
+}
+
+func TestOriginalQueueArguments(t *testing.T) {
+	args := originalQueueArguments(map[string]interface{}{
+		"x-queue-type":           "quorum",
+		"x-dead-letter-exchange": "legacy.dlx",
+		"x-max-length":           float64(1000),
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"x-dead-letter-exchange": "legacy.dlx",
+		"x-max-length":           int64(1000),
+	}, args)
+}
+
+func TestSpool_KeepsDeathCount(t *testing.T) {
+	j, err := NewReconfigureJournal(t.TempDir(), ReconfigureJournal{Queue: "orders", VHost: "/"})
+	require.NoError(t, err)
+
+	delivery := amqp.Delivery{
+		RoutingKey: "orders.attempt.2",
+		Headers: amqp.Table{"x-death": []interface{}{
+			amqp.Table{"queue": "orders.wait.2", "reason": "expired", "count": int64(1), "time": time.Unix(1700000000, 0)},
+			amqp.Table{"queue": "orders", "reason": "rejected", "count": int64(2), "time": time.Unix(1700000000, 0)},
+		}},
+		Body: []byte("falha"),
+	}
+
+	// Mesmo caminho do spool: gravação com appendQueue, leitura com republishSpool
+	file, err := os.OpenFile(j.SpoolPath(), os.O_CREATE|os.O_RDWR, 0600)
+	require.NoError(t, err)
+	_, err = repairSpool(file)
+	require.NoError(t, err)
+	writer := rabbitmq.NewMessageWriter(file)
+	require.NoError(t, writer.Write(rabbitmq.NewMessageRecord(delivery)))
+	require.NoError(t, writer.Sync())
+	require.NoError(t, file.Close())
+
+	file, err = os.Open(j.SpoolPath())
+	require.NoError(t, err)
+	defer file.Close()
+	record, _, err := rabbitmq.NewMessageReader(file).Next()
+	require.NoError(t, err)
+
+	msg := record.Publishing()
+	republished := amqp.Delivery{RoutingKey: "orders", Headers: msg.Headers}
+	count, err := consumer.DeathCount(republished, "orders", "rejected")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	attempt, err := consumer.Attempt(republished, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempt, "a contagem de tentativas não reinicia após o spool")
+}
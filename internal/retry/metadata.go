@@ -0,0 +1,177 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Argumentos do wait exchange onde a configuração do retry fica persistida.
+// O RabbitMQ ignora argumentos desconhecidos, então eles servem apenas para
+// o gohop recuperar o que foi configurado e comparar com os componentes.
+const (
+	metaMaxRetries = "x-gohop-max-retries"
+	metaBackoff    = "x-gohop-backoff"
+	metaDLQTTL     = "x-gohop-dlq-ttl"
+	metaQueueType  = "x-gohop-queue-type"
+	metaBackend    = "x-gohop-backend"
+)
+
+// RetryMetadata é a configuração do retry persistida no wait exchange
+type RetryMetadata struct {
+	MaxRetries int
+	Delays     []int // Delay de cada tentativa em segundos
+	DLQTTL     int   // Milissegundos (0 = sem expiração)
+	QueueType  string
+	Backend    string // BackendTTL ou BackendDelayed
+}
+
+// metadataArgs monta os argumentos de metadata para o wait exchange
+func metadataArgs(maxRetries int, delays []int, dlqTTL int, queueType string) amqp.Table {
+	parts := make([]string, len(delays))
+	for i, delay := range delays {
+		parts[i] = strconv.Itoa(delay)
+	}
+
+	return amqp.Table{
+		metaMaxRetries: int64(maxRetries),
+		metaBackoff:    strings.Join(parts, ","),
+		metaDLQTTL:     int64(dlqTTL),
+		metaQueueType:  queueType,
+	}
+}
+
+// ParseMetadata lê a metadata dos argumentos de um exchange.
+// Retorna nil quando o exchange foi criado sem metadata (versões antigas).
+func ParseMetadata(args map[string]interface{}) *RetryMetadata {
+	if _, ok := args[metaMaxRetries]; !ok {
+		return nil
+	}
+
+	meta := &RetryMetadata{
+		MaxRetries: argInt(args, metaMaxRetries),
+		DLQTTL:     argInt(args, metaDLQTTL),
+	}
+
+	if queueType, ok := args[metaQueueType].(string); ok {
+		meta.QueueType = queueType
+	}
+
+	// Sem backend persistido o retry foi criado com wait queues
+	meta.Backend = argString(args, metaBackend)
+	if meta.Backend == "" {
+		meta.Backend = BackendTTL
+	}
+
+	if backoff, ok := args[metaBackoff].(string); ok && backoff != "" {
+		for _, part := range strings.Split(backoff, ",") {
+			if delay, err := strconv.Atoi(part); err == nil {
+				meta.Delays = append(meta.Delays, delay)
+			}
+		}
+	}
+
+	return meta
+}
+
+// Changes lista as diferenças entre a metadata persistida e a configuração
+// de opts (vazio = mesma configuração)
+func (m *RetryMetadata) Changes(opts SetupOptions) []string {
+	var changes []string
+
+	maxRetries := max(opts.MaxRetries, 0)
+	if m.MaxRetries != maxRetries {
+		changes = append(changes, fmt.Sprintf("tentativas: %d → %d", m.MaxRetries, maxRetries))
+	}
+	if delays := opts.TierDelays(); !slices.Equal(m.Delays, delays) && (len(m.Delays) > 0 || len(delays) > 0) {
+		changes = append(changes, fmt.Sprintf("delays: %s → %s", formatDelayList(m.Delays), formatDelayList(delays)))
+	}
+	if m.DLQTTL != opts.DLQTTL {
+		changes = append(changes, fmt.Sprintf("dlq-ttl: %dms → %dms", m.DLQTTL, opts.DLQTTL))
+	}
+
+	queueType := opts.QueueType
+	if queueType == "" {
+		queueType = "classic"
+	}
+	if m.QueueType != "" && m.QueueType != queueType {
+		changes = append(changes, fmt.Sprintf("tipo: %s → %s", m.QueueType, queueType))
+	}
+
+	backend := opts.Backend
+	if backend == "" {
+		backend = BackendTTL
+	}
+	if m.Backend != backend {
+		changes = append(changes, fmt.Sprintf("backend: %s → %s", m.Backend, backend))
+	}
+
+	return changes
+}
+
+// CheckExistingConfig verifica pela Management API se o retry da fila já
+// existe com outra configuração. Ao redeclarar um exchange o RabbitMQ só
+// compara o alternate-exchange, então SetupRetry sem Force aceitaria a nova
+// configuração em silêncio e a metadata antiga continuaria no wait exchange.
+func CheckExistingConfig(mgmtClient *rabbitmq.ManagementClient, vhost string, opts SetupOptions) error {
+	exchange, err := mgmtClient.GetExchange(vhost, WaitExchangeName(opts.QueueName))
+	if errors.Is(err, rabbitmq.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao verificar o retry existente: %w", err)
+	}
+
+	// Sem metadata (versões antigas) não há configuração a comparar
+	meta := ParseMetadata(exchange.Arguments)
+	if meta == nil {
+		return nil
+	}
+
+	if changes := meta.Changes(opts); len(changes) > 0 {
+		return fmt.Errorf("o retry de '%s' já existe com outra configuração (%s); use --force para recriá-lo",
+			opts.QueueName, strings.Join(changes, "; "))
+	}
+	return nil
+}
+
+// formatDelayList formata delays em segundos para exibição (ex: "5s, 30s, 5m")
+func formatDelayList(delays []int) string {
+	if len(delays) == 0 {
+		return "nenhum"
+	}
+	parts := make([]string, len(delays))
+	for i, delay := range delays {
+		parts[i] = FormatDelay(delay)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// argInt lê um argumento numérico de fila ou exchange
+// (a Management API decodifica números JSON como float64)
+func argInt(args map[string]interface{}, key string) int {
+	switch v := args[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	}
+	return 0
+}
+
+// argString lê um argumento textual de fila ou exchange
+func argString(args map[string]interface{}, key string) string {
+	if v, ok := args[key].(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package retry
+
+import "github.com/davioliveeira/gohop/internal/retryname"
+
+// Nomes e headers do sistema de retry, definidos em retryname (veja a
+// topologia completa em retryname/names.go)
+var (
+	WaitExchangeName         = retryname.WaitExchangeName
+	FirstAttemptExchangeName = retryname.FirstAttemptExchangeName
+	WaitQueueName            = retryname.WaitQueueName
+	AttemptRoutingKey        = retryname.AttemptRoutingKey
+	ParseAttemptRoutingKey   = retryname.ParseAttemptRoutingKey
+	RetryExchangeName        = retryname.RetryExchangeName
+	DLQName                  = retryname.DLQName
+	IsRetryQueue             = retryname.IsRetryQueue
+	DelayedRetryPublishing   = retryname.DelayedRetryPublishing
+)
+
+// Headers usados pelo backend delayed
+const (
+	HeaderDelay   = retryname.HeaderDelay
+	HeaderRetries = retryname.HeaderRetries
+)
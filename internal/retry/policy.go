@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+)
+
+// Origem do dead-lettering da fila principal
+const (
+	DLXSourceArguments = "arguments" // x-dead-letter-exchange nos argumentos da fila
+	DLXSourcePolicy    = "policy"    // dead-letter-exchange via policy
+)
+
+// retryPolicyPriority é a prioridade do policy de retry. O RabbitMQ aplica
+// apenas o policy de maior prioridade a cada fila.
+const retryPolicyPriority = 10
+
+// RetryPolicyName retorna o nome do policy que liga a fila principal ao retry
+func RetryPolicyName(queueName string) string {
+	return fmt.Sprintf("gohop-retry-%s", queueName)
+}
+
+// RetryPolicy monta o policy que aplica o DLX do retry somente à fila principal
+func RetryPolicy(queueName string) rabbitmq.Policy {
+	return rabbitmq.Policy{
+		Pattern:  "^" + regexp.QuoteMeta(queueName) + "$",
+		ApplyTo:  "queues",
+		Priority: retryPolicyPriority,
+		Definition: map[string]interface{}{
+			"dead-letter-exchange": WaitExchangeName(queueName),
+		},
+	}
+}
+
+// MainQueueDLX retorna o dead-letter exchange efetivo da fila e de onde ele vem.
+// Argumentos da fila têm precedência sobre policies no broker.
+func MainQueueDLX(queue *rabbitmq.QueueInfoManagement) (string, string) {
+	if dlx := argString(queue.Arguments, "x-dead-letter-exchange"); dlx != "" {
+		return dlx, DLXSourceArguments
+	}
+	if dlx := argString(queue.EffectivePolicyDefinition, "dead-letter-exchange"); dlx != "" {
+		return dlx, DLXSourcePolicy
+	}
+	return "", ""
+}
+
+// CheckRetryPolicy verifica se o policy de retry terá efeito sobre a fila
+func CheckRetryPolicy(queue *rabbitmq.QueueInfoManagement) error {
+	if dlx := argString(queue.Arguments, "x-dead-letter-exchange"); dlx != "" {
+		return fmt.Errorf("a fila '%s' define x-dead-letter-exchange '%s' nos argumentos, que têm precedência sobre o policy; use o modo sem --policy", queue.Name, dlx)
+	}
+	if queue.Policy != "" && queue.Policy != RetryPolicyName(queue.Name) {
+		return fmt.Errorf("a fila '%s' já usa o policy '%s' e o RabbitMQ aplica apenas um policy por fila; inclua \"dead-letter-exchange\": \"%s\" nele", queue.Name, queue.Policy, WaitExchangeName(queue.Name))
+	}
+	return nil
+}
+
+// ApplyRetryPolicy liga uma fila existente ao retry via policy, sem recriá-la
+func ApplyRetryPolicy(mgmtClient *rabbitmq.ManagementClient, vhost string, queue *rabbitmq.QueueInfoManagement) error {
+	if err := CheckRetryPolicy(queue); err != nil {
+		return err
+	}
+	return mgmtClient.PutPolicy(vhost, RetryPolicyName(queue.Name), RetryPolicy(queue.Name))
+}
+
+// RemoveRetryPolicy remove o policy de retry da fila (se existir)
+func RemoveRetryPolicy(mgmtClient *rabbitmq.ManagementClient, vhost, queueName string) error {
+	return mgmtClient.DeletePolicy(vhost, RetryPolicyName(queueName))
+}
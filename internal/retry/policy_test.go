@@ -0,0 +1,56 @@
+package retry
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy("orders.v1")
+
+	assert.Equal(t, "gohop-retry-orders.v1", RetryPolicyName("orders.v1"))
+	assert.Equal(t, "queues", policy.ApplyTo)
+	assert.Equal(t, "orders.v1.wait.exchange", policy.Definition["dead-letter-exchange"])
+
+	// O pattern casa apenas com a fila principal
+	pattern := regexp.MustCompile(policy.Pattern)
+	assert.True(t, pattern.MatchString("orders.v1"))
+	assert.False(t, pattern.MatchString("ordersXv1"))
+	assert.False(t, pattern.MatchString("orders.v1.wait"))
+}
+
+func TestMainQueueDLX(t *testing.T) {
+	dlx, source := MainQueueDLX(&rabbitmq.QueueInfoManagement{
+		Arguments: map[string]interface{}{"x-dead-letter-exchange": "orders.wait.exchange"},
+	})
+	assert.Equal(t, "orders.wait.exchange", dlx)
+	assert.Equal(t, DLXSourceArguments, source)
+
+	dlx, source = MainQueueDLX(&rabbitmq.QueueInfoManagement{
+		Policy:                    "gohop-retry-orders",
+		EffectivePolicyDefinition: map[string]interface{}{"dead-letter-exchange": "orders.wait.exchange"},
+	})
+	assert.Equal(t, "orders.wait.exchange", dlx)
+	assert.Equal(t, DLXSourcePolicy, source)
+
+	dlx, source = MainQueueDLX(&rabbitmq.QueueInfoManagement{})
+	assert.Empty(t, dlx)
+	assert.Empty(t, source)
+}
+
+func TestCheckRetryPolicy(t *testing.T) {
+	assert.NoError(t, CheckRetryPolicy(&rabbitmq.QueueInfoManagement{Name: "orders"}))
+	assert.NoError(t, CheckRetryPolicy(&rabbitmq.QueueInfoManagement{Name: "orders", Policy: "gohop-retry-orders"}))
+
+	err := CheckRetryPolicy(&rabbitmq.QueueInfoManagement{
+		Name:      "orders",
+		Arguments: map[string]interface{}{"x-dead-letter-exchange": "legacy"},
+	})
+	assert.ErrorContains(t, err, "têm precedência sobre o policy")
+
+	err = CheckRetryPolicy(&rabbitmq.QueueInfoManagement{Name: "orders", Policy: "ha-all"})
+	assert.ErrorContains(t, err, "já usa o policy 'ha-all'")
+}
@@ -0,0 +1,366 @@
+package retry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+)
+
+// ReconfigureHooks recebem o andamento da reconfiguração para exibição
+type ReconfigureHooks struct {
+	OnStep     func(step ReconfigureStep, index int) // Início de uma etapa (index 1-based)
+	OnProgress func(step ReconfigureStep, count int) // Mensagens processadas na etapa
+}
+
+func (h ReconfigureHooks) step(step ReconfigureStep, index int) {
+	if h.OnStep != nil {
+		h.OnStep(step, index)
+	}
+}
+
+func (h ReconfigureHooks) progress(step ReconfigureStep, count int) {
+	if h.OnProgress != nil {
+		h.OnProgress(step, count)
+	}
+}
+
+// StartReconfigure registra uma nova reconfiguração da fila: guarda a
+// declaração original (para rollback) e cria o journal em dir.
+// opts.QueueType vazio mantém o tipo atual da fila.
+func StartReconfigure(dir string, mgmt *rabbitmq.ManagementClient, vhost string, opts SetupOptions) (*ReconfigureJournal, error) {
+	queue, err := mgmt.GetQueue(vhost, opts.QueueName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = mgmt.GetExchange(vhost, WaitExchangeName(opts.QueueName))
+	retryExisted := err == nil
+
+	queueType := opts.QueueType
+	if queueType == "" {
+		queueType = queue.Type
+	}
+
+	return NewReconfigureJournal(dir, ReconfigureJournal{
+		Queue:             opts.QueueName,
+		VHost:             vhost,
+		QueueType:         queueType,
+		MaxRetries:        opts.MaxRetries,
+		RetryDelay:        opts.RetryDelay,
+		DLQTTL:            opts.DLQTTL,
+		Backoff:           opts.Backoff,
+		BackoffMultiplier: opts.BackoffMultiplier,
+		MaxRetryDelay:     opts.MaxRetryDelay,
+		Backend:           opts.Backend,
+		Original: OriginalQueue{
+			Type:       queue.Type,
+			Durable:    queue.Durable,
+			AutoDelete: queue.AutoDelete,
+			Arguments:  queue.Arguments,
+		},
+		RetryExisted: retryExisted,
+	})
+}
+
+// RunReconfigure executa (ou retoma) as etapas pendentes do journal.
+//
+// As mensagens da fila vão para o spool em disco e cada uma só é removida da
+// fila depois de gravada e sincronizada; a fila só é removida vazia
+// (if-empty) e o journal é gravado ao fim de cada etapa. Se o processo cair,
+// RunReconfigure com o mesmo journal continua de onde parou. Ao concluir,
+// journal e spool são apagados.
+func RunReconfigure(ctx context.Context, cfg config.RabbitMQConfig, j *ReconfigureJournal, hooks ReconfigureHooks) error {
+	for i, step := range ReconfigureSteps {
+		if j.Done(step) {
+			continue
+		}
+		hooks.step(step, i+1)
+		progress := func(count int) { hooks.progress(step, count) }
+
+		var err error
+		switch step {
+		case StepSpooled:
+			err = spoolQueue(ctx, cfg, j, j.Queue, progress)
+
+		case StepRetryCreated:
+			err = withClient(cfg, func(client *rabbitmq.Client) error {
+				return SetupRetry(client, SetupOptions{
+					QueueName:         j.Queue,
+					QueueType:         j.QueueType,
+					MaxRetries:        j.MaxRetries,
+					RetryDelay:        j.RetryDelay,
+					DLQTTL:            j.DLQTTL,
+					Backoff:           j.Backoff,
+					BackoffMultiplier: j.BackoffMultiplier,
+					MaxRetryDelay:     j.MaxRetryDelay,
+					Backend:           j.Backend,
+					Force:             true,
+				})
+			})
+
+		case StepQueueDeleted:
+			// Mensagens publicadas desde o spool também vão para o disco antes da remoção
+			err = spoolAndDelete(ctx, cfg, j, j.VHost, j.Queue, progress)
+
+		case StepQueueRecreated:
+			err = withClient(cfg, func(client *rabbitmq.Client) error {
+				return RecreateQueueWithDLX(client, j.Queue, j.QueueType)
+			})
+
+		case StepRepublished:
+			err = republishSpool(ctx, cfg, j, j.Queue, progress)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", step.Title(), err)
+		}
+		if err := j.Complete(step); err != nil {
+			return err
+		}
+	}
+
+	return j.Remove()
+}
+
+// RollbackReconfigure desfaz uma reconfiguração interrompida: recria a fila
+// original se ela foi removida, devolve as mensagens do spool e remove os
+// componentes de retry criados pela execução.
+//
+// Só é possível antes de a fila ser recriada com DLX; depois disso a
+// reconfiguração deve ser concluída com RunReconfigure.
+func RollbackReconfigure(ctx context.Context, cfg config.RabbitMQConfig, j *ReconfigureJournal, hooks ReconfigureHooks) error {
+	if j.Done(StepQueueRecreated) {
+		return fmt.Errorf("a fila '%s' já foi recriada com DLX: use --resume para concluir a reconfiguração", j.Queue)
+	}
+
+	mgmt := rabbitmq.NewManagementClient(cfg)
+
+	_, err := mgmt.GetQueue(j.VHost, j.Queue)
+	switch {
+	case errors.Is(err, rabbitmq.ErrNotFound):
+		err = withClient(cfg, func(client *rabbitmq.Client) error {
+			return client.CreateQueue(rabbitmq.CreateQueueOptions{
+				Name:       j.Queue,
+				Type:       j.Original.Type,
+				Durable:    j.Original.Durable,
+				AutoDelete: j.Original.AutoDelete,
+				Arguments:  originalQueueArguments(j.Original.Arguments),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao recriar a fila original: %w", err)
+		}
+	case err != nil:
+		return err
+	}
+
+	hooks.step(StepRepublished, 1)
+	progress := func(count int) { hooks.progress(StepRepublished, count) }
+	if err := republishSpool(ctx, cfg, j, j.Queue, progress); err != nil {
+		return err
+	}
+
+	if !j.RetryExisted && (j.Done(StepRetryCreated) || j.Done(StepSpooled)) {
+		err := withClient(cfg, func(client *rabbitmq.Client) error {
+			if err := DeleteRetryComponents(client, j.Queue, j.MaxRetries); err != nil {
+				return err
+			}
+			_, err := client.DeleteQueue(DLQName(j.Queue), false, true, false)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("mensagens devolvidas, mas erro ao remover componentes de retry: %w", err)
+		}
+	}
+
+	return j.Remove()
+}
+
+// originalQueueArguments prepara os argumentos originais para redeclarar a fila,
+// mantendo o dead-lettering que ela tinha antes
+func originalQueueArguments(args map[string]interface{}) map[string]interface{} {
+	restored := PlainQueueArguments(args)
+	for _, key := range []string{"x-dead-letter-exchange", "x-dead-letter-routing-key"} {
+		if v, ok := args[key]; ok {
+			restored[key] = v
+		}
+	}
+	return restored
+}
+
+// withClient executa fn com uma conexão nova; as etapas reconectam porque
+// erros de precondição fecham o canal e operações longas podem derrubar a conexão
+func withClient(cfg config.RabbitMQConfig, fn func(client *rabbitmq.Client) error) error {
+	client, err := rabbitmq.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar: %w", err)
+	}
+	defer client.Close()
+
+	return fn(client)
+}
+
+// spoolQueue retira as mensagens da fila para o final do spool do journal
+func spoolQueue(ctx context.Context, cfg config.RabbitMQConfig, j spoolJournal, queue string, onProgress func(count int)) error {
+	lines, err := appendQueue(ctx, cfg, j.SpoolPath(), queue, onProgress)
+	j.spool().Spooled = lines
+	if err != nil {
+		return err
+	}
+	return j.Save()
+}
+
+// spoolAndDelete leva as mensagens da fila para o spool e a remove com
+// if-empty, repetindo enquanto chegarem mensagens novas (veja drainAndDelete)
+func spoolAndDelete(ctx context.Context, cfg config.RabbitMQConfig, j spoolJournal, vhost, queue string, onProgress func(count int)) error {
+	return drainAndDelete(ctx, rabbitmq.NewManagementClient(cfg), vhost, queue, func() error {
+		return spoolQueue(ctx, cfg, j, queue, onProgress)
+	})
+}
+
+// drainAndDelete esvazia a fila com drain e a remove com if-empty até
+// conseguir: uma mensagem publicada depois do drain impede a remoção e é lida
+// na volta seguinte, em vez de ser perdida. Não é erro se a fila já não
+// existir (etapa retomada).
+func drainAndDelete(ctx context.Context, mgmt *rabbitmq.ManagementClient, vhost, queue string, drain func() error) error {
+	for {
+		_, err := mgmt.GetQueue(vhost, queue)
+		if errors.Is(err, rabbitmq.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := drain(); err != nil {
+			return err
+		}
+
+		err = mgmt.DeleteQueueIfEmpty(vhost, queue)
+		if !errors.Is(err, rabbitmq.ErrQueueNotEmpty) {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// appendQueue retira as mensagens da fila para o final do arquivo NDJSON em
+// path e retorna o total de linhas do arquivo. Cada mensagem só sai da fila
+// depois de gravada e sincronizada.
+func appendQueue(ctx context.Context, cfg config.RabbitMQConfig, path, queue string, onProgress func(count int)) (int, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao abrir %s: %w", path, err)
+	}
+	defer file.Close()
+
+	lines, err := repairSpool(file)
+	if err != nil {
+		return 0, err
+	}
+	if err := syncDir(filepath.Dir(path)); err != nil {
+		return 0, err
+	}
+
+	total := lines
+	err = withClient(cfg, func(client *rabbitmq.Client) error {
+		n, err := client.ExportQueue(ctx, queue, rabbitmq.NewMessageWriter(file), rabbitmq.ExportOptions{
+			Remove: true,
+			OnProgress: func(exported int) {
+				onProgress(lines + exported)
+			},
+		})
+		total = lines + n
+		return err
+	})
+	if err != nil {
+		return total, err
+	}
+
+	if err := file.Close(); err != nil {
+		return total, fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return total, nil
+}
+
+// repairSpool descarta uma última linha incompleta (gravação interrompida),
+// posiciona o arquivo no fim e retorna a quantidade de linhas completas
+func repairSpool(file *os.File) (int, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("erro ao ler spool: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	var lines int
+	var complete int64
+	for {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("erro ao ler spool: %w", err)
+		}
+		lines++
+		complete += int64(len(data))
+	}
+
+	if err := file.Truncate(complete); err != nil {
+		return 0, fmt.Errorf("erro ao reparar spool: %w", err)
+	}
+	if _, err := file.Seek(complete, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("erro ao reparar spool: %w", err)
+	}
+	return lines, nil
+}
+
+// republishSpool publica as mensagens do spool na fila a partir da próxima
+// linha do journal, gravando a linha de retomada após cada lote confirmado
+func republishSpool(ctx context.Context, cfg config.RabbitMQConfig, j spoolJournal, queue string, onProgress func(count int)) error {
+	file, err := os.Open(j.SpoolPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao abrir spool: %w", err)
+	}
+	defer file.Close()
+
+	state := j.spool()
+	return withClient(cfg, func(client *rabbitmq.Client) error {
+		var saveErr error
+		stats, err := client.ImportMessages(ctx, rabbitmq.NewMessageReader(file), rabbitmq.ImportOptions{
+			RoutingKey: queue,
+			FromLine:   state.NextLine,
+			OnProgress: func(imported, line int) {
+				state.NextLine = line + 1
+				if err := j.Save(); err != nil && saveErr == nil {
+					saveErr = err
+				}
+				onProgress(imported)
+			},
+		})
+
+		state.UserIdDropped += stats.UserIdDropped
+		var importErr *rabbitmq.ImportError
+		if errors.As(err, &importErr) {
+			state.NextLine = importErr.Line
+		}
+		if err := j.Save(); err != nil {
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		return saveErr
+	})
+}
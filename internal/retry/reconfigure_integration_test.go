@@ -0,0 +1,114 @@
+//go:build integration
+// +build integration
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/pkg/consumer"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconfigure_ResumeAfterInterruption_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	cfg := config.RabbitMQConfig{
+		Host:           "localhost",
+		Port:           5672,
+		ManagementPort: 15672,
+		Username:       "test_user",
+		Password:       "test_pass",
+		VHost:          "/",
+	}
+
+	client := setupTestRetryClient(t)
+	defer client.Close()
+
+	queueName := "test_reconfigure_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(rabbitmq.CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer cleanupRetrySystem(client, queueName, 2)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, client.GetChannel().Publish("", queueName, false, false, amqp.Publishing{Body: []byte("hello")}))
+	}
+
+	dir := t.TempDir()
+	mgmt := rabbitmq.NewManagementClient(cfg)
+	journal, err := StartReconfigure(dir, mgmt, "/", SetupOptions{QueueName: queueName, MaxRetries: 2, RetryDelay: 1})
+	require.NoError(t, err)
+
+	// Interrompida antes de começar: o contexto cancelado derruba a primeira etapa
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, RunReconfigure(ctx, cfg, journal, ReconfigureHooks{}))
+
+	// Retomada a partir do journal gravado em disco
+	journal, err = LoadReconfigureJournal(dir, "/", queueName)
+	require.NoError(t, err)
+	require.NoError(t, RunReconfigure(context.Background(), cfg, journal, ReconfigureHooks{}))
+
+	queue, err := mgmt.GetQueue("/", queueName)
+	require.NoError(t, err)
+	assert.Equal(t, WaitExchangeName(queueName), queue.Arguments["x-dead-letter-exchange"])
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 5, info.Messages)
+
+	_, err = LoadReconfigureJournal(dir, "/", queueName)
+	assert.Error(t, err, "journal removido ao concluir")
+}
+
+func TestReconfigure_KeepsDeathCount_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	cfg := config.RabbitMQConfig{
+		Host:           "localhost",
+		Port:           5672,
+		ManagementPort: 15672,
+		Username:       "test_user",
+		Password:       "test_pass",
+		VHost:          "/",
+	}
+
+	client := setupTestRetryClient(t)
+	defer client.Close()
+
+	queueName := "test_reconfigure_xdeath_" + time.Now().Format("20060102150405")
+	require.NoError(t, SetupRetry(client, SetupOptions{QueueName: queueName, QueueType: "classic", MaxRetries: 3, RetryDelay: 1}))
+	require.NoError(t, RecreateQueueWithDLX(client, queueName, "classic"))
+	defer cleanupRetrySystem(client, queueName, 3)
+
+	// Uma rejeição de verdade: a mensagem volta da wait queue com x-death
+	channel := client.GetChannel()
+	require.NoError(t, channel.Publish("", queueName, false, false, amqp.Publishing{Body: []byte("falha")}))
+	require.NoError(t, waitForMessage(t, channel, queueName, 5*time.Second).Reject(false))
+	back := waitForMessage(t, channel, queueName, 5*time.Second)
+	attempt, err := consumer.Attempt(back, queueName)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempt)
+	require.NoError(t, back.Nack(false, true))
+
+	dir := t.TempDir()
+	journal, err := StartReconfigure(dir, rabbitmq.NewManagementClient(cfg), "/", SetupOptions{QueueName: queueName, MaxRetries: 3, RetryDelay: 1})
+	require.NoError(t, err)
+	require.NoError(t, RunReconfigure(context.Background(), cfg, journal, ReconfigureHooks{}))
+
+	// Depois do spool em disco a contagem continua legível pelo consumer
+	msg := waitForMessage(t, channel, queueName, 5*time.Second)
+	attempt, err = consumer.Attempt(msg, queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempt)
+	require.NoError(t, msg.Ack(false))
+}
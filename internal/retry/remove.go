@@ -0,0 +1,204 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+)
+
+// Destinos possíveis para as mensagens da DLQ ao remover o sistema de retry
+const (
+	DLQKeep   = "keep"   // Mantém a DLQ e suas mensagens
+	DLQMove   = "move"   // Devolve as mensagens para a fila principal e remove a DLQ
+	DLQExport = "export" // Exporta as mensagens para arquivo e remove a DLQ
+)
+
+// ValidDLQAction verifica se a ação para a DLQ é suportada
+func ValidDLQAction(action string) bool {
+	switch action {
+	case DLQKeep, DLQMove, DLQExport:
+		return true
+	}
+	return false
+}
+
+// RemoveHooks recebem o andamento da remoção para exibição
+type RemoveHooks struct {
+	OnStep     func(step RemoveStep, index int) // Início de uma etapa (index 1-based)
+	OnProgress func(step RemoveStep, count int) // Mensagens processadas na etapa
+}
+
+func (h RemoveHooks) step(step RemoveStep, index int) {
+	if h.OnStep != nil {
+		h.OnStep(step, index)
+	}
+}
+
+func (h RemoveHooks) progress(step RemoveStep, count int) {
+	if h.OnProgress != nil {
+		h.OnProgress(step, count)
+	}
+}
+
+// StartRemove registra uma nova remoção do retry da fila: guarda a declaração
+// da fila principal (para recriá-la sem DLX) e cria o journal em dir
+func StartRemove(dir, vhost string, queue *rabbitmq.QueueInfoManagement, info *RetrySystemInfo, dlqAction, exportFile string) (*RemoveJournal, error) {
+	waitQueues := make([]string, len(info.Tiers))
+	for i, tier := range info.Tiers {
+		waitQueues[i] = tier.QueueName
+	}
+
+	return NewRemoveJournal(dir, RemoveJournal{
+		Queue: info.QueueName,
+		VHost: vhost,
+		Original: OriginalQueue{
+			Type:       queue.Type,
+			Durable:    queue.Durable,
+			AutoDelete: queue.AutoDelete,
+			Arguments:  queue.Arguments,
+		},
+		ByPolicy:   info.DLXSource == DLXSourcePolicy,
+		WaitQueues: waitQueues,
+		DLQ:        info.DLQ,
+		DLQAction:  dlqAction,
+		ExportFile: exportFile,
+	})
+}
+
+// RunRemove executa (ou retoma) as etapas pendentes da remoção.
+//
+// As wait queues são esvaziadas e removidas primeiro, com o binding da retry
+// exchange ainda no lugar: o que expirar nesse meio tempo cai na fila
+// principal. Só depois os exchanges saem e a fila principal é esvaziada.
+// Toda fila é removida com if-empty, repetindo o spool até ela estar vazia,
+// e as mensagens ficam no spool em disco até serem republicadas com
+// confirmação. Ao concluir, journal e spool são apagados.
+func RunRemove(ctx context.Context, cfg config.RabbitMQConfig, j *RemoveJournal, hooks RemoveHooks) error {
+	mgmt := rabbitmq.NewManagementClient(cfg)
+
+	for i, step := range j.Plan() {
+		if j.Done(step) {
+			continue
+		}
+		hooks.step(step, i+1)
+		progress := func(count int) { hooks.progress(step, count) }
+
+		var err error
+		switch step {
+		case RemoveStepPolicyRemoved:
+			err = RemoveRetryPolicy(mgmt, j.VHost, j.Queue)
+
+		case RemoveStepTiersDeleted:
+			for _, waitQueue := range j.WaitQueues {
+				if err = spoolAndDelete(ctx, cfg, j, j.VHost, waitQueue, progress); err != nil {
+					break
+				}
+			}
+
+		case RemoveStepComponentsDeleted:
+			err = withClient(cfg, func(client *rabbitmq.Client) error {
+				return DeleteRetryComponents(client, j.Queue, len(j.WaitQueues))
+			})
+
+		case RemoveStepDLQHandled:
+			err = removeDLQ(ctx, cfg, mgmt, j, progress)
+
+		case RemoveStepQueueDeleted:
+			err = spoolAndDelete(ctx, cfg, j, j.VHost, j.Queue, progress)
+
+		case RemoveStepQueueRecreated:
+			err = withClient(cfg, func(client *rabbitmq.Client) error {
+				return client.CreateQueue(rabbitmq.CreateQueueOptions{
+					Name:       j.Queue,
+					Type:       j.Original.Type,
+					Durable:    j.Original.Durable,
+					AutoDelete: j.Original.AutoDelete,
+					Arguments:  PlainQueueArguments(j.Original.Arguments),
+				})
+			})
+
+		case RemoveStepRepublished:
+			err = republishSpool(ctx, cfg, j, j.Queue, progress)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", step.Title(), err)
+		}
+		if err := j.Complete(step); err != nil {
+			return err
+		}
+	}
+
+	return j.Remove()
+}
+
+// removeDLQ leva a DLQ para o spool (DLQMove) ou para o arquivo de exportação
+// (DLQExport) e a remove; com DLQKeep ela fica como fila comum
+func removeDLQ(ctx context.Context, cfg config.RabbitMQConfig, mgmt *rabbitmq.ManagementClient, j *RemoveJournal, onProgress func(count int)) error {
+	if !j.DLQ {
+		return nil
+	}
+
+	dlq := DLQName(j.Queue)
+	switch j.DLQAction {
+	case DLQMove:
+		return spoolAndDelete(ctx, cfg, j, j.VHost, dlq, onProgress)
+	case DLQExport:
+		return drainAndDelete(ctx, mgmt, j.VHost, dlq, func() error {
+			lines, err := appendQueue(ctx, cfg, j.ExportFile, dlq, onProgress)
+			j.Exported = lines
+			if err != nil {
+				return err
+			}
+			return j.Save()
+		})
+	}
+	return nil
+}
+
+// DeleteRetryComponents remove exchanges e wait queues do sistema de retry.
+// As wait queues devem ter sido esvaziadas antes (ifEmpty=true evita perder mensagens).
+// Usa um canal temporário porque erros de precondição fecham o canal AMQP.
+func DeleteRetryComponents(client *rabbitmq.Client, queueName string, waitQueues int) error {
+	channel, err := client.GetConnection().Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	for attempt := 1; attempt <= waitQueues; attempt++ {
+		waitQueueName := WaitQueueName(queueName, attempt)
+		if _, err := channel.QueueDelete(waitQueueName, false, true, false); err != nil {
+			return fmt.Errorf("erro ao remover wait queue %s (ainda tem mensagens?): %w", waitQueueName, err)
+		}
+	}
+
+	for _, exchange := range []string{WaitExchangeName(queueName), FirstAttemptExchangeName(queueName), RetryExchangeName(queueName)} {
+		if err := channel.ExchangeDelete(exchange, false, false); err != nil {
+			return fmt.Errorf("erro ao remover exchange %s: %w", exchange, err)
+		}
+	}
+
+	return nil
+}
+
+// PlainQueueArguments retorna os argumentos da fila principal sem o dead-lettering
+// do retry, prontos para redeclarar a fila via AMQP.
+// A Management API devolve números como float64; o broker exige inteiros.
+func PlainQueueArguments(args map[string]interface{}) map[string]interface{} {
+	plain := make(map[string]interface{})
+	for k, v := range args {
+		switch k {
+		case "x-dead-letter-exchange", "x-dead-letter-routing-key", "x-queue-type":
+			continue
+		}
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			v = int64(f)
+		}
+		plain[k] = v
+	}
+	return plain
+}
@@ -0,0 +1,197 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+)
+
+// RemoveStep é uma etapa da remoção do sistema de retry de uma fila
+type RemoveStep string
+
+// Etapas da remoção, na ordem em que são executadas
+const (
+	RemoveStepPolicyRemoved     RemoveStep = "policy_removed"     // Policy do retry removido (retry via policy)
+	RemoveStepTiersDeleted      RemoveStep = "tiers_deleted"      // Wait queues levadas para o spool e removidas
+	RemoveStepComponentsDeleted RemoveStep = "components_deleted" // Wait, first e retry exchanges removidos
+	RemoveStepDLQHandled        RemoveStep = "dlq_handled"        // DLQ levada para o spool ou exportada, e removida
+	RemoveStepQueueDeleted      RemoveStep = "queue_deleted"      // Fila principal levada para o spool e removida
+	RemoveStepQueueRecreated    RemoveStep = "queue_recreated"    // Fila principal recriada sem DLX
+	RemoveStepRepublished       RemoveStep = "republished"        // Mensagens do spool republicadas
+)
+
+// Title descreve a etapa para exibição
+func (s RemoveStep) Title() string {
+	switch s {
+	case RemoveStepPolicyRemoved:
+		return "Removendo o policy do retry"
+	case RemoveStepTiersDeleted:
+		return "Salvando e removendo as wait queues"
+	case RemoveStepComponentsDeleted:
+		return "Removendo exchanges do retry"
+	case RemoveStepDLQHandled:
+		return "Tratando a DLQ"
+	case RemoveStepQueueDeleted:
+		return "Salvando e removendo a fila principal"
+	case RemoveStepQueueRecreated:
+		return "Recriando a fila sem DLX"
+	case RemoveStepRepublished:
+		return "Republicando mensagens"
+	}
+	return string(s)
+}
+
+// ErrRemovePending indica que já existe uma remoção de retry interrompida para a fila
+var ErrRemovePending = errors.New("remoção de retry pendente")
+
+// RemoveJournal registra o progresso da remoção do sistema de retry em disco,
+// permitindo concluir uma execução interrompida.
+//
+// Fica em <config>/remove junto do spool com as mensagens das wait queues,
+// da fila principal e, com DLQMove, da DLQ.
+type RemoveJournal struct {
+	Queue string `json:"queue"`
+	VHost string `json:"vhost"`
+
+	Original   OriginalQueue `json:"original"`    // Declaração da fila principal (recriada sem DLX)
+	ByPolicy   bool          `json:"by_policy"`   // DLX via policy: a fila principal é mantida
+	WaitQueues []string      `json:"wait_queues"` // Wait queues existentes
+	DLQ        bool          `json:"dlq"`         // A DLQ existe
+	DLQAction  string        `json:"dlq_action"`  // DLQKeep, DLQMove ou DLQExport
+	ExportFile string        `json:"export_file,omitempty"`
+	Exported   int           `json:"exported,omitempty"` // Mensagens da DLQ gravadas em ExportFile
+
+	SpoolState
+	Steps []RemoveStep `json:"steps"` // Etapas concluídas
+
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	dir string
+}
+
+// RemoveDir retorna o diretório padrão de journals e spools da remoção
+func RemoveDir() string {
+	return filepath.Join(config.GetConfigDir(), "remove")
+}
+
+// NewRemoveJournal cria e grava o journal de uma nova remoção. Com DLQExport
+// o arquivo de exportação é criado (ou truncado) antes de qualquer alteração
+// no broker. Retorna ErrRemovePending se já houver uma execução interrompida
+// para a fila.
+func NewRemoveJournal(dir string, j RemoveJournal) (*RemoveJournal, error) {
+	j.dir = dir
+	if _, err := os.Stat(j.path()); err == nil {
+		return nil, fmt.Errorf("%w para a fila '%s': use --resume", ErrRemovePending, j.Queue)
+	}
+
+	if j.DLQ && j.DLQAction == DLQExport {
+		path, err := filepath.Abs(j.ExportFile)
+		if err != nil {
+			return nil, fmt.Errorf("arquivo de exportação inválido: %w", err)
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar arquivo de exportação: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return nil, fmt.Errorf("erro ao criar arquivo de exportação: %w", err)
+		}
+		j.ExportFile = path
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+
+	j.Steps = nil
+	j.StartedAt = time.Now()
+	if err := j.Save(); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// LoadRemoveJournal lê o journal de uma remoção interrompida.
+// Retorna um erro compatível com os.ErrNotExist se não houver nenhuma.
+func LoadRemoveJournal(dir, vhost, queueName string) (*RemoveJournal, error) {
+	j := &RemoveJournal{VHost: vhost, Queue: queueName, dir: dir}
+
+	data, err := os.ReadFile(j.path())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("nenhuma remoção de retry pendente para a fila '%s': %w", queueName, err)
+		}
+		return nil, fmt.Errorf("erro ao ler journal: %w", err)
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("journal corrompido (%s): %w", j.path(), err)
+	}
+	return j, nil
+}
+
+// Plan lista as etapas da remoção na ordem de execução. Com policy a fila
+// principal é mantida: as mensagens do spool voltam para ela sem recriá-la.
+func (j *RemoveJournal) Plan() []RemoveStep {
+	if j.ByPolicy {
+		return []RemoveStep{
+			RemoveStepPolicyRemoved,
+			RemoveStepTiersDeleted,
+			RemoveStepComponentsDeleted,
+			RemoveStepDLQHandled,
+			RemoveStepRepublished,
+		}
+	}
+	return []RemoveStep{
+		RemoveStepTiersDeleted,
+		RemoveStepComponentsDeleted,
+		RemoveStepDLQHandled,
+		RemoveStepQueueDeleted,
+		RemoveStepQueueRecreated,
+		RemoveStepRepublished,
+	}
+}
+
+func (j *RemoveJournal) path() string {
+	return filepath.Join(j.dir, reconfigureKey(j.VHost, j.Queue)+".journal.json")
+}
+
+// SpoolPath retorna o arquivo NDJSON com as mensagens a republicar na fila
+func (j *RemoveJournal) SpoolPath() string {
+	return filepath.Join(j.dir, reconfigureKey(j.VHost, j.Queue)+".spool.ndjson")
+}
+
+func (j *RemoveJournal) spool() *SpoolState {
+	return &j.SpoolState
+}
+
+// Done indica se a etapa já foi concluída
+func (j *RemoveJournal) Done(step RemoveStep) bool {
+	return slices.Contains(j.Steps, step)
+}
+
+// Complete marca a etapa como concluída e grava o journal
+func (j *RemoveJournal) Complete(step RemoveStep) error {
+	if !j.Done(step) {
+		j.Steps = append(j.Steps, step)
+	}
+	return j.Save()
+}
+
+// Save grava o journal de forma atômica (veja writeJournal)
+func (j *RemoveJournal) Save() error {
+	j.UpdatedAt = time.Now()
+	return writeJournal(j.dir, j.path(), j)
+}
+
+// Remove apaga o journal e o spool de uma remoção encerrada
+func (j *RemoveJournal) Remove() error {
+	return removeFiles(j.SpoolPath(), j.path())
+}
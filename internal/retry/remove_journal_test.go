@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveJournal_Lifecycle(t *testing.T) {
+	dir := t.TempDir()
+	exportFile := t.TempDir() + "/orders.dlq.ndjson"
+	require.NoError(t, os.WriteFile(exportFile, []byte("antigo\n"), 0600))
+
+	j, err := NewRemoveJournal(dir, RemoveJournal{
+		Queue:      "orders",
+		VHost:      "/",
+		WaitQueues: []string{"orders.wait", "orders.wait.2"},
+		DLQ:        true,
+		DLQAction:  DLQExport,
+		ExportFile: exportFile,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dir+"/%2F_orders.spool.ndjson", j.SpoolPath())
+
+	data, err := os.ReadFile(exportFile)
+	require.NoError(t, err)
+	assert.Empty(t, data, "o arquivo de exportação é truncado antes de alterar o broker")
+
+	_, err = NewRemoveJournal(dir, RemoveJournal{Queue: "orders", VHost: "/"})
+	assert.ErrorIs(t, err, ErrRemovePending)
+
+	j.Spooled = 7
+	require.NoError(t, j.Complete(RemoveStepTiersDeleted))
+
+	loaded, err := LoadRemoveJournal(dir, "/", "orders")
+	require.NoError(t, err)
+	assert.True(t, loaded.Done(RemoveStepTiersDeleted))
+	assert.False(t, loaded.Done(RemoveStepComponentsDeleted))
+	assert.Equal(t, 7, loaded.Spooled)
+	assert.Equal(t, []string{"orders.wait", "orders.wait.2"}, loaded.WaitQueues)
+	assert.Equal(t, exportFile, loaded.ExportFile)
+
+	require.NoError(t, loaded.Remove())
+	_, err = LoadRemoveJournal(dir, "/", "orders")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRemoveJournal_Plan(t *testing.T) {
+	byArguments := &RemoveJournal{}
+	assert.Equal(t, []RemoveStep{
+		RemoveStepTiersDeleted,
+		RemoveStepComponentsDeleted,
+		RemoveStepDLQHandled,
+		RemoveStepQueueDeleted,
+		RemoveStepQueueRecreated,
+		RemoveStepRepublished,
+	}, byArguments.Plan(), "wait queues saem antes de a fila principal ser esvaziada")
+
+	byPolicy := &RemoveJournal{ByPolicy: true}
+	assert.Equal(t, RemoveStepPolicyRemoved, byPolicy.Plan()[0])
+	assert.NotContains(t, byPolicy.Plan(), RemoveStepQueueDeleted, "com policy a fila principal é mantida")
+}
+
+// newQueueDeleteServer simula a Management API: a fila existe até um DELETE
+// if-empty ser aceito, o que só acontece depois de notEmpty recusas
+func newQueueDeleteServer(t *testing.T, notEmpty int) (*rabbitmq.ManagementClient, *int) {
+	deletes := 0
+	exists := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && exists:
+			w.Write([]byte(`{"name":"orders"}`))
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "DELETE":
+			assert.Equal(t, "true", r.URL.Query().Get("if-empty"))
+			deletes++
+			if deletes <= notEmpty {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			exists = false
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+	managementPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	return rabbitmq.NewManagementClient(config.RabbitMQConfig{Host: host, ManagementPort: managementPort}), &deletes
+}
+
+func TestDrainAndDelete_RetriesUntilEmpty(t *testing.T) {
+	mgmt, deletes := newQueueDeleteServer(t, 2)
+
+	drains := 0
+	err := drainAndDelete(context.Background(), mgmt, "/", "orders", func() error {
+		drains++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, drains, "um novo drain a cada recusa do if-empty")
+	assert.Equal(t, 3, *deletes)
+
+	// Etapa retomada: a fila já não existe
+	err = drainAndDelete(context.Background(), mgmt, "/", "orders", func() error {
+		t.Fatal("fila inexistente não deve ser lida")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestDrainAndDelete_DrainError(t *testing.T) {
+	mgmt, deletes := newQueueDeleteServer(t, 0)
+
+	drainErr := errors.New("conexão perdida")
+	err := drainAndDelete(context.Background(), mgmt, "/", "orders", func() error {
+		return drainErr
+	})
+	assert.ErrorIs(t, err, drainErr)
+	assert.Zero(t, *deletes, "a fila não é removida se o drain falhar")
+}
@@ -0,0 +1,33 @@
+package retry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidDLQAction(t *testing.T) {
+	for _, action := range []string{DLQKeep, DLQMove, DLQExport} {
+		assert.True(t, ValidDLQAction(action), action)
+	}
+	assert.False(t, ValidDLQAction(""))
+	assert.False(t, ValidDLQAction("delete"))
+}
+
+func TestPlainQueueArguments(t *testing.T) {
+	args := map[string]interface{}{
+		"x-dead-letter-exchange":    "orders.wait.exchange",
+		"x-dead-letter-routing-key": "orders",
+		"x-queue-type":              "quorum",
+		"x-max-length":              float64(1000),
+		"x-overflow":                "reject-publish",
+	}
+
+	plain := PlainQueueArguments(args)
+
+	assert.Equal(t, map[string]interface{}{
+		"x-max-length": int64(1000),
+		"x-overflow":   "reject-publish",
+	}, plain)
+	assert.Empty(t, PlainQueueArguments(nil))
+}
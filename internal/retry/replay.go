@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ReplayOptions são opções para reprocessar mensagens da DLQ
+type ReplayOptions struct {
+	QueueName  string                 // Fila principal (a DLQ é <fila>.dlq)
+	Exchange   string                 // Exchange de destino (vazio = fila principal)
+	RoutingKey string                 // Routing key no exchange de destino (padrão: routing key original)
+	Max        int                    // Máximo de mensagens reprocessadas (0 = todas)
+	Filter     rabbitmq.MessageFilter // Apenas mensagens que casam com o filtro
+	Rate       float64                // Mensagens por segundo (0 = sem limite)
+	KeepXDeath bool                   // Manter headers x-death (padrão: remover)
+	DryRun     bool                   // Apenas listar o que seria reprocessado
+	Timeout    time.Duration          // Timeout de cada confirmação (padrão: 5s)
+
+	// OnMessage é chamado para cada mensagem que casa com o filtro
+	OnMessage func(d amqp.Delivery, result ReplayResult)
+}
+
+// ReplayResult resume um replay
+type ReplayResult struct {
+	Scanned  int // Mensagens lidas da DLQ
+	Matched  int // Mensagens que casaram com o filtro
+	Replayed int // Mensagens republicadas e removidas da DLQ
+
+	Stats rabbitmq.PublishStats // Publicações no destino (vazão e reenvios)
+}
+
+// deathHeaders são os headers que o broker adiciona ao fazer dead-letter
+var deathHeaders = []string{
+	"x-death",
+	"x-first-death-queue",
+	"x-first-death-reason",
+	"x-first-death-exchange",
+	"x-last-death-queue",
+	"x-last-death-reason",
+	"x-last-death-exchange",
+}
+
+// ReplayDLQ republica mensagens da DLQ no destino e só então remove cada uma
+// da DLQ (ack após a confirmação do broker).
+//
+// As mensagens são lidas uma a uma com basic.get, sem carregar a DLQ em memória,
+// e publicadas por um rabbitmq.ConfirmPipeline, com várias confirmações
+// pendentes ao mesmo tempo. Mensagens fora do filtro (ou do limite) ficam
+// pendentes até o fim e voltam para a DLQ na ordem original; o mesmo vale
+// para todas as mensagens em --dry-run e para as que estavam em voo quando
+// o replay foi interrompido (que podem ter chegado ao destino: at-least-once).
+func ReplayDLQ(ctx context.Context, client *rabbitmq.Client, opts ReplayOptions) (*ReplayResult, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	dlqName := DLQName(opts.QueueName)
+	result := &ReplayResult{}
+
+	channel, err := client.GetConnection().Channel()
+	if err != nil {
+		return result, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	// Devolver para a DLQ tudo que não foi confirmado (ack); ao fechar o canal o
+	// broker faria o mesmo, mas explicitamente fica claro no código
+	var lastUnacked uint64
+	var inFlight []amqp.Delivery
+	defer func() {
+		for _, d := range inFlight {
+			d.Nack(false, true)
+		}
+		if lastUnacked > 0 {
+			channel.Nack(lastUnacked, true, true)
+		}
+	}()
+
+	var pipeline *rabbitmq.ConfirmPipeline
+	if !opts.DryRun {
+		pipeline, err = rabbitmq.NewConfirmPipeline(channel, rabbitmq.PipelineOptions{Timeout: opts.Timeout, User: client.GetUsername()})
+		if err != nil {
+			return result, err
+		}
+		defer func() {
+			result.Stats = pipeline.Stats()
+		}()
+	}
+
+	// As confirmações chegam na ordem de publicação: a mais antiga em voo é a confirmada
+	onConfirm := func() error {
+		d := inFlight[0]
+		inFlight = inFlight[1:]
+		if err := d.Ack(false); err != nil {
+			return fmt.Errorf("mensagem republicada, mas não removida da DLQ: %w", err)
+		}
+		result.Replayed++
+
+		if opts.OnMessage != nil {
+			opts.OnMessage(d, *result)
+		}
+		return nil
+	}
+
+	var interval time.Duration
+	if opts.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / opts.Rate)
+	}
+	var lastPublish time.Time
+
+	for opts.Max <= 0 || result.Matched < opts.Max {
+		if err := ctx.Err(); err != nil {
+			if pipeline != nil {
+				pipeline.Flush(context.Background())
+			}
+			return result, err
+		}
+
+		d, ok, err := channel.Get(dlqName, false)
+		if err != nil {
+			return result, fmt.Errorf("erro ao ler DLQ: %w", err)
+		}
+		if !ok {
+			break
+		}
+		result.Scanned++
+
+		if !opts.Filter.Match(d.Headers, d.Body) {
+			lastUnacked = d.DeliveryTag
+			continue
+		}
+		result.Matched++
+
+		if opts.DryRun {
+			lastUnacked = d.DeliveryTag
+			if opts.OnMessage != nil {
+				opts.OnMessage(d, *result)
+			}
+			continue
+		}
+
+		if interval > 0 && !lastPublish.IsZero() {
+			if wait := interval - time.Since(lastPublish); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					lastUnacked = d.DeliveryTag
+					pipeline.Flush(context.Background())
+					return result, ctx.Err()
+				}
+			}
+		}
+		lastPublish = time.Now()
+
+		exchange, routingKey := replayTarget(opts, d)
+		inFlight = append(inFlight, d)
+		if err := pipeline.Publish(ctx, exchange, routingKey, replayPublishing(d, opts.KeepXDeath), onConfirm); err != nil {
+			return result, fmt.Errorf("erro ao republicar (mensagens não confirmadas mantidas na DLQ): %w", err)
+		}
+	}
+
+	if pipeline != nil {
+		if err := pipeline.Flush(ctx); err != nil {
+			return result, fmt.Errorf("erro ao republicar (mensagens não confirmadas mantidas na DLQ): %w", err)
+		}
+	}
+	return result, nil
+}
+
+// replayTarget define exchange e routing key de destino de uma mensagem
+func replayTarget(opts ReplayOptions, d amqp.Delivery) (string, string) {
+	if opts.Exchange == "" {
+		return "", opts.QueueName
+	}
+	if opts.RoutingKey != "" {
+		return opts.Exchange, opts.RoutingKey
+	}
+	return opts.Exchange, originalRoutingKey(opts.QueueName, d)
+}
+
+// originalRoutingKey retorna a routing key com que a mensagem chegou à fila
+// principal. Na DLQ a routing key é a da última tentativa (<fila>.attempt.N);
+// a original fica na entrada do x-death da fila principal, que o broker não
+// altera nas mortes seguintes (só o contador). Sem essa entrada, usa a da DLQ.
+func originalRoutingKey(queueName string, d amqp.Delivery) string {
+	deaths := ParseXDeath(d.Headers)
+	// x-death vem da morte mais recente para a mais antiga
+	for i := len(deaths) - 1; i >= 0; i-- {
+		if deaths[i].Queue == queueName && len(deaths[i].RoutingKeys) > 0 {
+			return deaths[i].RoutingKeys[0]
+		}
+	}
+	return d.RoutingKey
+}
+
+// replayPublishing recria a mensagem com as propriedades originais (veja
+// rabbitmq.PublishingFromDelivery) e, sem keepXDeath, sem os headers de
+// dead-letter: a mensagem recomeça as tentativas
+func replayPublishing(d amqp.Delivery, keepXDeath bool) amqp.Publishing {
+	msg := rabbitmq.PublishingFromDelivery(d)
+	if keepXDeath {
+		return msg
+	}
+
+	headers := make(amqp.Table, len(d.Headers))
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	for _, key := range deathHeaders {
+		delete(headers, key)
+	}
+	// Contador do backend delayed
+	delete(headers, HeaderRetries)
+	msg.Headers = headers
+	return msg
+}
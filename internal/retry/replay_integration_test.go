@@ -0,0 +1,66 @@
+//go:build integration
+// +build integration
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayDLQ_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestRetryClient(t)
+	defer client.Close()
+
+	queueName := "test_replay_" + time.Now().Format("20060102150405")
+	require.NoError(t, SetupRetry(client, SetupOptions{QueueName: queueName, QueueType: "classic", MaxRetries: 1, RetryDelay: 1}))
+	require.NoError(t, RecreateQueueWithDLX(client, queueName, "classic"))
+	defer cleanupRetrySystem(client, queueName, 1)
+
+	channel := client.GetChannel()
+	for _, tenant := range []string{"acme", "other", "acme"} {
+		err := channel.Publish("", DLQName(queueName), false, false, amqp.Publishing{
+			Headers: amqp.Table{"tenant": tenant, "x-first-death-reason": "rejected"},
+			Body:    []byte(tenant),
+		})
+		require.NoError(t, err)
+	}
+
+	// Dry run não altera nada
+	result, err := ReplayDLQ(context.Background(), client, ReplayOptions{QueueName: queueName, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Matched)
+	assert.Equal(t, 0, result.Replayed)
+
+	// Apenas tenant=acme volta para a fila principal
+	result, err = ReplayDLQ(context.Background(), client, ReplayOptions{
+		QueueName: queueName,
+		Filter:    rabbitmq.MessageFilter{Headers: map[string]string{"tenant": "acme"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Scanned)
+	assert.Equal(t, 2, result.Replayed)
+
+	mainQueue, err := channel.QueueDeclarePassive(queueName, true, false, false, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mainQueue.Messages)
+
+	dlq, err := channel.QueueDeclarePassive(DLQName(queueName), true, false, false, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dlq.Messages)
+
+	msg, ok, err := channel.Get(queueName, true)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.NotContains(t, msg.Headers, "x-first-death-reason")
+}
@@ -0,0 +1,80 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func deadLetteredDelivery() amqp.Delivery {
+	return amqp.Delivery{
+		RoutingKey:    "orders.attempt.3",
+		ContentType:   "application/json",
+		MessageId:     "msg-1",
+		CorrelationId: "corr-1",
+		DeliveryMode:  amqp.Persistent,
+		Expiration:    "60000",
+		UserId:        "orders-service",
+		Timestamp:     time.Unix(1700000000, 0),
+		Body:          []byte(`{"id":1}`),
+		Headers: amqp.Table{
+			"tenant": "acme",
+			"x-death": []interface{}{
+				amqp.Table{"queue": "orders.wait.3", "reason": "expired", "count": int64(1), "routing-keys": []interface{}{"orders.attempt.3"}},
+				amqp.Table{"queue": "orders", "reason": "rejected", "count": int64(3), "routing-keys": []interface{}{"orders.created"}},
+			},
+			"x-first-death-queue":  "orders",
+			"x-first-death-reason": "rejected",
+			"x-last-death-queue":   "orders",
+		},
+	}
+}
+
+func TestReplayPublishing_ResetsXDeath(t *testing.T) {
+	d := deadLetteredDelivery()
+
+	msg := replayPublishing(d, false)
+
+	assert.Equal(t, amqp.Table{"tenant": "acme"}, msg.Headers)
+	assert.Equal(t, d.Body, msg.Body)
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, "msg-1", msg.MessageId)
+	assert.Equal(t, "corr-1", msg.CorrelationId)
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+	assert.Equal(t, d.Timestamp, msg.Timestamp)
+	assert.Equal(t, "60000", msg.Expiration, "mesma regra das demais republicações")
+	assert.Equal(t, "orders-service", msg.UserId, "o ConfirmPipeline decide se o user-id é aceito")
+
+	// A delivery original não é alterada
+	assert.Contains(t, d.Headers, "x-death")
+}
+
+func TestReplayPublishing_KeepsXDeath(t *testing.T) {
+	msg := replayPublishing(deadLetteredDelivery(), true)
+
+	assert.Contains(t, msg.Headers, "x-death")
+	assert.Contains(t, msg.Headers, "x-first-death-reason")
+}
+
+func TestReplayTarget(t *testing.T) {
+	d := deadLetteredDelivery()
+
+	exchange, key := replayTarget(ReplayOptions{QueueName: "orders"}, d)
+	assert.Equal(t, "", exchange)
+	assert.Equal(t, "orders", key)
+
+	exchange, key = replayTarget(ReplayOptions{QueueName: "orders", Exchange: "events"}, d)
+	assert.Equal(t, "events", exchange)
+	assert.Equal(t, "orders.created", key, "routing key do x-death da fila principal, não a da tentativa")
+
+	exchange, key = replayTarget(ReplayOptions{QueueName: "orders", Exchange: "events", RoutingKey: "orders.replayed"}, d)
+	assert.Equal(t, "events", exchange)
+	assert.Equal(t, "orders.replayed", key)
+
+	// Sem entrada da fila principal no x-death, fica a routing key da DLQ
+	d.Headers = amqp.Table{}
+	_, key = replayTarget(ReplayOptions{QueueName: "orders", Exchange: "events"}, d)
+	assert.Equal(t, "orders.attempt.3", key)
+}
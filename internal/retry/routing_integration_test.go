@@ -0,0 +1,121 @@
+//go:build integration
+// +build integration
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForMessage faz basic.get até receber uma mensagem ou estourar o timeout
+func waitForMessage(t *testing.T, channel *amqp.Channel, queueName string, timeout time.Duration) amqp.Delivery {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		msg, ok, err := channel.Get(queueName, false)
+		require.NoError(t, err)
+		if ok {
+			return msg
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	t.Fatalf("nenhuma mensagem recebida em %s", queueName)
+	return amqp.Delivery{}
+}
+
+func cleanupRetrySystem(client *rabbitmq.Client, queueName string, maxRetries int) {
+	channel := client.GetChannel()
+	channel.QueueDelete(queueName, false, false, false)
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		channel.QueueDelete(WaitQueueName(queueName, attempt), false, false, false)
+	}
+	channel.QueueDelete(DLQName(queueName), false, false, false)
+	channel.ExchangeDelete(WaitExchangeName(queueName), false, false)
+	channel.ExchangeDelete(FirstAttemptExchangeName(queueName), false, false)
+	channel.ExchangeDelete(RetryExchangeName(queueName), false, false)
+}
+
+func TestRetryRouting_EnforcesMaxRetries_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestRetryClient(t)
+	defer client.Close()
+
+	queueName := "test_retry_routing_" + time.Now().Format("20060102150405")
+	maxRetries := 2
+
+	err := SetupRetry(client, SetupOptions{
+		QueueName:  queueName,
+		QueueType:  "classic",
+		MaxRetries: maxRetries,
+		RetryDelay: 1,
+	})
+	require.NoError(t, err)
+	defer cleanupRetrySystem(client, queueName, maxRetries)
+
+	err = RecreateQueueWithDLX(client, queueName, "classic")
+	require.NoError(t, err)
+
+	channel := client.GetChannel()
+	err = channel.Publish("", queueName, false, false, amqp.Publishing{Body: []byte("falha")})
+	require.NoError(t, err)
+
+	// Cada rejeição deve devolver a mensagem para a fila principal após o delay
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		msg := waitForMessage(t, channel, queueName, 5*time.Second)
+		require.NoError(t, msg.Reject(false))
+
+		back := waitForMessage(t, channel, queueName, 5*time.Second)
+		assert.Equal(t, AttemptRoutingKey(queueName, attempt), back.RoutingKey)
+		require.NoError(t, back.Nack(false, true))
+	}
+
+	// Rejeição após a última tentativa vai direto para a DLQ
+	msg := waitForMessage(t, channel, queueName, 5*time.Second)
+	require.NoError(t, msg.Reject(false))
+
+	dead := waitForMessage(t, channel, DLQName(queueName), 5*time.Second)
+	assert.Equal(t, []byte("falha"), dead.Body)
+	require.NoError(t, dead.Ack(false))
+}
+
+func TestSetupRetry_MaxRetriesChangeRequiresForce_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client := setupTestRetryClient(t)
+	defer client.Close()
+
+	queueName := "test_retry_resize_" + time.Now().Format("20060102150405")
+	opts := SetupOptions{QueueName: queueName, QueueType: "classic", MaxRetries: 3, RetryDelay: 1}
+	defer cleanupRetrySystem(client, queueName, 5)
+
+	require.NoError(t, SetupRetry(client, opts))
+	require.NoError(t, SetupRetry(client, opts), "a mesma configuração pode ser reaplicada")
+
+	opts.MaxRetries = 5
+	err := SetupRetry(client, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "já existe com 3 tentativa(s)")
+
+	opts.Force = true
+	require.NoError(t, SetupRetry(client, opts))
+	_, tiers, err := existingTiers(client, queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 5, tiers)
+
+	// Reduzir com --force remove os tiers excedentes
+	opts.MaxRetries = 2
+	require.NoError(t, SetupRetry(client, opts))
+	_, tiers, err = existingTiers(client, queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tiers)
+}
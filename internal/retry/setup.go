@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"errors"
 	"fmt"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -16,52 +17,67 @@ type SetupOptions struct {
 	RetryDelay int    // Delay entre tentativas em segundos (será convertido para TTL em ms)
 	DLQTTL     int    // TTL de mensagens na DLQ em milissegundos (0 = sem expiração)
 	Force      bool   // Recriar mesmo se já existir
+	Backend    string // BackendTTL (padrão) ou BackendDelayed
+
+	// Backoff exponencial (opcional, veja TierDelays)
+	Backoff           []int   // Delay de cada tier em segundos (ex: 5, 30, 300, 1800)
+	BackoffMultiplier float64 // Multiplicador aplicado ao RetryDelay a cada tentativa
+	MaxRetryDelay     int     // Limite do delay calculado pelo multiplicador em segundos (0 = sem limite)
+}
+
+// RetryTierInfo contém informações de uma wait queue (tier de backoff)
+type RetryTierInfo struct {
+	Attempt   int
+	QueueName string
+	Delay     int // Delay em segundos (x-message-ttl da wait queue)
+	Messages  int
 }
 
 // RetrySystemInfo contém informações sobre o sistema de retry configurado
 type RetrySystemInfo struct {
-	QueueName       string
-	MainQueue       bool
-	WaitQueue       bool
-	WaitExchange    bool
-	RetryExchange   bool
-	DLQ             bool
-	MaxRetries      int
-	RetryDelay      int
-	DLQTTL          int
-	MainQueueMsgs   int
-	WaitQueueMsgs   int
-	DLQMsgs         int
+	QueueName     string
+	MainQueue     bool
+	WaitQueue     bool
+	WaitExchange  bool
+	FirstExchange bool
+	RetryExchange bool
+	DLQ           bool
+	Backend       string // BackendTTL ou BackendDelayed
+	DLXSource     string // DLXSourceArguments ou DLXSourcePolicy (vazio = fila sem DLX)
+	Policy        string // Policy aplicado à fila principal
+	MaxRetries    int
+	RetryDelay    int
+	DLQTTL        int
+	MainQueueMsgs int
+	WaitQueueMsgs int // Soma das mensagens em todos os tiers
+	DLQMsgs       int
+	Tiers         []RetryTierInfo
+	Metadata      *RetryMetadata // Configuração persistida no wait exchange (nil se ausente)
+	Drift         []string       // Divergências entre componentes e configuração
+	Bindings      []RetryBindingStatus
 }
 
-// SetupRetry configura o sistema completo de retry para uma fila
+// SetupRetry configura o sistema completo de retry para uma fila.
+//
+// Cria uma wait queue por tentativa (veja names.go): o número da tentativa
+// viaja na routing key, então o próprio broker decide se a mensagem volta
+// para a fila principal ou vai para a DLQ, sem lógica no consumer.
+// Cada wait queue é um tier de backoff com o TTL calculado por TierDelays.
+// Com BackendDelayed as wait queues são substituídas por um exchange
+// x-delayed-message (veja delayed.go).
+// A fila principal deve ser (re)criada depois com RecreateQueueWithDLX.
 func SetupRetry(client *rabbitmq.Client, opts SetupOptions) error {
 	queueName := opts.QueueName
-	
-	// Nomes dos componentes do sistema de retry
-	waitQueueName := fmt.Sprintf("%s.wait", queueName)
-	waitExchangeName := fmt.Sprintf("%s.wait.exchange", queueName)
-	retryExchangeName := fmt.Sprintf("%s.retry", queueName)
-	dlqName := fmt.Sprintf("%s.dlq", queueName)
-
-	channel := client.GetChannel()
-
-	// 1. Criar Wait Exchange (recebe mensagens rejeitadas)
-	if err := channel.ExchangeDeclare(
-		waitExchangeName,
-		"fanout", // tipo fanout
-		true,     // durable
-		false,    // auto-delete
-		false,    // internal
-		false,    // no-wait
-		nil,      // arguments
-	); err != nil {
-		return fmt.Errorf("erro ao criar wait exchange: %w", err)
+	maxRetries := opts.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
 	}
 
-	// 2. Criar Wait Queue (delays mensagem antes de retry)
-	// TTL é em milissegundos
-	retryDelayMs := opts.RetryDelay * 1000
+	// Nomes dos componentes do sistema de retry
+	waitExchangeName := WaitExchangeName(queueName)
+	firstExchangeName := FirstAttemptExchangeName(queueName)
+	retryExchangeName := RetryExchangeName(queueName)
+	dlqName := DLQName(queueName)
 
 	// Determinar tipo de fila (herda da fila principal)
 	queueType := opts.QueueType
@@ -69,56 +85,32 @@ func SetupRetry(client *rabbitmq.Client, opts SetupOptions) error {
 		queueType = "classic"
 	}
 
-	waitQueueArgs := amqp.Table{
-		"x-message-ttl":          int32(retryDelayMs),
-		"x-dead-letter-exchange": retryExchangeName,
-		"x-queue-type":           queueType,
+	if opts.Force {
+		if err := resetComponents(client, queueName, maxRetries); err != nil {
+			return err
+		}
+	} else if opts.Backend != BackendDelayed {
+		// Os bindings de cada tentativa dependem de MaxRetries: redeclarar com
+		// outro valor deixaria os antigos (ex: attempt.3 -> DLQ e attempt.3 -> wait.4)
+		exists, tiers, err := existingTiers(client, queueName)
+		if err != nil {
+			return err
+		}
+		if exists && tiers != maxRetries {
+			return fmt.Errorf("o retry de '%s' já existe com %d tentativa(s); use --force para recriá-lo com %d", queueName, tiers, maxRetries)
+		}
 	}
 
-	if _, err := channel.QueueDeclare(
-		waitQueueName,
-		true,  // durable
-		false, // auto-delete
-		false, // exclusive
-		false, // no-wait
-		waitQueueArgs,
-	); err != nil {
-		return fmt.Errorf("erro ao criar wait queue: %w", err)
-	}
-
-	// 3. Bind Wait Queue to Wait Exchange
-	if err := channel.QueueBind(
-		waitQueueName,
-		"", // routing key (fanout não usa)
-		waitExchangeName,
-		false, // no-wait
-		nil,   // arguments
-	); err != nil {
-		return fmt.Errorf("erro ao fazer bind wait queue: %w", err)
-	}
-
-	// 4. Criar Retry Exchange (rota para main queue ou DLQ baseado em retry count)
-	// Tipo headers para permitir roteamento baseado em headers
-	if err := channel.ExchangeDeclare(
-		retryExchangeName,
-		"headers", // tipo headers
-		true,      // durable
-		false,     // auto-delete
-		false,     // internal
-		false,     // no-wait
-		nil,       // arguments
-	); err != nil {
-		return fmt.Errorf("erro ao criar retry exchange: %w", err)
-	}
+	channel := client.GetChannel()
 
-	// 5. Criar DLQ (destino final após max retries)
+	// 1. Criar DLQ (destino final após max retries)
 	// Herda o tipo da fila principal para manter consistência
 	dlqArgs := amqp.Table{
 		"x-queue-type": queueType,
 	}
 	// Apenas adiciona TTL se for maior que 0 (0 = sem expiração)
 	if opts.DLQTTL > 0 {
-		dlqArgs["x-message-ttl"] = int32(opts.DLQTTL)
+		dlqArgs["x-message-ttl"] = int64(opts.DLQTTL)
 	}
 
 	if _, err := channel.QueueDeclare(
@@ -132,29 +124,182 @@ func SetupRetry(client *rabbitmq.Client, opts SetupOptions) error {
 		return fmt.Errorf("erro ao criar DLQ: %w", err)
 	}
 
-	// 6. Bind DLQ to Retry Exchange
-	// Nota: O roteamento baseado em retry count é feito pelo consumer
-	// Este bind aceita todas as mensagens que chegam no retry exchange
-	bindingArgs := amqp.Table{
-		"x-match": "any", // Aceita qualquer mensagem
+	delays := opts.TierDelays()
+	waitExchangeArgs := metadataArgs(maxRetries, delays, opts.DLQTTL, queueType)
+	if opts.Backend == BackendDelayed {
+		return setupDelayedRetry(client, opts, waitExchangeArgs)
 	}
-	if err := channel.QueueBind(
-		dlqName,
-		"", // routing key (headers não usa routing key tradicional)
-		retryExchangeName,
-		false,        // no-wait
-		bindingArgs,  // arguments para headers
+
+	// 2. Criar Retry Exchange (devolve as mensagens para a fila principal)
+	// O bind com a fila principal é feito em RecreateQueueWithDLX
+	if err := declareRetryExchange(client, queueName, "headers", nil, opts.Force); err != nil {
+		return err
+	}
+
+	// Versões anteriores ligavam a DLQ ao retry exchange; o bind não roteava nada
+	if err := channel.QueueUnbind(dlqName, "", retryExchangeName, amqp.Table{"x-match": "any"}); err != nil {
+		return fmt.Errorf("erro ao remover bind antigo da DLQ: %w", err)
+	}
+
+	// 3. Criar exchange da primeira falha (alternate exchange do wait exchange)
+	if err := channel.ExchangeDeclare(
+		firstExchangeName,
+		"fanout",
+		true,  // durable
+		false, // auto-delete
+		false, // internal
+		false, // no-wait
+		nil,
+	); err != nil {
+		return fmt.Errorf("erro ao criar exchange da primeira tentativa: %w", err)
+	}
+
+	// 4. Criar Wait Exchange (recebe mensagens rejeitadas)
+	// Rejeições com routing key "<fila>.attempt.N" seguem para a próxima tentativa;
+	// qualquer outra chave é a primeira falha e cai no alternate exchange.
+	// A configuração fica persistida nos argumentos (veja metadata.go).
+	waitExchangeArgs["alternate-exchange"] = firstExchangeName
+
+	if err := channel.ExchangeDeclare(
+		waitExchangeName,
+		"direct",
+		true,  // durable
+		false, // auto-delete
+		false, // internal
+		false, // no-wait
+		waitExchangeArgs,
 	); err != nil {
-		return fmt.Errorf("erro ao fazer bind DLQ: %w", err)
+		return fmt.Errorf("erro ao criar wait exchange (use --force para recriar componentes antigos): %w", err)
+	}
+
+	// 5. Criar uma Wait Queue por tentativa (TTL do tier em milissegundos)
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		waitQueueName := WaitQueueName(queueName, attempt)
+		waitQueueArgs := amqp.Table{
+			"x-message-ttl":             int64(delays[attempt-1]) * 1000,
+			"x-dead-letter-exchange":    retryExchangeName,
+			"x-dead-letter-routing-key": AttemptRoutingKey(queueName, attempt),
+			"x-queue-type":              queueType,
+		}
+
+		if _, err := channel.QueueDeclare(
+			waitQueueName,
+			true,  // durable
+			false, // auto-delete
+			false, // exclusive
+			false, // no-wait
+			waitQueueArgs,
+		); err != nil {
+			return fmt.Errorf("erro ao criar wait queue %s (use --force para recriar componentes antigos): %w", waitQueueName, err)
+		}
+	}
+
+	// 6. Bindings: primeira falha -> tentativa 1; tentativa N -> N+1; última -> DLQ
+	firstTarget := dlqName
+	if maxRetries > 0 {
+		firstTarget = WaitQueueName(queueName, 1)
+	}
+	if err := channel.QueueBind(firstTarget, "", firstExchangeName, false, nil); err != nil {
+		return fmt.Errorf("erro ao fazer bind da primeira tentativa: %w", err)
+	}
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		target := dlqName
+		if attempt < maxRetries {
+			target = WaitQueueName(queueName, attempt+1)
+		}
+		if err := channel.QueueBind(
+			target,
+			AttemptRoutingKey(queueName, attempt),
+			waitExchangeName,
+			false, // no-wait
+			nil,
+		); err != nil {
+			return fmt.Errorf("erro ao fazer bind da tentativa %d: %w", attempt, err)
+		}
+	}
+
+	return nil
+}
+
+// resetComponents remove exchanges e wait queues vazias para que possam ser
+// redeclarados com novos argumentos. Usa um canal temporário porque erros de
+// precondição fecham o canal AMQP.
+func resetComponents(client *rabbitmq.Client, queueName string, maxRetries int) error {
+	channel, err := client.GetConnection().Channel()
+	if err != nil {
+		return fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+	defer channel.Close()
+
+	for _, exchange := range []string{WaitExchangeName(queueName), FirstAttemptExchangeName(queueName)} {
+		if err := channel.ExchangeDelete(exchange, false, false); err != nil {
+			return fmt.Errorf("erro ao remover exchange %s: %w", exchange, err)
+		}
+	}
+
+	// Tiers além do novo MaxRetries também saem, para não ficarem órfãos
+	_, tiers, err := existingTiers(client, queueName)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; attempt <= max(maxRetries, tiers); attempt++ {
+		waitQueueName := WaitQueueName(queueName, attempt)
+		if _, err := channel.QueueDelete(waitQueueName, false, true, false); err != nil {
+			return fmt.Errorf("wait queue %s ainda tem mensagens aguardando retry; aguarde esvaziar: %w", waitQueueName, err)
+		}
 	}
 
 	return nil
 }
 
+// existingTiers verifica se o wait exchange existe e conta as wait queues
+// consecutivas a partir da tentativa 1. Declarações passivas de recursos
+// inexistentes fecham o canal, então cada verificação usa um canal novo.
+func existingTiers(client *rabbitmq.Client, queueName string) (bool, int, error) {
+	exists := func(declare func(channel *amqp.Channel) error) (bool, error) {
+		channel, err := client.GetConnection().Channel()
+		if err != nil {
+			return false, fmt.Errorf("erro ao abrir canal: %w", err)
+		}
+		defer channel.Close()
+
+		err = declare(channel)
+		var amqpErr *amqp.Error
+		if errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	found, err := exists(func(channel *amqp.Channel) error {
+		return channel.ExchangeDeclarePassive(WaitExchangeName(queueName), "direct", true, false, false, false, nil)
+	})
+	if err != nil || !found {
+		return false, 0, err
+	}
+
+	tiers := 0
+	for {
+		found, err := exists(func(channel *amqp.Channel) error {
+			_, err := channel.QueueDeclarePassive(WaitQueueName(queueName, tiers+1), true, false, false, false, nil)
+			return err
+		})
+		if err != nil {
+			return false, 0, err
+		}
+		if !found {
+			return true, tiers, nil
+		}
+		tiers++
+	}
+}
+
 // RecreateQueueWithDLX recria a fila principal com DLX apontando para wait exchange
+// e a liga ao retry exchange para receber as mensagens de volta
 func RecreateQueueWithDLX(client *rabbitmq.Client, queueName string, queueType string) error {
 	channel := client.GetChannel()
-	waitExchangeName := fmt.Sprintf("%s.wait.exchange", queueName)
+	waitExchangeName := WaitExchangeName(queueName)
 
 	// Argumentos da fila com DLX
 	args := amqp.Table{
@@ -181,13 +326,36 @@ func RecreateQueueWithDLX(client *rabbitmq.Client, queueName string, queueType s
 		return fmt.Errorf("erro ao recriar fila com DLX: %w", err)
 	}
 
+	return BindRetryExchange(client, queueName)
+}
+
+// BindRetryExchange liga a fila principal ao retry exchange para receber as
+// mensagens de volta das wait queues
+func BindRetryExchange(client *rabbitmq.Client, queueName string) error {
+	// x-match all sem outros headers casa com qualquer mensagem
+	if err := client.GetChannel().QueueBind(
+		queueName,
+		"",
+		RetryExchangeName(queueName),
+		false,
+		amqp.Table{"x-match": "all"},
+	); err != nil {
+		return fmt.Errorf("erro ao fazer bind da fila no retry exchange: %w", err)
+	}
+
 	return nil
 }
 
-// GetRetrySystemInfo obtém informações sobre o sistema de retry
+// GetRetrySystemInfo obtém informações sobre o sistema de retry.
+//
+// A configuração é lida dos próprios componentes (TTL das wait queues e
+// argumentos da DLQ), que é o que o broker de fato aplica. A metadata
+// persistida no wait exchange é usada quando os componentes não existem
+// e para apontar divergências (Drift).
 func GetRetrySystemInfo(mgmtClient *rabbitmq.ManagementClient, cfg config.RabbitMQConfig, queueName string) (*RetrySystemInfo, error) {
 	info := &RetrySystemInfo{
 		QueueName: queueName,
+		Backend:   BackendTTL,
 	}
 
 	vhost := cfg.VHost
@@ -197,37 +365,99 @@ func GetRetrySystemInfo(mgmtClient *rabbitmq.ManagementClient, cfg config.Rabbit
 		vhost = "/" + vhost
 	}
 
+	components := retryComponents{queueName: queueName}
+
 	// Verificar componentes
 	mainQueue, err := mgmtClient.GetQueue(vhost, queueName)
 	if err == nil {
 		info.MainQueue = true
 		info.MainQueueMsgs = mainQueue.MessagesReady
+		info.Policy = mainQueue.Policy
+		_, info.DLXSource = MainQueueDLX(mainQueue)
+		components.mainQueue = mainQueue
 	}
 
-	waitQueueName := fmt.Sprintf("%s.wait", queueName)
-	waitQueue, err := mgmtClient.GetQueue(vhost, waitQueueName)
-	if err == nil {
-		info.WaitQueue = true
-		info.WaitQueueMsgs = waitQueue.MessagesReady
+	// Tiers: wait queues consecutivas a partir da tentativa 1
+	for attempt := 1; ; attempt++ {
+		waitQueue, err := mgmtClient.GetQueue(vhost, WaitQueueName(queueName, attempt))
+		if err != nil {
+			break
+		}
+		tier := RetryTierInfo{
+			Attempt:   attempt,
+			QueueName: waitQueue.Name,
+			Delay:     argInt(waitQueue.Arguments, "x-message-ttl") / 1000,
+			Messages:  waitQueue.MessagesReady,
+		}
+		info.Tiers = append(info.Tiers, tier)
+		info.WaitQueueMsgs += tier.Messages
+		components.waitQueues = append(components.waitQueues, waitQueue)
 	}
+	info.WaitQueue = len(info.Tiers) > 0
 
-	dlqName := fmt.Sprintf("%s.dlq", queueName)
-	dlq, err := mgmtClient.GetQueue(vhost, dlqName)
+	dlq, err := mgmtClient.GetQueue(vhost, DLQName(queueName))
 	if err == nil {
 		info.DLQ = true
 		info.DLQMsgs = dlq.MessagesReady
+		components.dlq = dlq
+	}
+
+	waitExchange, err := mgmtClient.GetExchange(vhost, WaitExchangeName(queueName))
+	if err == nil {
+		info.WaitExchange = true
+		info.Metadata = ParseMetadata(waitExchange.Arguments)
+		if info.Metadata != nil {
+			info.Backend = info.Metadata.Backend
+		}
+		components.waitExchange = waitExchange
+	}
+
+	firstExchange, err := mgmtClient.GetExchange(vhost, FirstAttemptExchangeName(queueName))
+	if err == nil {
+		info.FirstExchange = true
+		components.firstExchange = firstExchange
+	}
+
+	retryExchange, err := mgmtClient.GetExchange(vhost, RetryExchangeName(queueName))
+	if err == nil {
+		info.RetryExchange = true
+		components.retryExchange = retryExchange
+	}
+
+	// Bindings saindo de cada exchange do retry
+	for _, exchange := range []*rabbitmq.ExchangeInfoManagement{waitExchange, firstExchange, retryExchange} {
+		if exchange == nil {
+			continue
+		}
+		bindings, err := mgmtClient.GetExchangeBindings(vhost, exchange.Name)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar bindings de %s: %w", exchange.Name, err)
+		}
+		components.bindings = append(components.bindings, bindings...)
 	}
 
-	// Verificar exchanges (Management API tem endpoint para exchanges também)
-	// Por enquanto, assumimos que se as filas existem, os exchanges também existem
-	info.WaitExchange = info.WaitQueue
-	info.RetryExchange = info.DLQ || info.WaitQueue
+	// Configuração efetiva: componentes primeiro, metadata como fallback
+	switch {
+	case info.WaitQueue:
+		info.MaxRetries = len(info.Tiers)
+		info.RetryDelay = info.Tiers[0].Delay
+	case info.Metadata != nil:
+		info.MaxRetries = info.Metadata.MaxRetries
+		if len(info.Metadata.Delays) > 0 {
+			info.RetryDelay = info.Metadata.Delays[0]
+		}
+	}
+
+	switch {
+	case info.DLQ:
+		info.DLQTTL = argInt(dlq.Arguments, "x-message-ttl")
+	case info.Metadata != nil:
+		info.DLQTTL = info.Metadata.DLQTTL
+	}
 
-	// TODO: Obter MaxRetries, RetryDelay, DLQTTL dos arguments das filas
-	// Por enquanto, usamos valores padrão
-	info.MaxRetries = 3
-	info.RetryDelay = 5
-	info.DLQTTL = 604800000
+	components.metadata = info.Metadata
+	info.Drift = components.drift()
+	info.Bindings = components.bindingStatus()
 
 	return info, nil
 }
@@ -0,0 +1,40 @@
+package retryname
+
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers usados pelo backend delayed
+const (
+	HeaderDelay   = "x-delay"         // Delay em milissegundos lido pelo plugin
+	HeaderRetries = "x-gohop-retries" // Tentativas que já falharam
+)
+
+// DelayedRetryPublishing monta a mensagem republicada no retry exchange do
+// backend delayed após a falha da tentativa informada (1-based)
+func DelayedRetryPublishing(d amqp.Delivery, attempt int, delay time.Duration) amqp.Publishing {
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[HeaderDelay] = delay.Milliseconds()
+	headers[HeaderRetries] = int64(attempt)
+
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    d.DeliveryMode,
+		Priority:        d.Priority,
+		CorrelationId:   d.CorrelationId,
+		ReplyTo:         d.ReplyTo,
+		Expiration:      d.Expiration,
+		MessageId:       d.MessageId,
+		Timestamp:       d.Timestamp,
+		Type:            d.Type,
+		AppId:           d.AppId,
+		Body:            d.Body,
+	}
+}
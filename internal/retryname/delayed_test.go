@@ -0,0 +1,31 @@
+package retryname
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayedRetryPublishing(t *testing.T) {
+	d := amqp.Delivery{
+		Headers:      amqp.Table{"tenant": "acme", HeaderRetries: int64(1)},
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    "msg-1",
+		Body:         []byte(`{"id":1}`),
+	}
+
+	msg := DelayedRetryPublishing(d, 2, 30*time.Second)
+
+	assert.Equal(t, int64(30000), msg.Headers[HeaderDelay])
+	assert.Equal(t, int64(2), msg.Headers[HeaderRetries])
+	assert.Equal(t, "acme", msg.Headers["tenant"])
+	assert.Equal(t, "msg-1", msg.MessageId)
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+	assert.Equal(t, d.Body, msg.Body)
+
+	// A delivery original não é alterada
+	assert.Equal(t, int64(1), d.Headers[HeaderRetries])
+}
@@ -0,0 +1,90 @@
+// Package retryname define os nomes, routing keys e headers do sistema de
+// retry do gohop. Não depende de configuração nem de clientes do broker, para
+// que pkg/consumer possa usá-lo sem arrastar essas dependências.
+package retryname
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Nomes dos componentes do sistema de retry.
+//
+// Para uma fila "orders" com MaxRetries = 3 a topologia é:
+//
+//	orders                 (fila principal, DLX = orders.wait.exchange)
+//	orders.wait.exchange   (direct, alternate-exchange = orders.wait.first)
+//	orders.wait.first      (fanout, recebe a primeira falha)
+//	orders.wait            (espera da tentativa 1, DLRK = orders.attempt.1)
+//	orders.wait.2          (espera da tentativa 2, DLRK = orders.attempt.2)
+//	orders.wait.3          (espera da tentativa 3, DLRK = orders.attempt.3)
+//	orders.retry           (headers, devolve tudo para a fila principal)
+//	orders.dlq             (destino final após MaxRetries)
+//
+// O número da tentativa viaja na routing key: ao expirar na wait queue N a
+// mensagem recebe a routing key "orders.attempt.N", e quando a fila principal
+// rejeita de novo o wait exchange usa essa chave para escolher a próxima
+// wait queue (ou a DLQ, se N == MaxRetries).
+
+// WaitExchangeName retorna o nome do exchange que recebe as rejeições da fila principal
+func WaitExchangeName(queueName string) string {
+	return fmt.Sprintf("%s.wait.exchange", queueName)
+}
+
+// FirstAttemptExchangeName retorna o nome do alternate exchange da primeira falha
+func FirstAttemptExchangeName(queueName string) string {
+	return fmt.Sprintf("%s.wait.first", queueName)
+}
+
+// WaitQueueName retorna o nome da wait queue de uma tentativa (1-based).
+// A primeira tentativa mantém o nome histórico "<fila>.wait".
+func WaitQueueName(queueName string, attempt int) string {
+	if attempt <= 1 {
+		return fmt.Sprintf("%s.wait", queueName)
+	}
+	return fmt.Sprintf("%s.wait.%d", queueName, attempt)
+}
+
+// AttemptRoutingKey retorna a routing key que marca uma mensagem após a tentativa informada
+func AttemptRoutingKey(queueName string, attempt int) string {
+	return fmt.Sprintf("%s.attempt.%d", queueName, attempt)
+}
+
+// ParseAttemptRoutingKey extrai o número da tentativa de uma routing key "<fila>.attempt.N"
+func ParseAttemptRoutingKey(queueName, routingKey string) (int, bool) {
+	suffix, ok := strings.CutPrefix(routingKey, queueName+".attempt.")
+	if !ok {
+		return 0, false
+	}
+	attempt, err := strconv.Atoi(suffix)
+	if err != nil || attempt <= 0 {
+		return 0, false
+	}
+	return attempt, true
+}
+
+// RetryExchangeName retorna o nome do exchange que devolve mensagens para a fila principal
+func RetryExchangeName(queueName string) string {
+	return fmt.Sprintf("%s.retry", queueName)
+}
+
+// DLQName retorna o nome da Dead Letter Queue
+func DLQName(queueName string) string {
+	return fmt.Sprintf("%s.dlq", queueName)
+}
+
+// IsRetryQueue indica se a fila é um componente do sistema de retry
+// ("<fila>.wait", "<fila>.wait.N" ou "<fila>.dlq")
+func IsRetryQueue(name string) bool {
+	if strings.HasSuffix(name, ".wait") || strings.HasSuffix(name, ".dlq") {
+		return true
+	}
+
+	idx := strings.LastIndex(name, ".wait.")
+	if idx < 0 {
+		return false
+	}
+	_, err := strconv.Atoi(name[idx+len(".wait."):])
+	return err == nil
+}
@@ -0,0 +1,70 @@
+package retryname
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentNameHelpers(t *testing.T) {
+	assert.Equal(t, "orders.wait.exchange", WaitExchangeName("orders"))
+	assert.Equal(t, "orders.wait.first", FirstAttemptExchangeName("orders"))
+	assert.Equal(t, "orders.retry", RetryExchangeName("orders"))
+	assert.Equal(t, "orders.dlq", DLQName("orders"))
+}
+
+func TestWaitQueueName(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected string
+	}{
+		{0, "orders.wait"},
+		{1, "orders.wait"},
+		{2, "orders.wait.2"},
+		{10, "orders.wait.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WaitQueueName("orders", tt.attempt))
+		})
+	}
+}
+
+func TestAttemptRoutingKey(t *testing.T) {
+	assert.Equal(t, "orders.attempt.1", AttemptRoutingKey("orders", 1))
+	assert.Equal(t, "orders.attempt.3", AttemptRoutingKey("orders", 3))
+}
+
+func TestIsRetryQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"orders", false},
+		{"orders.wait", true},
+		{"orders.wait.2", true},
+		{"orders.wait.12", true},
+		{"orders.dlq", true},
+		{"orders.wait.exchange", false},
+		{"orders.waiting", false},
+		{"orders.wait.v2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryQueue(tt.name))
+		})
+	}
+}
+
+func TestParseAttemptRoutingKey(t *testing.T) {
+	attempt, ok := ParseAttemptRoutingKey("orders", "orders.attempt.3")
+	assert.True(t, ok)
+	assert.Equal(t, 3, attempt)
+
+	for _, key := range []string{"orders", "orders.attempt.", "orders.attempt.x", "orders.attempt.0", "payments.attempt.1"} {
+		_, ok := ParseAttemptRoutingKey("orders", key)
+		assert.False(t, ok, key)
+	}
+}
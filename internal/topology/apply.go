@@ -0,0 +1,126 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+)
+
+// Apply executa as mudanças do plano na ordem em que foram calculadas.
+// onChange (opcional) é chamado antes de cada mudança. Filas e retry usam a
+// conexão AMQP de client, que deve estar no mesmo vhost do plano; o resto
+// usa a Management API.
+func Apply(client *rabbitmq.Client, mgmt *rabbitmq.ManagementClient, vhost string, plan *Plan, onChange func(Change)) error {
+	if blocked := plan.Blocked(); len(blocked) > 0 {
+		return fmt.Errorf("%s %s: %s", blocked[0].Kind, blocked[0].Name, blocked[0].Blocked)
+	}
+
+	for _, change := range plan.Changes {
+		if onChange != nil {
+			onChange(change)
+		}
+		if err := applyChange(client, mgmt, vhost, change); err != nil {
+			return fmt.Errorf("%s %s: %w", change.Kind, change.Name, err)
+		}
+	}
+	return nil
+}
+
+func applyChange(client *rabbitmq.Client, mgmt *rabbitmq.ManagementClient, vhost string, change Change) error {
+	switch change.Kind {
+	case KindExchange:
+		if change.Action == ActionRecreate || change.Action == ActionDelete {
+			if err := mgmt.DeleteExchange(vhost, change.Name, false); err != nil {
+				return err
+			}
+		}
+		if change.Action == ActionDelete {
+			return nil
+		}
+		return mgmt.CreateExchange(vhost, change.exchange)
+
+	case KindRetry:
+		return applyRetry(client, mgmt, vhost, change)
+
+	case KindQueue:
+		if change.Action == ActionRecreate || change.Action == ActionDelete {
+			if err := deleteEmptyQueue(mgmt, vhost, change.Name); err != nil {
+				return err
+			}
+		}
+		if change.Action == ActionDelete {
+			return nil
+		}
+		q := change.queue
+		if err := client.CreateQueue(rabbitmq.CreateQueueOptions{
+			Name:       q.Name,
+			Type:       q.queueType(),
+			Durable:    q.durable(),
+			AutoDelete: q.AutoDelete,
+			Arguments:  q.arguments(),
+		}); err != nil {
+			return err
+		}
+		if q.Retry != nil {
+			return retry.BindRetryExchange(client, q.Name)
+		}
+		return nil
+
+	case KindBinding:
+		if change.Action == ActionDelete {
+			return mgmt.DeleteBinding(vhost, change.liveBinding)
+		}
+		return mgmt.CreateBinding(vhost, change.binding)
+
+	case KindPolicy:
+		if change.Action == ActionDelete {
+			return mgmt.DeletePolicy(vhost, change.Name)
+		}
+		return mgmt.PutPolicy(vhost, change.Name, change.policy)
+	}
+
+	return fmt.Errorf("mudança desconhecida")
+}
+
+// applyRetry cria ou reconfigura o sistema de retry com retry.SetupRetry
+func applyRetry(client *rabbitmq.Client, mgmt *rabbitmq.ManagementClient, vhost string, change Change) error {
+	opts := change.retry
+	opts.Force = change.Action == ActionUpdate
+
+	// A DLQ não é recriada pelo SetupRetry: sem isso a redeclaração com
+	// outro TTL seria recusada pelo broker
+	if change.resetDLQ {
+		if err := deleteEmptyQueue(mgmt, vhost, retry.DLQName(change.Name)); err != nil {
+			return err
+		}
+	}
+
+	if err := retry.SetupRetry(client, opts); err != nil {
+		return err
+	}
+
+	// O policy pode existir antes da fila: vale quando ela for criada
+	if change.retryPolicy {
+		if err := mgmt.PutPolicy(vhost, retry.RetryPolicyName(change.Name), retry.RetryPolicy(change.Name)); err != nil {
+			return err
+		}
+	}
+
+	if change.bindMain {
+		return retry.BindRetryExchange(client, change.Name)
+	}
+	return nil
+}
+
+// deleteEmptyQueue remove a fila só se ela estiver vazia (if-empty). O plano
+// bloqueia filas com mensagens, mas elas podem chegar entre o plano e o apply:
+// nesse caso a mudança falha em vez de descartá-las.
+func deleteEmptyQueue(mgmt *rabbitmq.ManagementClient, vhost, name string) error {
+	err := mgmt.DeleteQueueIfEmpty(vhost, name)
+	if errors.Is(err, rabbitmq.ErrQueueNotEmpty) {
+		return fmt.Errorf("a fila %s recebeu mensagens depois do plano; esvazie-a e rode o apply de novo: %w", name, rabbitmq.ErrQueueNotEmpty)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+//go:build integration
+// +build integration
+
+package topology
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	cfg := config.RabbitMQConfig{
+		Host:           "localhost",
+		Port:           5672,
+		ManagementPort: 15672,
+		Username:       "test_user",
+		Password:       "test_pass",
+		VHost:          "/",
+	}
+	client, err := rabbitmq.NewClient(cfg)
+	require.NoError(t, err)
+	defer client.Close()
+	mgmt := rabbitmq.NewManagementClient(cfg)
+
+	suffix := time.Now().Format("20060102150405")
+	queueName := "test_apply_" + suffix
+	exchangeName := "test_apply_events_" + suffix
+
+	spec, err := ParseSpec([]byte(fmt.Sprintf(`
+exchanges:
+  - name: %[2]s
+    type: topic
+queues:
+  - name: %[1]s
+    type: classic
+    retry:
+      max_retries: 2
+      retry_delay: 1
+bindings:
+  - source: %[2]s
+    destination: %[1]s
+    routing_key: orders.*
+`, queueName, exchangeName)))
+	require.NoError(t, err)
+	spec.VHost = "/"
+
+	defer func() {
+		channel := client.GetChannel()
+		channel.QueueDelete(queueName, false, false, false)
+		for attempt := 1; attempt <= 2; attempt++ {
+			channel.QueueDelete(retry.WaitQueueName(queueName, attempt), false, false, false)
+		}
+		channel.QueueDelete(retry.DLQName(queueName), false, false, false)
+		for _, name := range []string{exchangeName, retry.WaitExchangeName(queueName), retry.FirstAttemptExchangeName(queueName), retry.RetryExchangeName(queueName)} {
+			channel.ExchangeDelete(name, false, false)
+		}
+	}()
+
+	live, err := LoadLiveState(mgmt, cfg, spec)
+	require.NoError(t, err)
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, plan.Count(ActionCreate))
+
+	require.NoError(t, Apply(client, mgmt, "/", plan, nil))
+
+	// A Management API atualiza as listagens de forma assíncrona
+	time.Sleep(2 * time.Second)
+
+	info, err := retry.GetRetrySystemInfo(mgmt, cfg, queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.MaxRetries)
+	assert.Empty(t, info.Drift)
+
+	live, err = LoadLiveState(mgmt, cfg, spec)
+	require.NoError(t, err)
+	plan, err = BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "%+v", plan.Changes)
+}
+
+func TestApply_RecreateKeepsMessagesPublishedAfterPlan_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	cfg := config.RabbitMQConfig{
+		Host:           "localhost",
+		Port:           5672,
+		ManagementPort: 15672,
+		Username:       "test_user",
+		Password:       "test_pass",
+		VHost:          "/",
+	}
+	client, err := rabbitmq.NewClient(cfg)
+	require.NoError(t, err)
+	defer client.Close()
+	mgmt := rabbitmq.NewManagementClient(cfg)
+
+	queueName := "test_apply_nonempty_" + time.Now().Format("20060102150405")
+	require.NoError(t, client.CreateQueue(rabbitmq.CreateQueueOptions{Name: queueName, Type: "classic", Durable: true}))
+	defer client.GetChannel().QueueDelete(queueName, false, false, false)
+
+	spec, err := ParseSpec([]byte(fmt.Sprintf("queues:\n  - name: %s\n    type: classic\n    durable: false\n", queueName)))
+	require.NoError(t, err)
+	spec.VHost = "/"
+
+	live, err := LoadLiveState(mgmt, cfg, spec)
+	require.NoError(t, err)
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, plan.Count(ActionRecreate))
+
+	// Mensagem publicada entre o plano e o apply
+	_, err = client.Publish(context.Background(), rabbitmq.PublishOptions{RoutingKey: queueName}, amqp.Publishing{Body: []byte("late")}, nil)
+	require.NoError(t, err)
+
+	err = Apply(client, mgmt, "/", plan, nil)
+	assert.ErrorIs(t, err, rabbitmq.ErrQueueNotEmpty)
+
+	info, err := client.GetQueueInfo(queueName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.Messages, "a fila não é removida com a mensagem")
+}
@@ -0,0 +1,263 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportSpec lê o vhost de cfg pela Management API e monta o spec que o
+// descreve (veja BuildSpec)
+func ExportSpec(mgmt *rabbitmq.ManagementClient, cfg config.RabbitMQConfig) (*Spec, []string, error) {
+	live, err := LoadLiveState(mgmt, cfg, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	vhost := cfg.VHost
+	if vhost == "" {
+		vhost = "/"
+	}
+	spec, warnings := BuildSpec(vhost, live)
+	return spec, warnings, nil
+}
+
+// BuildSpec monta o spec que descreve o estado atual do vhost.
+//
+// Sistemas de retry do gohop (wait queues, wait/first/retry exchanges, DLQ,
+// bindings e policy) viram um bloco retry: na fila principal. Filas
+// exclusivas e recursos do broker (amq.*) ficam de fora; o que o spec não
+// consegue representar é descrito nos avisos retornados.
+func BuildSpec(vhost string, live *LiveState) (*Spec, []string) {
+	spec := &Spec{VHost: vhost}
+	var warnings []string
+
+	// Os blocos retry vêm primeiro para saber quais componentes omitir
+	retrySpecs := map[string]*RetrySpec{}
+	for _, name := range sortedKeys(live.Retry) {
+		info := live.Retry[name]
+		if _, ok := live.Queues[name]; !ok || !info.WaitExchange {
+			continue
+		}
+		retrySpecs[name] = exportRetry(info)
+		spec.Queues = append(spec.Queues, QueueSpec{Name: name, Retry: retrySpecs[name]})
+		if info.DLXSource == "" {
+			warnings = append(warnings, fmt.Sprintf("fila %s: retry sem x-dead-letter-exchange na fila principal; o apply vai recriá-la com o DLX", name))
+		}
+	}
+	managed := spec.retryComponents(live)
+	spec.Queues = nil
+
+	for _, name := range sortedKeys(live.Exchanges) {
+		ex := live.Exchanges[name]
+		if rabbitmq.IsBuiltinExchange(name) || managed[name] {
+			continue
+		}
+		arguments := exportArguments(ex.Arguments)
+		alternate, _ := arguments["alternate-exchange"].(string)
+		delete(arguments, "alternate-exchange")
+		spec.Exchanges = append(spec.Exchanges, ExchangeSpec{
+			Name:              name,
+			Type:              ex.Type,
+			Durable:           falsePtr(ex.Durable),
+			AutoDelete:        ex.AutoDelete,
+			Internal:          ex.Internal,
+			AlternateExchange: alternate,
+			Arguments:         emptyToNil(arguments),
+		})
+	}
+
+	for _, name := range sortedKeys(live.Queues) {
+		q := live.Queues[name]
+		if q.Exclusive || managed[name] {
+			continue
+		}
+		queueType := q.Type
+		if queueType == "" {
+			queueType = "classic"
+		}
+		if queueType != "classic" && queueType != "quorum" {
+			warnings = append(warnings, fmt.Sprintf("fila %s ignorada: tipo %s não é suportado pelo spec", name, queueType))
+			continue
+		}
+
+		arguments := exportArguments(q.Arguments)
+		delete(arguments, "x-queue-type")
+		// O DLX do retry fica implícito no bloco retry:
+		if r := retrySpecs[name]; r != nil && !r.Policy {
+			delete(arguments, "x-dead-letter-exchange")
+		}
+		spec.Queues = append(spec.Queues, QueueSpec{
+			Name:       name,
+			Type:       queueType,
+			Durable:    falsePtr(q.Durable),
+			AutoDelete: q.AutoDelete,
+			Arguments:  emptyToNil(arguments),
+			Retry:      retrySpecs[name],
+		})
+	}
+
+	for _, b := range live.Bindings {
+		if b.Source == "" || managed[b.Source] || managed[b.Destination] {
+			continue
+		}
+		binding := BindingSpec{
+			Source:      b.Source,
+			Destination: b.Destination,
+			RoutingKey:  b.RoutingKey,
+			Arguments:   emptyToNil(exportArguments(b.Arguments)),
+		}
+		if b.DestinationType != rabbitmq.DestinationQueue {
+			binding.DestinationType = b.DestinationType
+		}
+		spec.Bindings = append(spec.Bindings, binding)
+	}
+	sort.SliceStable(spec.Bindings, func(i, j int) bool {
+		a, b := spec.Bindings[i], spec.Bindings[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Destination != b.Destination {
+			return a.Destination < b.Destination
+		}
+		return a.RoutingKey < b.RoutingKey
+	})
+
+	for _, name := range sortedKeys(live.Policies) {
+		if managed[name] {
+			continue
+		}
+		p := live.Policies[name]
+		policy := PolicySpec{
+			Name:       name,
+			Pattern:    p.Pattern,
+			Priority:   p.Priority,
+			Definition: exportArguments(p.Definition),
+		}
+		if p.ApplyTo != "all" {
+			policy.ApplyTo = p.ApplyTo
+		}
+		spec.Policies = append(spec.Policies, policy)
+	}
+
+	return spec, warnings
+}
+
+// exportRetry descreve o sistema de retry com os campos de RetrySpec,
+// omitindo o que é igual ao padrão
+func exportRetry(info *retry.RetrySystemInfo) *RetrySpec {
+	maxRetries := info.MaxRetries
+	spec := &RetrySpec{
+		MaxRetries: &maxRetries,
+		Policy:     info.DLXSource == retry.DLXSourcePolicy,
+	}
+	if info.Backend != retry.BackendTTL {
+		spec.Backend = info.Backend
+	}
+	if info.DLQTTL != defaultDLQTTL {
+		dlqTTL := info.DLQTTL
+		spec.DLQTTL = &dlqTTL
+	}
+
+	delays := liveDelays(info)
+	if len(delays) > maxRetries {
+		delays = delays[:maxRetries]
+	}
+	switch {
+	case len(delays) == 0:
+		// Sem tiers não há delay a descrever
+	case allEqual(delays):
+		if delays[0] != defaultRetryDelay {
+			spec.RetryDelay = delays[0]
+		}
+	default:
+		parts := make([]string, len(delays))
+		for i, d := range delays {
+			parts[i] = retry.FormatDelay(d)
+		}
+		spec.Backoff = strings.Join(parts, ",")
+	}
+
+	return spec
+}
+
+// Marshal serializa o spec em YAML
+func (s *Spec) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s); err != nil {
+		return nil, fmt.Errorf("erro ao gerar YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao gerar YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportArguments copia os argumentos convertendo números inteiros (float64
+// na Management API) em int, para o YAML não sair com 1e+06
+func exportArguments(args map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		result[k] = exportValue(v)
+	}
+	return result
+}
+
+func exportValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+			return int64(value)
+		}
+	case map[string]interface{}:
+		return exportArguments(value)
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = exportValue(item)
+		}
+		return items
+	}
+	return v
+}
+
+func emptyToNil(args map[string]interface{}) map[string]interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// falsePtr retorna nil para o padrão (true), omitindo o campo no YAML
+func falsePtr(b bool) *bool {
+	if b {
+		return nil
+	}
+	return &b
+}
+
+func allEqual(values []int) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withRetryComponents adiciona ao vhost os componentes do retry de orders
+func withRetryComponents(live *LiveState) {
+	for _, name := range []string{"orders.wait.exchange", "orders.wait.first", "orders.retry"} {
+		live.Exchanges[name] = rabbitmq.ExchangeInfoManagement{Name: name, Type: "topic", Durable: true}
+	}
+	for _, name := range []string{"orders.wait", "orders.wait.2", "orders.wait.3", "orders.dlq"} {
+		live.Queues[name] = rabbitmq.QueueInfoManagement{Name: name, Type: "quorum", Durable: true}
+	}
+	live.Bindings = append(live.Bindings,
+		rabbitmq.BindingInfoManagement{Source: "orders.wait.exchange", Destination: "orders.wait", DestinationType: "queue", RoutingKey: "#"},
+		rabbitmq.BindingInfoManagement{Source: "orders.retry", Destination: "orders", DestinationType: "queue", RoutingKey: "orders.attempt.1"},
+	)
+	live.Retry["orders"].DLXSource = retry.DLXSourceArguments
+}
+
+func TestBuildSpec_CollapsesRetry(t *testing.T) {
+	_, live := liveStateFor(t)
+	withRetryComponents(live)
+
+	spec, warnings := BuildSpec("/prod", live)
+	assert.Empty(t, warnings)
+
+	var queues []string
+	for _, q := range spec.Queues {
+		queues = append(queues, q.Name)
+	}
+	assert.Equal(t, []string{"audit", "orders"}, queues)
+	require.Len(t, spec.Exchanges, 1)
+	assert.Equal(t, "orders.unrouted", spec.Exchanges[0].AlternateExchange)
+	assert.Nil(t, spec.Exchanges[0].Arguments)
+	require.Len(t, spec.Bindings, 1)
+	assert.Equal(t, "orders.events", spec.Bindings[0].Source)
+
+	orders := spec.Queues[1]
+	require.NotNil(t, orders.Retry)
+	assert.Equal(t, "5s,30s,5m", orders.Retry.Backoff)
+	assert.Equal(t, 3, *orders.Retry.MaxRetries)
+	assert.Nil(t, orders.Retry.DLQTTL)
+	assert.Equal(t, map[string]interface{}{"x-max-length": int64(1000)}, orders.Arguments)
+	assert.False(t, *spec.Queues[0].Durable)
+}
+
+func TestBuildSpec_RoundTrip(t *testing.T) {
+	_, live := liveStateFor(t)
+	withRetryComponents(live)
+	live.Queues["stream"] = rabbitmq.QueueInfoManagement{Name: "stream", Type: "stream", Durable: true}
+
+	exported, warnings := BuildSpec("/prod", live)
+	assert.Equal(t, []string{"fila stream ignorada: tipo stream não é suportado pelo spec"}, warnings)
+
+	data, err := exported.Marshal()
+	require.NoError(t, err)
+	spec, err := ParseSpec(data)
+	require.NoError(t, err, string(data))
+
+	delete(live.Queues, "stream")
+	plan, err := BuildPlan(spec, live, PlanOptions{Prune: true})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "%s\n%+v", data, plan.Changes)
+}
+
+func TestExportRetry_Defaults(t *testing.T) {
+	r := exportRetry(&retry.RetrySystemInfo{
+		Backend: retry.BackendDelayed, MaxRetries: 2, DLQTTL: 0, DLXSource: retry.DLXSourcePolicy,
+		Metadata: &retry.RetryMetadata{Delays: []int{10, 10}},
+	})
+
+	assert.Equal(t, retry.BackendDelayed, r.Backend)
+	assert.Equal(t, 10, r.RetryDelay)
+	assert.Empty(t, r.Backoff)
+	assert.Equal(t, 0, *r.DLQTTL)
+	assert.True(t, r.Policy)
+}
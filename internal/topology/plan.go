@@ -0,0 +1,712 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+)
+
+// Action é o que o plano faz com um recurso
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionRecreate Action = "recreate" // Propriedade imutável mudou: remove e cria de novo
+	ActionDelete   Action = "delete"   // Só com PlanOptions.Prune
+)
+
+// Kind é o tipo de recurso de uma mudança
+type Kind string
+
+const (
+	KindExchange Kind = "exchange"
+	KindQueue    Kind = "queue"
+	KindRetry    Kind = "retry"
+	KindBinding  Kind = "binding"
+	KindPolicy   Kind = "policy"
+)
+
+// Change é uma mudança do plano
+type Change struct {
+	Kind    Kind     `json:"kind"`
+	Action  Action   `json:"action"`
+	Name    string   `json:"name"`
+	Details []string `json:"details,omitempty"`
+	Blocked string   `json:"blocked,omitempty"` // Motivo pelo qual a mudança não pode ser aplicada
+
+	exchange    rabbitmq.CreateExchangeOptions
+	queue       QueueSpec
+	retry       retry.SetupOptions
+	retryPolicy bool // Retry aplicado via policy
+	bindMain    bool // Ligar a fila principal (já existente) ao retry exchange
+	resetDLQ    bool // Remover a DLQ (vazia) para recriá-la com outro TTL
+	binding     rabbitmq.BindingOptions
+	liveBinding rabbitmq.BindingInfoManagement
+	policy      rabbitmq.Policy
+}
+
+// Plan é a lista ordenada de mudanças para levar o vhost ao spec
+type Plan struct {
+	VHost   string   `json:"vhost"`
+	Changes []Change `json:"changes"`
+}
+
+// Empty indica se o vhost já está de acordo com o spec
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count retorna quantas mudanças têm a ação informada
+func (p *Plan) Count(action Action) int {
+	count := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Blocked retorna as mudanças que impedem a aplicação do plano
+func (p *Plan) Blocked() []Change {
+	var blocked []Change
+	for _, c := range p.Changes {
+		if c.Blocked != "" {
+			blocked = append(blocked, c)
+		}
+	}
+	return blocked
+}
+
+// PlanOptions são opções do cálculo do plano
+type PlanOptions struct {
+	// Prune remove filas, exchanges, bindings e policies que não estão no spec
+	Prune bool
+}
+
+// LiveState é o estado atual do vhost lido pela Management API
+type LiveState struct {
+	Queues    map[string]rabbitmq.QueueInfoManagement
+	Exchanges map[string]rabbitmq.ExchangeInfoManagement
+	Bindings  []rabbitmq.BindingInfoManagement
+	Policies  map[string]rabbitmq.Policy
+	Retry     map[string]*retry.RetrySystemInfo // Por fila com retry (do spec ou detectada)
+}
+
+// LoadLiveState lê filas, exchanges, bindings, policies e os sistemas de
+// retry das filas do spec no vhost de cfg. Sem spec (nil), lê o retry de
+// todas as filas que têm um wait exchange.
+func LoadLiveState(mgmt *rabbitmq.ManagementClient, cfg config.RabbitMQConfig, spec *Spec) (*LiveState, error) {
+	vhost := cfg.VHost
+	live := &LiveState{
+		Queues:    map[string]rabbitmq.QueueInfoManagement{},
+		Exchanges: map[string]rabbitmq.ExchangeInfoManagement{},
+		Policies:  map[string]rabbitmq.Policy{},
+		Retry:     map[string]*retry.RetrySystemInfo{},
+	}
+
+	queues, err := mgmt.ListQueues()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar filas: %w", err)
+	}
+	for _, q := range queues {
+		if normalizeVHost(q.VHost) == normalizeVHost(vhost) {
+			live.Queues[q.Name] = q
+		}
+	}
+
+	exchanges, err := mgmt.ListExchanges(vhost)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar exchanges: %w", err)
+	}
+	for _, ex := range exchanges {
+		live.Exchanges[ex.Name] = ex
+	}
+
+	if live.Bindings, err = mgmt.ListBindings(vhost); err != nil {
+		return nil, fmt.Errorf("erro ao listar bindings: %w", err)
+	}
+
+	policies, err := mgmt.ListPolicies(vhost)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar policies: %w", err)
+	}
+	for _, p := range policies {
+		live.Policies[p.Name] = p
+	}
+
+	var retryQueues []string
+	if spec != nil {
+		for _, q := range spec.Queues {
+			if q.Retry != nil {
+				retryQueues = append(retryQueues, q.Name)
+			}
+		}
+	} else {
+		retryQueues = live.retryCandidates()
+	}
+
+	for _, name := range retryQueues {
+		info, err := retry.GetRetrySystemInfo(mgmt, cfg, name)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler retry de %s: %w", name, err)
+		}
+		live.Retry[name] = info
+	}
+
+	return live, nil
+}
+
+// retryCandidates retorna as filas que têm o wait exchange de um sistema de retry
+func (l *LiveState) retryCandidates() []string {
+	var names []string
+	for name := range l.Queues {
+		if _, ok := l.Exchanges[retry.WaitExchangeName(name)]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuildPlan compara o spec com o estado atual. As mudanças saem na ordem em
+// que devem ser aplicadas: exchanges, retry, filas, bindings e policies;
+// remoções por último.
+func BuildPlan(spec *Spec, live *LiveState, opts PlanOptions) (*Plan, error) {
+	plan := &Plan{VHost: spec.VHost}
+
+	// Exchanges e filas removidos e criados de novo perdem os bindings
+	recreatedExchanges := map[string]bool{}
+	recreatedQueues := map[string]bool{}
+	createdQueues := map[string]bool{}
+
+	for _, ex := range spec.Exchanges {
+		want := ex.options()
+		current, ok := live.Exchanges[ex.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:     KindExchange,
+				Action:   ActionCreate,
+				Name:     ex.Name,
+				Details:  []string{fmt.Sprintf("tipo %s", want.Type)},
+				exchange: want,
+			})
+			continue
+		}
+		if diff := exchangeDiff(want, current); len(diff) > 0 {
+			recreatedExchanges[ex.Name] = true
+			plan.Changes = append(plan.Changes, Change{
+				Kind:     KindExchange,
+				Action:   ActionRecreate,
+				Name:     ex.Name,
+				Details:  append(diff, lostBindings(spec, live, ex.Name)...),
+				exchange: want,
+			})
+		}
+	}
+
+	var queueChanges []Change
+	for _, q := range spec.Queues {
+		current, ok := live.Queues[q.Name]
+		if !ok {
+			createdQueues[q.Name] = true
+			queueChanges = append(queueChanges, Change{
+				Kind:    KindQueue,
+				Action:  ActionCreate,
+				Name:    q.Name,
+				Details: []string{fmt.Sprintf("tipo %s", q.queueType())},
+				queue:   q,
+			})
+			continue
+		}
+		if diff := queueDiff(q, current); len(diff) > 0 {
+			recreatedQueues[q.Name] = true
+			change := Change{
+				Kind:    KindQueue,
+				Action:  ActionRecreate,
+				Name:    q.Name,
+				Details: append(diff, lostBindings(spec, live, q.Name)...),
+				queue:   q,
+			}
+			if current.Messages > 0 {
+				change.Blocked = fmt.Sprintf("a fila tem %d mensagem(ns) que seriam perdidas; esvazie-a antes (ex: gohop queue export %s --remove)", current.Messages, q.Name)
+				if q.Retry != nil && !q.Retry.Policy {
+					change.Blocked += " ou use policy: true no retry"
+				}
+			}
+			queueChanges = append(queueChanges, change)
+		}
+	}
+
+	for _, q := range spec.Queues {
+		if q.Retry == nil {
+			continue
+		}
+		if change := retryChange(q, live, createdQueues[q.Name] || recreatedQueues[q.Name]); change != nil {
+			plan.Changes = append(plan.Changes, *change)
+		}
+	}
+	plan.Changes = append(plan.Changes, queueChanges...)
+
+	for _, b := range spec.Bindings {
+		want := b.options()
+		if err := checkBindingEnds(spec, live, want); err != nil {
+			return nil, err
+		}
+		destinationRecreated := recreatedQueues[want.Destination]
+		if want.DestinationType == rabbitmq.DestinationExchange {
+			destinationRecreated = recreatedExchanges[want.Destination]
+		}
+		if !recreatedExchanges[want.Source] && !destinationRecreated && findBinding(live.Bindings, want) != nil {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Kind:    KindBinding,
+			Action:  ActionCreate,
+			Name:    bindingName(want.Source, want.Destination, want.RoutingKey),
+			Details: bindingDetails(want.DestinationType, want.Arguments),
+			binding: want,
+		})
+	}
+
+	for _, p := range spec.Policies {
+		want := p.policy()
+		current, ok := live.Policies[p.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:    KindPolicy,
+				Action:  ActionCreate,
+				Name:    p.Name,
+				Details: []string{fmt.Sprintf("pattern %s (%s)", want.Pattern, want.ApplyTo)},
+				policy:  want,
+			})
+			continue
+		}
+		if diff := policyDiff(want, current); len(diff) > 0 {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:    KindPolicy,
+				Action:  ActionUpdate,
+				Name:    p.Name,
+				Details: diff,
+				policy:  want,
+			})
+		}
+	}
+
+	if opts.Prune {
+		plan.Changes = append(plan.Changes, pruneChanges(spec, live)...)
+	}
+
+	return plan, nil
+}
+
+// exchangeDiff lista as diferenças entre o exchange declarado e o atual
+func exchangeDiff(want rabbitmq.CreateExchangeOptions, current rabbitmq.ExchangeInfoManagement) []string {
+	var diff []string
+	if current.Type != want.Type {
+		diff = append(diff, fmt.Sprintf("tipo: %s → %s", current.Type, want.Type))
+	}
+	diff = append(diff, boolDiff("durable", current.Durable, want.Durable)...)
+	diff = append(diff, boolDiff("auto_delete", current.AutoDelete, want.AutoDelete)...)
+	diff = append(diff, boolDiff("internal", current.Internal, want.Internal)...)
+	return append(diff, argumentsDiff(current.Arguments, want.EffectiveArguments())...)
+}
+
+// queueDiff lista as diferenças entre a fila declarada e a atual. Todas as
+// propriedades de uma fila são imutáveis: qualquer diferença força recriação.
+func queueDiff(q QueueSpec, current rabbitmq.QueueInfoManagement) []string {
+	var diff []string
+
+	currentType := current.Type
+	if currentType == "" {
+		currentType = "classic"
+	}
+	if currentType != q.queueType() {
+		diff = append(diff, fmt.Sprintf("tipo: %s → %s", currentType, q.queueType()))
+	}
+	diff = append(diff, boolDiff("durable", current.Durable, q.durable())...)
+	diff = append(diff, boolDiff("auto_delete", current.AutoDelete, q.AutoDelete)...)
+
+	currentArgs := make(map[string]interface{}, len(current.Arguments))
+	for k, v := range current.Arguments {
+		if k != "x-queue-type" {
+			currentArgs[k] = v
+		}
+	}
+	return append(diff, argumentsDiff(currentArgs, q.arguments())...)
+}
+
+// policyDiff lista as diferenças entre o policy declarado e o atual
+func policyDiff(want, current rabbitmq.Policy) []string {
+	var diff []string
+	if current.Pattern != want.Pattern {
+		diff = append(diff, fmt.Sprintf("pattern: %s → %s", current.Pattern, want.Pattern))
+	}
+	if current.ApplyTo != want.ApplyTo {
+		diff = append(diff, fmt.Sprintf("apply_to: %s → %s", current.ApplyTo, want.ApplyTo))
+	}
+	if current.Priority != want.Priority {
+		diff = append(diff, fmt.Sprintf("priority: %d → %d", current.Priority, want.Priority))
+	}
+	for _, line := range argumentsDiff(current.Definition, want.Definition) {
+		diff = append(diff, strings.Replace(line, "argumento", "definição", 1))
+	}
+	return diff
+}
+
+// retryChange compara o retry declarado com os componentes atuais
+func retryChange(q QueueSpec, live *LiveState, mainQueueChanging bool) *Change {
+	opts, _ := q.Retry.setupOptions(q) // Validado em Spec.Validate
+	info := live.Retry[q.Name]
+	delays := opts.TierDelays()
+
+	change := &Change{
+		Kind:        KindRetry,
+		Name:        q.Name,
+		retry:       opts,
+		retryPolicy: q.Retry.Policy,
+	}
+	_, mainExists := live.Queues[q.Name]
+	change.bindMain = mainExists && !mainQueueChanging
+
+	if info == nil || !(info.WaitExchange || info.RetryExchange || info.DLQ || info.WaitQueue) {
+		change.Action = ActionCreate
+		change.Details = []string{fmt.Sprintf("%d tentativa(s) (%s), backend %s, DLQ %s",
+			opts.MaxRetries, formatDelays(delays), opts.Backend, retry.DLQName(q.Name))}
+		if q.Retry.Policy {
+			change.Details = append(change.Details, fmt.Sprintf("policy %s", retry.RetryPolicyName(q.Name)))
+		}
+		return change
+	}
+
+	var diff []string
+	if info.Backend != opts.Backend {
+		diff = append(diff, fmt.Sprintf("backend: %s → %s", info.Backend, opts.Backend))
+	}
+	if info.MaxRetries != opts.MaxRetries {
+		diff = append(diff, fmt.Sprintf("tentativas: %d → %d", info.MaxRetries, opts.MaxRetries))
+	}
+	if current := liveDelays(info); formatDelays(current) != formatDelays(delays) {
+		diff = append(diff, fmt.Sprintf("delays: %s → %s", formatDelays(current), formatDelays(delays)))
+	}
+	if info.DLQ && info.DLQTTL != opts.DLQTTL {
+		diff = append(diff, fmt.Sprintf("dlq_ttl: %dms → %dms", info.DLQTTL, opts.DLQTTL))
+		change.resetDLQ = true
+		if info.DLQMsgs > 0 {
+			change.Blocked = fmt.Sprintf("a DLQ %s tem %d mensagem(ns) e precisa ser recriada para mudar o TTL; esvazie-a antes (ex: gohop dlq replay %s)",
+				retry.DLQName(q.Name), info.DLQMsgs, q.Name)
+		}
+	}
+
+	if q.Retry.Policy {
+		policy, ok := live.Policies[retry.RetryPolicyName(q.Name)]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("policy %s ausente", retry.RetryPolicyName(q.Name)))
+		} else if len(policyDiff(retry.RetryPolicy(q.Name), policy)) > 0 {
+			diff = append(diff, fmt.Sprintf("policy %s divergente", retry.RetryPolicyName(q.Name)))
+		}
+	}
+
+	// O DLX e o tipo da fila principal são tratados pela mudança da fila;
+	// o bind da fila principal também, quando ela vai ser (re)criada
+	mainBind := fmt.Sprintf("bind ausente: %s → %s ", retry.RetryExchangeName(q.Name), q.Name)
+	for _, line := range info.Drift {
+		if strings.HasPrefix(line, "fila principal") || (mainQueueChanging && strings.HasPrefix(line, mainBind)) {
+			continue
+		}
+		diff = append(diff, "divergência: "+line)
+	}
+
+	if len(diff) == 0 {
+		return nil
+	}
+	change.Action = ActionUpdate
+	change.Details = diff
+	return change
+}
+
+// liveDelays retorna os delays atuais: TTL das wait queues ou, no backend
+// delayed, os persistidos na metadata
+func liveDelays(info *retry.RetrySystemInfo) []int {
+	if len(info.Tiers) > 0 {
+		delays := make([]int, len(info.Tiers))
+		for i, tier := range info.Tiers {
+			delays[i] = tier.Delay
+		}
+		return delays
+	}
+	if info.Metadata != nil {
+		return info.Metadata.Delays
+	}
+	return nil
+}
+
+// checkBindingEnds verifica se a origem e o destino do binding existem no
+// spec ou no vhost
+func checkBindingEnds(spec *Spec, live *LiveState, b rabbitmq.BindingOptions) error {
+	if !spec.hasExchange(b.Source) {
+		if _, ok := live.Exchanges[b.Source]; !ok {
+			return fmt.Errorf("binding %s -> %s: exchange de origem '%s' não existe no spec nem no vhost", b.Source, b.Destination, b.Source)
+		}
+	}
+
+	if b.DestinationType == rabbitmq.DestinationExchange {
+		if _, ok := live.Exchanges[b.Destination]; !ok && !spec.hasExchange(b.Destination) {
+			return fmt.Errorf("binding %s -> %s: exchange de destino '%s' não existe no spec nem no vhost", b.Source, b.Destination, b.Destination)
+		}
+		return nil
+	}
+
+	if _, ok := live.Queues[b.Destination]; !ok && !spec.hasQueue(b.Destination) {
+		return fmt.Errorf("binding %s -> %s: fila de destino '%s' não existe no spec nem no vhost", b.Source, b.Destination, b.Destination)
+	}
+	return nil
+}
+
+// findBinding procura um binding atual igual ao declarado
+func findBinding(bindings []rabbitmq.BindingInfoManagement, want rabbitmq.BindingOptions) *rabbitmq.BindingInfoManagement {
+	for i := range bindings {
+		if want.Matches(bindings[i]) {
+			return &bindings[i]
+		}
+	}
+	return nil
+}
+
+// lostBindings descreve os bindings do recurso que não estão no spec e
+// desaparecem quando ele é recriado
+func lostBindings(spec *Spec, live *LiveState, name string) []string {
+	lost := 0
+	for _, b := range live.Bindings {
+		if b.Source == "" || (b.Source != name && b.Destination != name) {
+			continue
+		}
+		if !spec.declaresBinding(b) {
+			lost++
+		}
+	}
+	if lost == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%d binding(s) fora do spec serão perdidos", lost)}
+}
+
+// pruneChanges remove o que existe no vhost e não está no spec. Ficam de
+// fora os recursos do broker (amq.*, default exchange), filas exclusivas e
+// os componentes de retry das filas do spec.
+func pruneChanges(spec *Spec, live *LiveState) []Change {
+	managed := spec.retryComponents(live)
+	var changes []Change
+
+	deletedQueues := map[string]bool{}
+	var queueNames []string
+	for name, q := range live.Queues {
+		if !spec.hasQueue(name) && !managed[name] && !q.Exclusive {
+			queueNames = append(queueNames, name)
+			deletedQueues[name] = true
+		}
+	}
+
+	deletedExchanges := map[string]bool{}
+	var exchangeNames []string
+	for name := range live.Exchanges {
+		if !rabbitmq.IsBuiltinExchange(name) && !spec.hasExchange(name) && !managed[name] {
+			exchangeNames = append(exchangeNames, name)
+			deletedExchanges[name] = true
+		}
+	}
+
+	// Bindings de recursos removidos somem junto com eles
+	for _, b := range live.Bindings {
+		if b.Source == "" || spec.declaresBinding(b) || managed[b.Source] || managed[b.Destination] {
+			continue
+		}
+		if deletedExchanges[b.Source] || deletedExchanges[b.Destination] || deletedQueues[b.Destination] {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:        KindBinding,
+			Action:      ActionDelete,
+			Name:        bindingName(b.Source, b.Destination, b.RoutingKey),
+			Details:     bindingDetails(b.DestinationType, b.Arguments),
+			liveBinding: b,
+		})
+	}
+
+	sort.Strings(queueNames)
+	for _, name := range queueNames {
+		var details []string
+		if messages := live.Queues[name].Messages; messages > 0 {
+			details = append(details, fmt.Sprintf("%d mensagem(ns) serão perdidas", messages))
+		}
+		changes = append(changes, Change{Kind: KindQueue, Action: ActionDelete, Name: name, Details: details})
+	}
+
+	sort.Strings(exchangeNames)
+	for _, name := range exchangeNames {
+		changes = append(changes, Change{Kind: KindExchange, Action: ActionDelete, Name: name})
+	}
+
+	var policyNames []string
+	for name := range live.Policies {
+		if !spec.hasPolicy(name) && !managed[name] {
+			policyNames = append(policyNames, name)
+		}
+	}
+	sort.Strings(policyNames)
+	for _, name := range policyNames {
+		changes = append(changes, Change{Kind: KindPolicy, Action: ActionDelete, Name: name})
+	}
+
+	return changes
+}
+
+// retryComponents retorna os nomes de filas, exchanges e policies criados
+// pelo retry das filas do spec
+func (s *Spec) retryComponents(live *LiveState) map[string]bool {
+	managed := map[string]bool{}
+	for _, q := range s.Queues {
+		if q.Retry == nil {
+			continue
+		}
+		managed[retry.WaitExchangeName(q.Name)] = true
+		managed[retry.FirstAttemptExchangeName(q.Name)] = true
+		managed[retry.RetryExchangeName(q.Name)] = true
+		managed[retry.DLQName(q.Name)] = true
+		managed[retry.RetryPolicyName(q.Name)] = true
+		managed[retry.WaitQueueName(q.Name, 1)] = true
+		for name := range live.Queues {
+			if suffix, ok := strings.CutPrefix(name, q.Name+".wait."); ok {
+				if _, err := strconv.Atoi(suffix); err == nil {
+					managed[name] = true
+				}
+			}
+		}
+	}
+	return managed
+}
+
+func (s *Spec) hasExchange(name string) bool {
+	for _, ex := range s.Exchanges {
+		if ex.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Spec) hasQueue(name string) bool {
+	for _, q := range s.Queues {
+		if q.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Spec) hasPolicy(name string) bool {
+	for _, p := range s.Policies {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// declaresBinding indica se o binding atual está declarado no spec
+func (s *Spec) declaresBinding(b rabbitmq.BindingInfoManagement) bool {
+	for _, spec := range s.Bindings {
+		if spec.options().Matches(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// argumentsDiff lista os argumentos adicionados, removidos e alterados
+func argumentsDiff(current, want map[string]interface{}) []string {
+	keys := map[string]bool{}
+	for k := range current {
+		keys[k] = true
+	}
+	for k := range want {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diff []string
+	for _, k := range sorted {
+		old, hasOld := current[k]
+		value, hasNew := want[k]
+		switch {
+		case !hasOld:
+			diff = append(diff, fmt.Sprintf("argumento %s: (ausente) → %s", k, rabbitmq.ArgumentString(value)))
+		case !hasNew:
+			diff = append(diff, fmt.Sprintf("argumento %s: %s → (removido)", k, rabbitmq.ArgumentString(old)))
+		case rabbitmq.ArgumentString(old) != rabbitmq.ArgumentString(value):
+			diff = append(diff, fmt.Sprintf("argumento %s: %s → %s", k, rabbitmq.ArgumentString(old), rabbitmq.ArgumentString(value)))
+		}
+	}
+	return diff
+}
+
+func boolDiff(name string, current, want bool) []string {
+	if current == want {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %v → %v", name, current, want)}
+}
+
+// bindingName identifica um binding no plano
+func bindingName(source, destination, routingKey string) string {
+	if routingKey == "" {
+		return fmt.Sprintf("%s → %s", source, destination)
+	}
+	return fmt.Sprintf("%s → %s (%s)", source, destination, routingKey)
+}
+
+func bindingDetails(destinationType string, arguments map[string]interface{}) []string {
+	details := []string{fmt.Sprintf("destino: %s", destinationType)}
+	if len(arguments) > 0 {
+		keys := make([]string, 0, len(arguments))
+		for k := range arguments {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprintf("%s=%s", k, rabbitmq.ArgumentString(arguments[k]))
+		}
+		details = append(details, "argumentos: "+strings.Join(parts, ", "))
+	}
+	return details
+}
+
+func formatDelays(delays []int) string {
+	if len(delays) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(delays))
+	for i, d := range delays {
+		parts[i] = retry.FormatDelay(d)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// normalizeVHost coloca o vhost na forma devolvida pela API ("/" ou "nome")
+func normalizeVHost(vhost string) string {
+	if vhost == "" || vhost == "/" {
+		return "/"
+	}
+	return strings.TrimPrefix(vhost, "/")
+}
@@ -0,0 +1,210 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func emptyLiveState() *LiveState {
+	return &LiveState{
+		Queues: map[string]rabbitmq.QueueInfoManagement{},
+		Exchanges: map[string]rabbitmq.ExchangeInfoManagement{
+			"":          {Name: "", Type: "direct", Durable: true},
+			"amq.topic": {Name: "amq.topic", Type: "topic", Durable: true},
+		},
+		Policies: map[string]rabbitmq.Policy{},
+		Retry:    map[string]*retry.RetrySystemInfo{},
+	}
+}
+
+// liveStateFor simula o vhost já de acordo com sampleSpec (como a API devolve)
+func liveStateFor(t *testing.T) (*Spec, *LiveState) {
+	spec, err := ParseSpec([]byte(sampleSpec))
+	require.NoError(t, err)
+
+	live := emptyLiveState()
+	live.Exchanges["orders.events"] = rabbitmq.ExchangeInfoManagement{
+		Name: "orders.events", Type: "topic", Durable: true,
+		Arguments: map[string]interface{}{"alternate-exchange": "orders.unrouted"},
+	}
+	live.Queues["orders"] = rabbitmq.QueueInfoManagement{
+		Name: "orders", Type: "quorum", Durable: true,
+		Arguments: map[string]interface{}{
+			"x-queue-type":           "quorum",
+			"x-max-length":           float64(1000),
+			"x-dead-letter-exchange": "orders.wait.exchange",
+		},
+	}
+	live.Queues["audit"] = rabbitmq.QueueInfoManagement{Name: "audit", Type: "classic", Arguments: map[string]interface{}{"x-queue-type": "classic"}}
+	live.Bindings = []rabbitmq.BindingInfoManagement{
+		{Source: "", Destination: "orders", DestinationType: "queue", RoutingKey: "orders"},
+		{Source: "orders.events", Destination: "orders", DestinationType: "queue", RoutingKey: "orders.*", Arguments: map[string]interface{}{}},
+	}
+	live.Policies["orders-limit"] = rabbitmq.Policy{
+		Name: "orders-limit", Pattern: "^orders$", ApplyTo: "queues",
+		Definition: map[string]interface{}{"max-length": float64(100000)},
+	}
+	live.Retry["orders"] = &retry.RetrySystemInfo{
+		QueueName: "orders", MainQueue: true, WaitQueue: true, WaitExchange: true, FirstExchange: true, RetryExchange: true, DLQ: true,
+		Backend: retry.BackendTTL, MaxRetries: 3, RetryDelay: 5, DLQTTL: defaultDLQTTL,
+		Tiers: []retry.RetryTierInfo{{Attempt: 1, Delay: 5}, {Attempt: 2, Delay: 30}, {Attempt: 3, Delay: 300}},
+	}
+	return spec, live
+}
+
+func TestBuildPlan_CreatesEverythingInOrder(t *testing.T) {
+	spec, err := ParseSpec([]byte(sampleSpec))
+	require.NoError(t, err)
+
+	plan, err := BuildPlan(spec, emptyLiveState(), PlanOptions{})
+	require.NoError(t, err)
+
+	var kinds []Kind
+	for _, c := range plan.Changes {
+		assert.Equal(t, ActionCreate, c.Action, c.Name)
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []Kind{KindExchange, KindRetry, KindQueue, KindQueue, KindBinding, KindPolicy}, kinds)
+	assert.False(t, plan.Changes[1].bindMain, "a fila principal é ligada ao retry quando for criada")
+}
+
+func TestBuildPlan_NoChanges(t *testing.T) {
+	spec, live := liveStateFor(t)
+
+	plan, err := BuildPlan(spec, live, PlanOptions{Prune: true})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "%+v", plan.Changes)
+}
+
+func TestBuildPlan_QueueRecreate(t *testing.T) {
+	spec, live := liveStateFor(t)
+	audit := live.Queues["audit"]
+	audit.Durable = true
+	live.Queues["audit"] = audit
+
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionRecreate, plan.Changes[0].Action)
+	assert.Contains(t, plan.Changes[0].Details, "durable: true → false")
+	assert.Empty(t, plan.Blocked())
+
+	// Com mensagens a recriação é bloqueada
+	audit.Messages = 10
+	live.Queues["audit"] = audit
+	plan, err = BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Blocked(), 1)
+	assert.Contains(t, plan.Blocked()[0].Blocked, "10 mensagem(ns)")
+}
+
+func TestBuildPlan_ExchangeRecreateRebindsQueues(t *testing.T) {
+	spec, live := liveStateFor(t)
+	live.Exchanges["orders.events"] = rabbitmq.ExchangeInfoManagement{Name: "orders.events", Type: "direct", Durable: true}
+
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, ActionRecreate, plan.Changes[0].Action)
+	assert.Contains(t, plan.Changes[0].Details, "tipo: direct → topic")
+	assert.Contains(t, plan.Changes[0].Details, "argumento alternate-exchange: (ausente) → orders.unrouted")
+	assert.Equal(t, KindBinding, plan.Changes[1].Kind)
+	assert.Equal(t, ActionCreate, plan.Changes[1].Action)
+}
+
+func TestBuildPlan_RetryUpdate(t *testing.T) {
+	spec, live := liveStateFor(t)
+	live.Retry["orders"].Tiers[2].Delay = 60
+	live.Retry["orders"].DLQTTL = 0
+	live.Retry["orders"].DLQMsgs = 2
+
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+
+	change := plan.Changes[0]
+	assert.Equal(t, KindRetry, change.Kind)
+	assert.Equal(t, ActionUpdate, change.Action)
+	assert.Contains(t, change.Details, "delays: 5s, 30s, 1m → 5s, 30s, 5m")
+	assert.True(t, change.resetDLQ)
+	assert.True(t, change.bindMain)
+	assert.Contains(t, change.Blocked, "DLQ orders.dlq tem 2 mensagem(ns)")
+}
+
+func TestBuildPlan_PolicyUpdate(t *testing.T) {
+	spec, live := liveStateFor(t)
+	policy := live.Policies["orders-limit"]
+	policy.Definition = map[string]interface{}{"max-length": float64(500)}
+	live.Policies["orders-limit"] = policy
+
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionUpdate, plan.Changes[0].Action)
+	assert.Equal(t, []string{"definição max-length: 500 → 100000"}, plan.Changes[0].Details)
+}
+
+func TestBuildPlan_Prune(t *testing.T) {
+	spec, live := liveStateFor(t)
+	// Componentes de retry das filas do spec não são removidos
+	live.Queues["orders.wait"] = rabbitmq.QueueInfoManagement{Name: "orders.wait"}
+	live.Queues["orders.wait.2"] = rabbitmq.QueueInfoManagement{Name: "orders.wait.2"}
+	live.Queues["orders.dlq"] = rabbitmq.QueueInfoManagement{Name: "orders.dlq"}
+	live.Exchanges["orders.retry"] = rabbitmq.ExchangeInfoManagement{Name: "orders.retry", Type: "headers"}
+	live.Bindings = append(live.Bindings, rabbitmq.BindingInfoManagement{Source: "orders.retry", Destination: "orders", DestinationType: "queue"})
+	live.Policies[retry.RetryPolicyName("orders")] = rabbitmq.Policy{Name: retry.RetryPolicyName("orders")}
+	// Fila exclusiva (ex: queue tail) também fica
+	live.Queues["amq.gen-123"] = rabbitmq.QueueInfoManagement{Name: "amq.gen-123", Exclusive: true}
+
+	// Fora do spec
+	live.Queues["legacy"] = rabbitmq.QueueInfoManagement{Name: "legacy", Messages: 3}
+	live.Exchanges["legacy.events"] = rabbitmq.ExchangeInfoManagement{Name: "legacy.events", Type: "fanout"}
+	live.Bindings = append(live.Bindings,
+		rabbitmq.BindingInfoManagement{Source: "legacy.events", Destination: "orders", DestinationType: "queue"},
+		rabbitmq.BindingInfoManagement{Source: "orders.events", Destination: "orders", DestinationType: "queue", RoutingKey: "old.*"},
+	)
+	live.Policies["legacy"] = rabbitmq.Policy{Name: "legacy"}
+
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "sem --prune nada é removido")
+
+	plan, err = BuildPlan(spec, live, PlanOptions{Prune: true})
+	require.NoError(t, err)
+
+	var deleted []string
+	for _, c := range plan.Changes {
+		assert.Equal(t, ActionDelete, c.Action)
+		deleted = append(deleted, string(c.Kind)+":"+c.Name)
+	}
+	assert.Equal(t, []string{
+		"binding:orders.events → orders (old.*)",
+		"queue:legacy",
+		"exchange:legacy.events",
+		"policy:legacy",
+	}, deleted, "o binding de legacy.events some junto com o exchange")
+	assert.Equal(t, []string{"3 mensagem(ns) serão perdidas"}, plan.Changes[1].Details)
+}
+
+func TestBuildPlan_UnknownBindingEnd(t *testing.T) {
+	spec, err := ParseSpec([]byte("bindings:\n  - source: missing\n    destination: orders\n"))
+	require.NoError(t, err)
+
+	live := emptyLiveState()
+	live.Queues["orders"] = rabbitmq.QueueInfoManagement{Name: "orders"}
+
+	_, err = BuildPlan(spec, live, PlanOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exchange de origem 'missing'")
+
+	// Exchanges do broker existem no vhost
+	spec, err = ParseSpec([]byte("bindings:\n  - source: amq.topic\n    destination: orders\n"))
+	require.NoError(t, err)
+	plan, err := BuildPlan(spec, live, PlanOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(ActionCreate))
+}
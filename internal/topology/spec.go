@@ -0,0 +1,301 @@
+package topology
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"gopkg.in/yaml.v3"
+)
+
+// Padrões do spec (os mesmos das flags de 'queue create' e 'retry setup')
+const (
+	defaultQueueType    = "quorum"
+	defaultExchangeType = "direct"
+	defaultMaxRetries   = 3
+	defaultRetryDelay   = 5
+	defaultDLQTTL       = 604800000
+)
+
+// Spec é a topologia declarada de um vhost
+type Spec struct {
+	VHost     string         `yaml:"vhost,omitempty"` // Vazio = vhost do perfil
+	Exchanges []ExchangeSpec `yaml:"exchanges,omitempty"`
+	Queues    []QueueSpec    `yaml:"queues,omitempty"`
+	Bindings  []BindingSpec  `yaml:"bindings,omitempty"`
+	Policies  []PolicySpec   `yaml:"policies,omitempty"`
+}
+
+// ExchangeSpec declara um exchange
+type ExchangeSpec struct {
+	Name              string                 `yaml:"name"`
+	Type              string                 `yaml:"type,omitempty"`    // Padrão: direct
+	Durable           *bool                  `yaml:"durable,omitempty"` // Padrão: true
+	AutoDelete        bool                   `yaml:"auto_delete,omitempty"`
+	Internal          bool                   `yaml:"internal,omitempty"`
+	AlternateExchange string                 `yaml:"alternate_exchange,omitempty"`
+	Arguments         map[string]interface{} `yaml:"arguments,omitempty"`
+}
+
+// QueueSpec declara uma fila e, opcionalmente, o seu sistema de retry
+type QueueSpec struct {
+	Name       string                 `yaml:"name"`
+	Type       string                 `yaml:"type,omitempty"`    // classic ou quorum (padrão: quorum)
+	Durable    *bool                  `yaml:"durable,omitempty"` // Padrão: true
+	AutoDelete bool                   `yaml:"auto_delete,omitempty"`
+	Arguments  map[string]interface{} `yaml:"arguments,omitempty"`
+	Retry      *RetrySpec             `yaml:"retry,omitempty"`
+}
+
+// RetrySpec declara o sistema de retry de uma fila; os campos seguem as
+// flags de 'gohop retry setup'
+type RetrySpec struct {
+	MaxRetries        *int    `yaml:"max_retries,omitempty"` // Padrão: 3 (ou um por tier do backoff)
+	RetryDelay        int     `yaml:"retry_delay,omitempty"` // Segundos (padrão: 5)
+	Backoff           string  `yaml:"backoff,omitempty"`     // Ex: 5s,30s,5m,30m
+	BackoffMultiplier float64 `yaml:"backoff_multiplier,omitempty"`
+	MaxRetryDelay     int     `yaml:"max_retry_delay,omitempty"` // Segundos
+	DLQTTL            *int    `yaml:"dlq_ttl,omitempty"`         // Milissegundos (padrão: 7 dias, 0 = sem expiração)
+	Backend           string  `yaml:"backend,omitempty"`         // ttl (padrão) ou delayed
+	Policy            bool    `yaml:"policy,omitempty"`          // DLX via policy em vez de argumento da fila
+}
+
+// BindingSpec declara um binding de um exchange para uma fila ou exchange
+type BindingSpec struct {
+	Source          string                 `yaml:"source"`
+	Destination     string                 `yaml:"destination"`
+	DestinationType string                 `yaml:"destination_type,omitempty"` // queue (padrão) ou exchange
+	RoutingKey      string                 `yaml:"routing_key,omitempty"`
+	Arguments       map[string]interface{} `yaml:"arguments,omitempty"`
+}
+
+// PolicySpec declara um policy
+type PolicySpec struct {
+	Name       string                 `yaml:"name"`
+	Pattern    string                 `yaml:"pattern"`
+	ApplyTo    string                 `yaml:"apply_to,omitempty"` // queues, exchanges ou all (padrão)
+	Priority   int                    `yaml:"priority,omitempty"`
+	Definition map[string]interface{} `yaml:"definition"`
+}
+
+// LoadSpec lê e valida um spec YAML
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec interpreta e valida um spec YAML; campos desconhecidos são erro
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("spec inválido: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate verifica nomes duplicados e valores inválidos
+func (s *Spec) Validate() error {
+	exchanges := map[string]bool{}
+	for _, ex := range s.Exchanges {
+		if ex.Name == "" {
+			return fmt.Errorf("exchange sem nome")
+		}
+		if rabbitmq.IsBuiltinExchange(ex.Name) {
+			return fmt.Errorf("exchange '%s': o prefixo amq. é reservado", ex.Name)
+		}
+		if exchanges[ex.Name] {
+			return fmt.Errorf("exchange '%s' declarado mais de uma vez", ex.Name)
+		}
+		exchanges[ex.Name] = true
+	}
+
+	queues := map[string]bool{}
+	for _, q := range s.Queues {
+		if q.Name == "" {
+			return fmt.Errorf("fila sem nome")
+		}
+		if queues[q.Name] {
+			return fmt.Errorf("fila '%s' declarada mais de uma vez", q.Name)
+		}
+		queues[q.Name] = true
+
+		if q.Type != "" && q.Type != "classic" && q.Type != "quorum" {
+			return fmt.Errorf("fila '%s': tipo inválido %s (use classic ou quorum)", q.Name, q.Type)
+		}
+		if q.Retry != nil {
+			if _, err := q.Retry.setupOptions(q); err != nil {
+				return fmt.Errorf("fila '%s': %w", q.Name, err)
+			}
+		}
+	}
+
+	for _, b := range s.Bindings {
+		if err := b.options().Validate(); err != nil {
+			return fmt.Errorf("binding %s -> %s: %w", b.Source, b.Destination, err)
+		}
+	}
+
+	policies := map[string]bool{}
+	for _, p := range s.Policies {
+		if p.Name == "" || p.Pattern == "" {
+			return fmt.Errorf("policy sem nome ou pattern")
+		}
+		if policies[p.Name] {
+			return fmt.Errorf("policy '%s' declarado mais de uma vez", p.Name)
+		}
+		policies[p.Name] = true
+		switch p.ApplyTo {
+		case "", "all", "queues", "exchanges":
+		default:
+			return fmt.Errorf("policy '%s': apply_to inválido %s (use queues, exchanges ou all)", p.Name, p.ApplyTo)
+		}
+	}
+
+	return nil
+}
+
+// options converte o exchange declarado em opções de criação
+func (e ExchangeSpec) options() rabbitmq.CreateExchangeOptions {
+	exchangeType := e.Type
+	if exchangeType == "" {
+		exchangeType = defaultExchangeType
+	}
+	return rabbitmq.CreateExchangeOptions{
+		Name:              e.Name,
+		Type:              exchangeType,
+		Durable:           e.Durable == nil || *e.Durable,
+		AutoDelete:        e.AutoDelete,
+		Internal:          e.Internal,
+		AlternateExchange: e.AlternateExchange,
+		Arguments:         e.Arguments,
+	}
+}
+
+// queueType retorna o tipo declarado ou o padrão
+func (q QueueSpec) queueType() string {
+	if q.Type == "" {
+		return defaultQueueType
+	}
+	return q.Type
+}
+
+// durable retorna a durabilidade declarada ou o padrão
+func (q QueueSpec) durable() bool {
+	return q.Durable == nil || *q.Durable
+}
+
+// arguments retorna os argumentos esperados na fila, incluindo o DLX do
+// retry quando ele não é aplicado via policy (x-queue-type fica de fora:
+// o tipo é comparado à parte)
+func (q QueueSpec) arguments() map[string]interface{} {
+	args := make(map[string]interface{}, len(q.Arguments)+1)
+	for k, v := range q.Arguments {
+		if k != "x-queue-type" {
+			args[k] = v
+		}
+	}
+	if q.Retry != nil && !q.Retry.Policy {
+		args["x-dead-letter-exchange"] = retry.WaitExchangeName(q.Name)
+	}
+	return args
+}
+
+// setupOptions converte o retry declarado nas opções de retry.SetupRetry
+func (r RetrySpec) setupOptions(q QueueSpec) (retry.SetupOptions, error) {
+	backend := r.Backend
+	if backend == "" {
+		backend = retry.BackendTTL
+	}
+	if !retry.ValidBackend(backend) {
+		return retry.SetupOptions{}, fmt.Errorf("backend de retry inválido: %s (use ttl ou delayed)", backend)
+	}
+
+	backoff, err := retry.ParseBackoff(r.Backoff)
+	if err != nil {
+		return retry.SetupOptions{}, err
+	}
+
+	// Sem max_retries explícito, cada tier do backoff é uma tentativa
+	maxRetries := defaultMaxRetries
+	if r.MaxRetries != nil {
+		maxRetries = *r.MaxRetries
+	} else if len(backoff) > 0 {
+		maxRetries = len(backoff)
+	}
+	if maxRetries < 0 {
+		return retry.SetupOptions{}, fmt.Errorf("max_retries não pode ser negativo")
+	}
+	if maxRetries < len(backoff) {
+		return retry.SetupOptions{}, fmt.Errorf("max_retries %d é menor que os %d tiers do backoff", maxRetries, len(backoff))
+	}
+	if r.BackoffMultiplier != 0 && r.BackoffMultiplier <= 1 {
+		return retry.SetupOptions{}, fmt.Errorf("backoff_multiplier %g inválido (use um valor maior que 1)", r.BackoffMultiplier)
+	}
+
+	retryDelay := r.RetryDelay
+	if retryDelay == 0 {
+		retryDelay = defaultRetryDelay
+	}
+	dlqTTL := defaultDLQTTL
+	if r.DLQTTL != nil {
+		dlqTTL = *r.DLQTTL
+	}
+
+	return retry.SetupOptions{
+		QueueName:         q.Name,
+		QueueType:         q.queueType(),
+		MaxRetries:        maxRetries,
+		RetryDelay:        retryDelay,
+		DLQTTL:            dlqTTL,
+		Backend:           backend,
+		Backoff:           backoff,
+		BackoffMultiplier: r.BackoffMultiplier,
+		MaxRetryDelay:     r.MaxRetryDelay,
+	}, nil
+}
+
+// options converte o binding declarado em opções de binding
+func (b BindingSpec) options() rabbitmq.BindingOptions {
+	destinationType := b.DestinationType
+	if destinationType == "" {
+		destinationType = rabbitmq.DestinationQueue
+	}
+	arguments := b.Arguments
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+	return rabbitmq.BindingOptions{
+		Source:          b.Source,
+		Destination:     b.Destination,
+		DestinationType: destinationType,
+		RoutingKey:      b.RoutingKey,
+		Arguments:       arguments,
+	}
+}
+
+// policy converte o policy declarado para a Management API
+func (p PolicySpec) policy() rabbitmq.Policy {
+	applyTo := p.ApplyTo
+	if applyTo == "" {
+		applyTo = "all"
+	}
+	return rabbitmq.Policy{
+		Name:       p.Name,
+		Pattern:    p.Pattern,
+		ApplyTo:    applyTo,
+		Priority:   p.Priority,
+		Definition: p.Definition,
+	}
+}
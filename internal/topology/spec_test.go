@@ -0,0 +1,102 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/davioliveeira/gohop/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleSpec = `
+vhost: /prod
+exchanges:
+  - name: orders.events
+    type: topic
+    alternate_exchange: orders.unrouted
+queues:
+  - name: orders
+    arguments:
+      x-max-length: 1000
+    retry:
+      backoff: 5s,30s,5m
+  - name: audit
+    type: classic
+    durable: false
+bindings:
+  - source: orders.events
+    destination: orders
+    routing_key: orders.*
+policies:
+  - name: orders-limit
+    pattern: ^orders$
+    apply_to: queues
+    definition:
+      max-length: 100000
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(sampleSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/prod", spec.VHost)
+	require.Len(t, spec.Exchanges, 1)
+	assert.Equal(t, "topic", spec.Exchanges[0].options().Type)
+	assert.True(t, spec.Exchanges[0].options().Durable)
+
+	require.Len(t, spec.Queues, 2)
+	orders := spec.Queues[0]
+	assert.Equal(t, "quorum", orders.queueType())
+	assert.True(t, orders.durable())
+	assert.Equal(t, map[string]interface{}{
+		"x-max-length":           1000,
+		"x-dead-letter-exchange": retry.WaitExchangeName("orders"),
+	}, orders.arguments())
+	assert.False(t, spec.Queues[1].durable())
+
+	opts, err := orders.Retry.setupOptions(orders)
+	require.NoError(t, err)
+	assert.Equal(t, 3, opts.MaxRetries, "sem max_retries, um por tier do backoff")
+	assert.Equal(t, []int{5, 30, 300}, opts.TierDelays())
+	assert.Equal(t, retry.BackendTTL, opts.Backend)
+	assert.Equal(t, defaultDLQTTL, opts.DLQTTL)
+
+	binding := spec.Bindings[0].options()
+	assert.Equal(t, "queue", binding.DestinationType)
+	assert.Equal(t, "queues", spec.Policies[0].policy().ApplyTo)
+}
+
+func TestParseSpec_Empty(t *testing.T) {
+	spec, err := ParseSpec([]byte(""))
+	require.NoError(t, err)
+	assert.Empty(t, spec.Queues)
+}
+
+func TestParseSpec_PolicyRetryHasNoDLXArgument(t *testing.T) {
+	spec, err := ParseSpec([]byte("queues:\n  - name: orders\n    retry:\n      policy: true\n"))
+	require.NoError(t, err)
+	assert.Empty(t, spec.Queues[0].arguments())
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"campo desconhecido": "queues:\n  - name: orders\n    durabel: true\n",
+		"fila duplicada":     "queues:\n  - name: orders\n  - name: orders\n",
+		"tipo de fila":       "queues:\n  - name: orders\n    type: stream\n",
+		"backend":            "queues:\n  - name: orders\n    retry:\n      backend: redis\n",
+		"backoff":            "queues:\n  - name: orders\n    retry:\n      backoff: abc\n",
+		"exchange reservado": "exchanges:\n  - name: amq.custom\n",
+		"binding sem origem": "bindings:\n  - destination: orders\n",
+		"apply_to":           "policies:\n  - name: p\n    pattern: ^x$\n    apply_to: streams\n    definition: {}\n",
+		"policy sem pattern": "policies:\n  - name: p\n    definition: {}\n",
+		"exchange duplicado": "exchanges:\n  - name: orders\n  - name: orders\n",
+		"backoff truncado":   "queues:\n  - name: orders\n    retry:\n      max_retries: 2\n      backoff: 5s,30s,5m\n",
+		"multiplicador <= 1": "queues:\n  - name: orders\n    retry:\n      backoff_multiplier: 0.5\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseSpec([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
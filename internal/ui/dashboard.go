@@ -57,6 +57,8 @@ type RetryData struct {
 	DLQMsgs         int
 	MaxRetries      int
 	RetryDelay      int
+	Tiers           []retry.RetryTierInfo
+	Drift           []string
 }
 
 // Model representa o estado do dashboard
@@ -77,6 +79,9 @@ type Model struct {
 	headerAnim    HeaderAnimationModel
 	progressAnim  ProgressAnimationModel
 	cycle         float64 // Para animações de ciclo (pulsing)
+
+	// Navegador da DLQ aberto sobre o dashboard (tecla d)
+	dlqBrowser    *dlqBrowserModel
 }
 
 // tickMsg é enviado periodicamente para atualizar o dashboard
@@ -139,11 +144,35 @@ type progressUpdateMsg struct {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Com o navegador da DLQ aberto, teclas e dados da DLQ vão para ele;
+	// os ticks continuam atualizando o dashboard em segundo plano
+	if m.dlqBrowser != nil {
+		switch msg.(type) {
+		case dlqBrowserClosedMsg:
+			m.dlqBrowser = nil
+			return m, nil
+		case tea.KeyMsg, dlqLoadedMsg:
+			return m.updateDLQBrowser(msg)
+		case tea.WindowSizeMsg, spinner.TickMsg:
+			var cmd tea.Cmd
+			m, cmd = m.updateDLQBrowser(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			return m, tea.Quit
+		case "d", "D":
+			// Abrir navegador da DLQ da fila monitorada
+			browser := newDLQBrowserModel(m.queueName, m.cfg)
+			browser.embedded = true
+			browser.width = m.width
+			browser.height = m.height
+			m.dlqBrowser = &browser
+			return m, browser.Init()
 		case "r", "R":
 			// Atualizar manualmente
 			m.loading = true
@@ -232,8 +261,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateDLQBrowser repassa uma mensagem ao navegador da DLQ
+func (m Model) updateDLQBrowser(msg tea.Msg) (Model, tea.Cmd) {
+	updated, cmd := m.dlqBrowser.Update(msg)
+	browser := updated.(dlqBrowserModel)
+	m.dlqBrowser = &browser
+	return m, cmd
+}
+
 // View renderiza a interface
 func (m Model) View() string {
+	if m.dlqBrowser != nil {
+		return m.dlqBrowser.View()
+	}
+
 	if m.width == 0 {
 		return "Carregando..."
 	}
@@ -494,8 +535,24 @@ func (m Model) renderRetryBoxNew() string {
 	if data.WaitQueueMsgs > 0 {
 		waitColor = WarningColor
 	}
-	lines = append(lines, lipgloss.NewStyle().Foreground(waitColor).PaddingLeft(2).Render(
-		fmt.Sprintf("└─ %d msgs aguardando retry", data.WaitQueueMsgs)))
+	if len(data.Tiers) > 1 {
+		// Um tier por tentativa: mostrar onde as mensagens estão aguardando
+		for i, tier := range data.Tiers {
+			prefix := "├─"
+			if i == len(data.Tiers)-1 {
+				prefix = "└─"
+			}
+			tierColor := MutedColor
+			if tier.Messages > 0 {
+				tierColor = WarningColor
+			}
+			lines = append(lines, lipgloss.NewStyle().Foreground(tierColor).PaddingLeft(2).Render(
+				fmt.Sprintf("%s #%d %-6s %d msgs", prefix, tier.Attempt, retry.FormatDelay(tier.Delay), tier.Messages)))
+		}
+	} else {
+		lines = append(lines, lipgloss.NewStyle().Foreground(waitColor).PaddingLeft(2).Render(
+			fmt.Sprintf("└─ %d msgs aguardando retry", data.WaitQueueMsgs)))
+	}
 
 	lines = append(lines, "")
 
@@ -523,9 +580,13 @@ func (m Model) renderRetryBoxNew() string {
 		labelStyle.Render("Max Retries"),
 		lipgloss.NewStyle().Foreground(InfoColor).Bold(true).Render(fmt.Sprintf("%d", data.MaxRetries))))
 
+	retryDelay := fmt.Sprintf("%ds", data.RetryDelay)
+	if n := len(data.Tiers); n > 1 && data.Tiers[0].Delay != data.Tiers[n-1].Delay {
+		retryDelay = fmt.Sprintf("%s → %s", retry.FormatDelay(data.Tiers[0].Delay), retry.FormatDelay(data.Tiers[n-1].Delay))
+	}
 	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left,
 		labelStyle.Render("Retry Delay"),
-		lipgloss.NewStyle().Foreground(InfoColor).Bold(true).Render(fmt.Sprintf("%ds", data.RetryDelay))))
+		lipgloss.NewStyle().Foreground(InfoColor).Bold(true).Render(retryDelay)))
 
 	// Status geral
 	lines = append(lines, "")
@@ -534,6 +595,10 @@ func (m Model) renderRetryBoxNew() string {
 			Foreground(ErrorColor).
 			Bold(true).
 			Render("⚠ ATENÇÃO: Muitas falhas na DLQ!"))
+	} else if len(data.Drift) > 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(WarningColor).
+			Render(fmt.Sprintf("⚠ %d divergência(s) na configuração", len(data.Drift))))
 	} else if data.WaitQueueMsgs > 0 {
 		lines = append(lines, lipgloss.NewStyle().
 			Foreground(WarningColor).
@@ -602,6 +667,7 @@ func (m Model) renderFooter() string {
 
 	keys := []string{
 		keyStyle.Render("r") + descStyle.Render(" atualizar"),
+		keyStyle.Render("d") + descStyle.Render(" explorar DLQ"),
 		keyStyle.Render("q") + descStyle.Render(" sair"),
 	}
 
@@ -833,6 +899,8 @@ func (m Model) fetchData() tea.Msg {
 			DLQMsgs:         retryInfo.DLQMsgs,
 			MaxRetries:      retryInfo.MaxRetries,
 			RetryDelay:      retryInfo.RetryDelay,
+			Tiers:           retryInfo.Tiers,
+			Drift:           retryInfo.Drift,
 		}
 	}
 
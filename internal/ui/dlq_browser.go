@@ -0,0 +1,456 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MODELO DO NAVEGADOR DE DLQ
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// dlqPeekLimit é o máximo de mensagens lidas da DLQ a cada carga
+const dlqPeekLimit = 200
+
+// dlqBodyPreview é o tamanho máximo do preview do body
+const dlqBodyPreview = 400
+
+type dlqBrowserModel struct {
+	queueName string
+	cfg       *config.Config
+	messages  []retry.DeadMessage
+	cursor    int
+	offset    int
+	loading   bool
+	err       error
+	quitting  bool
+	embedded  bool // Aberto a partir do dashboard: q/esc volta em vez de sair
+	width     int
+	height    int
+	spinner   spinner.Model
+}
+
+// Mensagens
+type dlqLoadedMsg struct {
+	messages []retry.DeadMessage
+	err      error
+}
+
+// dlqBrowserClosedMsg avisa o dashboard que o navegador foi fechado
+type dlqBrowserClosedMsg struct{}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CONSTRUTOR
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func newDLQBrowserModel(queueName string, cfg *config.Config) dlqBrowserModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(PrimaryColor)
+
+	return dlqBrowserModel{
+		queueName: queueName,
+		cfg:       cfg,
+		loading:   true,
+		spinner:   s,
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BUBBLE TEA INTERFACE
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (m dlqBrowserModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadMessages)
+}
+
+func (m dlqBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "q", "esc":
+			if m.embedded {
+				return m, func() tea.Msg { return dlqBrowserClosedMsg{} }
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				if m.cursor < m.offset {
+					m.offset = m.cursor
+				}
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.messages)-1 {
+				m.cursor++
+				maxVisible := m.getMaxVisibleRows()
+				if m.cursor >= m.offset+maxVisible {
+					m.offset = m.cursor - maxVisible + 1
+				}
+			}
+
+		case "home", "g":
+			m.cursor = 0
+			m.offset = 0
+
+		case "end", "G":
+			if len(m.messages) > 0 {
+				m.cursor = len(m.messages) - 1
+				maxVisible := m.getMaxVisibleRows()
+				if m.cursor >= maxVisible {
+					m.offset = m.cursor - maxVisible + 1
+				}
+			}
+
+		case "r", "R":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadMessages)
+		}
+
+	case dlqLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.messages = msg.messages
+		if m.cursor >= len(m.messages) {
+			m.cursor = 0
+			m.offset = 0
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m dlqBrowserModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.renderMessageBox(
+			fmt.Sprintf("\n%s Lendo mensagens da DLQ...\n", m.spinner.View()), PrimaryColor, InfoColor)))
+	case m.err != nil:
+		b.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.renderMessageBox(
+			fmt.Sprintf("\n❌ %s\n", m.err), ErrorColor, ErrorColor)))
+	case len(m.messages) == 0:
+		b.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.renderMessageBox(
+			"\n📭 DLQ vazia\n\nNenhuma mensagem morta para esta fila\n", MutedColor, MutedColor)))
+	default:
+		b.WriteString(m.renderList())
+		b.WriteString("\n")
+		b.WriteString(m.renderDetails(m.messages[m.cursor]))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// RENDERIZAÇÃO
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (m dlqBrowserModel) renderHeader() string {
+	logo := lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Render("🐰 GoHop")
+	title := lipgloss.NewStyle().Foreground(TextPrimary).Bold(true).Render(" DLQ Browser ")
+	queue := lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(retry.DLQName(m.queueName))
+
+	count := ""
+	if !m.loading && m.err == nil {
+		label := fmt.Sprintf(" (%d mensagens)", len(m.messages))
+		if len(m.messages) >= dlqPeekLimit {
+			label = fmt.Sprintf(" (primeiras %d mensagens)", dlqPeekLimit)
+		}
+		count = lipgloss.NewStyle().Foreground(MutedColor).Render(label)
+	}
+
+	separator := lipgloss.NewStyle().Foreground(MutedColorDark).Render(strings.Repeat("━", 90))
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		"",
+		logo+title+queue+count,
+		separator,
+	)
+}
+
+func (m dlqBrowserModel) renderMessageBox(content string, border, fg lipgloss.Color) string {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 4).
+		Width(60).
+		Align(lipgloss.Center).
+		Foreground(fg).
+		Render(content)
+}
+
+func (m dlqBrowserModel) renderList() string {
+	headerStyle := lipgloss.NewStyle().Foreground(AccentColor).Bold(true)
+	header := lipgloss.JoinHorizontal(lipgloss.Left,
+		headerStyle.Width(6).Render("#"),
+		headerStyle.Width(28).Render("MESSAGE ID"),
+		headerStyle.Width(22).Render("FILA DE ORIGEM"),
+		headerStyle.Width(12).Render("MOTIVO"),
+		headerStyle.Width(8).Align(lipgloss.Right).Render("MORTES"),
+		headerStyle.Width(22).Align(lipgloss.Right).Render("ÚLTIMA MORTE"),
+	)
+
+	rows := []string{
+		header,
+		lipgloss.NewStyle().Foreground(MutedColorDark).Render(strings.Repeat("─", 98)),
+	}
+
+	maxVisible := m.getMaxVisibleRows()
+	endIdx := m.offset + maxVisible
+	if endIdx > len(m.messages) {
+		endIdx = len(m.messages)
+	}
+
+	for i := m.offset; i < endIdx; i++ {
+		rows = append(rows, m.renderRow(i, m.messages[i], i == m.cursor))
+	}
+
+	if len(m.messages) > maxVisible {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(MutedColor).Width(98).Align(lipgloss.Center).
+			Render(fmt.Sprintf("%d/%d", m.cursor+1, len(m.messages))))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(SecondaryColor).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func (m dlqBrowserModel) renderRow(idx int, msg retry.DeadMessage, selected bool) string {
+	base := lipgloss.NewStyle().Foreground(TextPrimary)
+	if selected {
+		base = base.Background(lipgloss.Color("#2C323C")).Bold(true)
+	}
+
+	messageID := msg.MessageId
+	if messageID == "" {
+		messageID = "-"
+	}
+
+	originQueue, reason := msg.FirstDeathQueue, msg.FirstDeathReason
+	if len(msg.Deaths) > 0 {
+		originQueue, reason = msg.Deaths[0].Queue, msg.Deaths[0].Reason
+	}
+
+	idStyle := base.Copy()
+	if selected {
+		idStyle = idStyle.Foreground(PrimaryColor)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Left,
+		base.Copy().Width(6).Foreground(MutedColor).Render(fmt.Sprintf("%d", idx+1)),
+		idStyle.Width(28).Render(truncateString(messageID, 26)),
+		base.Copy().Width(22).Render(truncateString(valueOrDash(originQueue), 20)),
+		base.Copy().Width(12).Foreground(deathReasonColor(reason)).Render(valueOrDash(reason)),
+		base.Copy().Width(8).Align(lipgloss.Right).Foreground(WarningColor).Render(fmt.Sprintf("%d", msg.TotalDeaths())),
+		base.Copy().Width(22).Align(lipgloss.Right).Foreground(MutedColor).Render(formatDeathTime(msg.LastDeath)),
+	)
+}
+
+func (m dlqBrowserModel) renderDetails(msg retry.DeadMessage) string {
+	titleStyle := lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true)
+	label := lipgloss.NewStyle().Foreground(MutedColor).Width(22)
+	value := lipgloss.NewStyle().Foreground(TextPrimary)
+
+	kv := func(k, v string) string {
+		return lipgloss.JoinHorizontal(lipgloss.Left, label.Render(k), value.Render(v))
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("☠ DEAD LETTER"))
+	lines = append(lines, kv("Primeira morte:", fmt.Sprintf("%s (%s) via '%s'",
+		valueOrDash(msg.FirstDeathQueue), valueOrDash(msg.FirstDeathReason), msg.FirstDeathExchange)))
+	lines = append(lines, kv("Entre:", fmt.Sprintf("%s → %s", formatDeathTime(msg.FirstDeath), formatDeathTime(msg.LastDeath))))
+
+	for _, death := range msg.Deaths {
+		lines = append(lines, kv(fmt.Sprintf("  %s:", truncateString(death.Queue, 18)), fmt.Sprintf("%s ×%d  %s  rk=%s",
+			lipgloss.NewStyle().Foreground(deathReasonColor(death.Reason)).Render(death.Reason),
+			death.Count,
+			formatDeathTime(death.Time),
+			strings.Join(death.RoutingKeys, ","))))
+	}
+
+	if keys := msg.SortedExceptionHeaders(); len(keys) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, titleStyle.Copy().Foreground(ErrorColor).Render("⚠ ERRO DA APLICAÇÃO"))
+		for _, key := range keys {
+			lines = append(lines, kv(truncateString(key, 20)+":", truncateString(fmt.Sprint(msg.ExceptionHeaders[key]), 70)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("📄 BODY (%d bytes, %s)", len(msg.Body), valueOrDash(msg.ContentType))))
+	lines = append(lines, lipgloss.NewStyle().Foreground(TextSecondary).Width(94).Render(bodyPreview(msg.Body, dlqBodyPreview)))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(AccentColor).
+		Padding(0, 1).
+		Width(100).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m dlqBrowserModel) renderFooter() string {
+	keyStyle := lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(MutedColor)
+	sepStyle := lipgloss.NewStyle().Foreground(MutedColorDark)
+
+	quitLabel := " sair"
+	if m.embedded {
+		quitLabel = " voltar"
+	}
+
+	shortcuts := []string{
+		keyStyle.Render("↑↓") + descStyle.Render(" navegar"),
+		keyStyle.Render("r") + descStyle.Render(" atualizar"),
+		keyStyle.Render("q") + descStyle.Render(quitLabel),
+	}
+
+	hint := descStyle.Render("As mensagens são apenas lidas e permanecem na DLQ")
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Align(lipgloss.Center).
+		Padding(1, 0).
+		Render(lipgloss.JoinVertical(lipgloss.Center, strings.Join(shortcuts, sepStyle.Render("  │  ")), hint))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HELPERS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (m dlqBrowserModel) getMaxVisibleRows() int {
+	// Metade da tela para a lista; o restante é do painel de detalhes
+	available := m.height/2 - 6
+	if available < 3 {
+		return 3
+	}
+	if available > 15 {
+		return 15
+	}
+	return available
+}
+
+func (m dlqBrowserModel) loadMessages() tea.Msg {
+	client, err := rabbitmq.NewClient(m.cfg.RabbitMQ)
+	if err != nil {
+		return dlqLoadedMsg{err: fmt.Errorf("erro ao conectar: %w", err)}
+	}
+	defer client.Close()
+
+	messages, err := retry.PeekDLQ(client, m.queueName, dlqPeekLimit)
+	return dlqLoadedMsg{messages: messages, err: err}
+}
+
+func deathReasonColor(reason string) lipgloss.Color {
+	switch reason {
+	case "rejected":
+		return ErrorColor
+	case "expired":
+		return WarningColor
+	case "maxlen", "delivery_limit":
+		return AccentColor
+	}
+	return MutedColor
+}
+
+func formatDeathTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// bodyPreview retorna o início do body em uma linha, com bytes binários substituídos
+func bodyPreview(body []byte, max int) string {
+	if len(body) == 0 {
+		return "(vazio)"
+	}
+
+	truncated := len(body) > max
+	if truncated {
+		body = body[:max]
+	}
+
+	var b strings.Builder
+	for len(body) > 0 {
+		r, size := utf8.DecodeRune(body)
+		body = body[size:]
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			b.WriteRune('·')
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteRune(' ')
+		case r < 0x20:
+			b.WriteRune('·')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if truncated {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EXECUÇÃO
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// RunDLQBrowser abre o navegador da DLQ de uma fila
+func RunDLQBrowser(queueName string, cfg *config.Config) error {
+	if !isTerminal() {
+		return fmt.Errorf("terminal não interativo")
+	}
+
+	model := newDLQBrowserModel(queueName, cfg)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	_, err := p.Run()
+	return err
+}
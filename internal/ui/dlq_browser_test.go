@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyPreview(t *testing.T) {
+	assert.Equal(t, "(vazio)", bodyPreview(nil, 10))
+	assert.Equal(t, "a b", bodyPreview([]byte("a\nb"), 10))
+	assert.Equal(t, "abc…", bodyPreview([]byte("abcdef"), 3))
+	assert.Equal(t, "a·b", bodyPreview([]byte{'a', 0xff, 'b'}, 10))
+}
+
+func TestDLQBrowser_Navigation(t *testing.T) {
+	model := newDLQBrowserModel("orders", &config.Config{})
+
+	updated, _ := model.Update(dlqLoadedMsg{messages: []retry.DeadMessage{{MessageId: "1"}, {MessageId: "2"}}})
+	model = updated.(dlqBrowserModel)
+	assert.False(t, model.loading)
+	require.Len(t, model.messages, 2)
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(dlqBrowserModel)
+	assert.Equal(t, 1, model.cursor)
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(dlqBrowserModel)
+	assert.Equal(t, 1, model.cursor, "cursor não passa da última mensagem")
+}
+
+func TestDashboard_OpensAndClosesDLQBrowser(t *testing.T) {
+	model := NewDashboard("orders", &config.Config{}, time.Second)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	model = updated.(Model)
+	require.NotNil(t, model.dlqBrowser)
+	assert.True(t, model.dlqBrowser.embedded)
+	assert.NotNil(t, cmd)
+
+	// q no navegador volta ao dashboard em vez de encerrar o programa
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	closed := cmd()
+	assert.IsType(t, dlqBrowserClosedMsg{}, closed)
+
+	updated, _ = model.Update(closed)
+	model = updated.(Model)
+	assert.Nil(t, model.dlqBrowser)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+)
+
+// RunExchangeCreateForm executa o formulário de criação de exchange e
+// retorna as opções escolhidas (após a confirmação do usuário)
+func RunExchangeCreateForm(cfg *config.Config) (*rabbitmq.CreateExchangeOptions, error) {
+	fmt.Print(renderFormHeader("🔀", "Criar Exchange", "Configure o tipo e as propriedades do exchange"))
+
+	var (
+		name              string
+		exchangeType      = "direct"
+		durable           = true
+		autoDelete        bool
+		internal          bool
+		alternateExchange string
+		argumentsText     string
+		confirm           bool
+	)
+
+	// Exchanges existentes como opções de alternate-exchange
+	alternateOptions := []huh.Option[string]{huh.NewOption("Nenhum", "")}
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	if exchanges, err := mgmtClient.ListExchanges(cfg.RabbitMQ.VHost); err == nil {
+		for _, ex := range exchanges {
+			if rabbitmq.IsBuiltinExchange(ex.Name) {
+				continue
+			}
+			alternateOptions = append(alternateOptions, huh.NewOption(fmt.Sprintf("%s (%s)", ex.Name, ex.Type), ex.Name))
+		}
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Nome do Exchange").
+				Description("Identificador único (sem espaços)").
+				Value(&name).
+				Placeholder("orders.events").
+				Validate(func(s string) error {
+					if s == "" {
+						return fmt.Errorf("obrigatório")
+					}
+					if strings.Contains(s, " ") {
+						return fmt.Errorf("sem espaços")
+					}
+					if rabbitmq.IsBuiltinExchange(s) {
+						return fmt.Errorf("o prefixo amq. é reservado")
+					}
+					return nil
+				}),
+
+			huh.NewSelect[string]().
+				Title("Tipo").
+				Description("Como as mensagens são roteadas para os bindings").
+				Options(
+					huh.NewOption("direct - routing key exata", "direct"),
+					huh.NewOption("topic - padrões com * e #", "topic"),
+					huh.NewOption("fanout - todos os bindings", "fanout"),
+					huh.NewOption("headers - por headers da mensagem", "headers"),
+				).
+				Value(&exchangeType),
+		),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("💾 Exchange Durável").
+				Description("Sobrevive a restarts do servidor").
+				Affirmative("Sim, durável").
+				Negative("Não, temporário").
+				Value(&durable),
+
+			huh.NewConfirm().
+				Title("🗑️  Auto-deletar").
+				Description("Remove automaticamente quando o último binding é removido").
+				Affirmative("Sim").
+				Negative("Não").
+				Value(&autoDelete),
+
+			huh.NewConfirm().
+				Title("🔒 Interno").
+				Description("Não aceita publicações de clientes, só de outros exchanges").
+				Affirmative("Sim").
+				Negative("Não").
+				Value(&internal),
+		),
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("↪️  Alternate Exchange").
+				Description("Recebe as mensagens que não casam com nenhum binding").
+				Options(alternateOptions...).
+				Value(&alternateExchange),
+
+			huh.NewText().
+				Title("⚙️  Argumentos").
+				Description("Opcional: um chave=valor por linha").
+				Value(&argumentsText).
+				Validate(func(s string) error {
+					_, err := rabbitmq.ParseArguments(argumentLines(s))
+					return err
+				}),
+		),
+	)
+	form.WithTheme(getCustomTheme())
+
+	if err := form.Run(); err != nil {
+		return nil, fmt.Errorf("cancelado")
+	}
+
+	arguments, err := rabbitmq.ParseArguments(argumentLines(argumentsText))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("%s (%s) │ durável: %s │ auto-delete: %s │ interno: %s",
+		name, exchangeType, boolToStr(durable), boolToStr(autoDelete), boolToStr(internal))
+	if alternateExchange != "" {
+		summary += fmt.Sprintf(" │ alternate: %s", alternateExchange)
+	}
+
+	confirmForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("✅ Confirmar Criação").
+				Description(summary).
+				Affirmative("Criar Exchange").
+				Negative("Cancelar").
+				Value(&confirm),
+		),
+	)
+	confirmForm.WithTheme(getCustomTheme())
+
+	if err := confirmForm.Run(); err != nil {
+		return nil, fmt.Errorf("cancelado")
+	}
+	if !confirm {
+		return nil, fmt.Errorf("operação cancelada")
+	}
+
+	return &rabbitmq.CreateExchangeOptions{
+		Name:              name,
+		Type:              exchangeType,
+		Durable:           durable,
+		AutoDelete:        autoDelete,
+		Internal:          internal,
+		AlternateExchange: alternateExchange,
+		Arguments:         arguments,
+	}, nil
+}
+
+// argumentLines separa o texto do formulário em linhas chave=valor não vazias
+func argumentLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -184,6 +184,13 @@ func NewMainMenu(cfg *config.Config) mainMenuModel {
 			command:     "queue purge",
 			color:       menuYellow,
 		},
+		{
+			icon:        "🔀",
+			title:       "Criar Exchange",
+			description: "Criar exchange com tipo e alternate-exchange",
+			command:     "exchange create",
+			color:       menuBlue,
+		},
 		{
 			icon:        "📊",
 			title:       "Monitorar Fila",
@@ -191,6 +198,13 @@ func NewMainMenu(cfg *config.Config) mainMenuModel {
 			command:     "monitor",
 			color:       menuOrange,
 		},
+		{
+			icon:        "☠",
+			title:       "Explorar DLQ",
+			description: "Ver mensagens mortas e o motivo",
+			command:     "dlq browse",
+			color:       menuPurple,
+		},
 		{
 			icon:        "✕",
 			title:       "Sair",
@@ -561,6 +575,13 @@ func executeSelectedCommand(cfg *config.Config, selectedCmd string) error {
 			return RunMultiDashboard(selectedQueues, cfg, 20*time.Second)
 		}
 
+	case "dlq browse":
+		selectedQueue, err := RunDLQSelectForm(cfg)
+		if err != nil {
+			return fmt.Errorf("erro ao selecionar fila: %w", err)
+		}
+		return RunDLQBrowser(selectedQueue, cfg)
+
 	default:
 		args := strings.Fields(selectedCmd)
 		return executeCommand(args...)
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -486,11 +487,16 @@ func renderSummary(name, qType string, durable, autoDelete, withRetry bool, maxR
 
 		lines = append(lines, "")
 		lines = append(lines, lipgloss.NewStyle().Foreground(MutedColor).Italic(true).Render("  Componentes que serão criados:"))
+		waitQueues := retry.WaitQueueName(name, 1)
+		if n, err := strconv.Atoi(maxRetries); err == nil && n > 1 {
+			waitQueues = fmt.Sprintf("%s … %s", waitQueues, retry.WaitQueueName(name, n))
+		}
 		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s (fila principal)", name)))
-		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s.wait (delay queue)", name)))
-		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s.dlq (dead letter)", name)))
-		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s.wait.exchange", name)))
-		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s.retry.exchange", name)))
+		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s (delay queues)", waitQueues)))
+		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s (dead letter)", retry.DLQName(name))))
+		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s", retry.WaitExchangeName(name))))
+		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s", retry.FirstAttemptExchangeName(name))))
+		lines = append(lines, lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("    • %s", retry.RetryExchangeName(name))))
 	} else {
 		lines = append(lines, lipgloss.NewStyle().Foreground(MutedColor).Italic(true).Render("Sistema de retry: desabilitado"))
 	}
@@ -536,15 +542,16 @@ func CreateQueueFromForm(cfg *config.Config, result *QueueCreateFormResult) erro
 	tasks := []creationTask{
 		{name: "Verificar existência", status: "pending"},
 		{name: "Conectar ao RabbitMQ", status: "pending"},
-		{name: "Criar fila principal", status: "pending"},
 	}
 
 	if result.WithRetry {
-		tasks = append(tasks, creationTask{name: "Criar Wait Exchange", status: "pending"})
-		tasks = append(tasks, creationTask{name: "Criar Wait Queue", status: "pending"})
-		tasks = append(tasks, creationTask{name: "Criar Retry Exchange", status: "pending"})
 		tasks = append(tasks, creationTask{name: "Criar Dead Letter Queue", status: "pending"})
-		tasks = append(tasks, creationTask{name: "Configurar DLX na fila", status: "pending"})
+		tasks = append(tasks, creationTask{name: "Criar Retry Exchange", status: "pending"})
+		tasks = append(tasks, creationTask{name: "Criar Wait Exchange", status: "pending"})
+		tasks = append(tasks, creationTask{name: fmt.Sprintf("Criar %d Wait Queue(s)", result.MaxRetries), status: "pending"})
+		tasks = append(tasks, creationTask{name: "Criar fila principal com DLX", status: "pending"})
+	} else {
+		tasks = append(tasks, creationTask{name: "Criar fila principal", status: "pending"})
 	}
 
 	renderTasks(tasks)
@@ -629,34 +636,37 @@ func CreateQueueFromForm(cfg *config.Config, result *QueueCreateFormResult) erro
 	animateDelay()
 
 	// ═══════════════════════════════════════════════════════════════════════
-	// TASK 3: Criar fila
+	// TASK 3: Criar fila simples
 	// ═══════════════════════════════════════════════════════════════════════
-	tasks[2].status = "running"
-	renderTasks(tasks)
+	if !result.WithRetry {
+		tasks[2].status = "running"
+		renderTasks(tasks)
 
-	opts := rabbitmq.CreateQueueOptions{
-		Name:       result.QueueName,
-		Type:       result.QueueTypeSel,
-		Durable:    result.Durable,
-		AutoDelete: result.AutoDelete,
-		Exclusive:  false,
-		NoWait:     false,
-		Arguments:  make(map[string]interface{}),
-	}
+		opts := rabbitmq.CreateQueueOptions{
+			Name:       result.QueueName,
+			Type:       result.QueueTypeSel,
+			Durable:    result.Durable,
+			AutoDelete: result.AutoDelete,
+			Exclusive:  false,
+			NoWait:     false,
+			Arguments:  make(map[string]interface{}),
+		}
 
-	if err := client.CreateQueue(opts); err != nil {
-		tasks[2].status = "error"
-		tasks[2].message = "Falha"
+		if err := client.CreateQueue(opts); err != nil {
+			tasks[2].status = "error"
+			tasks[2].message = "Falha"
+			renderTasks(tasks)
+			return fmt.Errorf("erro ao criar fila: %w", err)
+		}
+
+		tasks[2].status = "done"
 		renderTasks(tasks)
-		return fmt.Errorf("erro ao criar fila: %w", err)
+		animateDelay()
 	}
 
-	tasks[2].status = "done"
-	renderTasks(tasks)
-	animateDelay()
-
 	// ═══════════════════════════════════════════════════════════════════════
-	// TASKS 4-8: Sistema de Retry
+	// TASKS 3-7: Sistema de Retry
+	// A fila principal é criada por último, já com DLX e bind no retry exchange
 	// ═══════════════════════════════════════════════════════════════════════
 	if result.WithRetry {
 		setupOpts := retry.SetupOptions{
@@ -669,7 +679,7 @@ func CreateQueueFromForm(cfg *config.Config, result *QueueCreateFormResult) erro
 		}
 
 		// Simular progresso das tarefas de retry
-		for i := 3; i < len(tasks)-1; i++ {
+		for i := 2; i < len(tasks)-1; i++ {
 			tasks[i].status = "running"
 			renderTasks(tasks)
 			animateDelay()
@@ -958,6 +968,57 @@ func RunSingleMonitorForm(cfg *config.Config) (string, error) {
 	return selectedQueue, nil
 }
 
+// RunDLQSelectForm seleciona uma fila com DLQ e retorna o nome da fila principal
+func RunDLQSelectForm(cfg *config.Config) (string, error) {
+	fmt.Print(renderFormHeader("☠", "Explorar DLQ", "Selecione a DLQ para ver as mensagens mortas"))
+
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	queues, err := mgmtClient.ListQueues()
+	if err != nil {
+		return "", fmt.Errorf("erro ao listar filas: %w", err)
+	}
+
+	var queueOptions []huh.Option[string]
+	for _, queue := range queues {
+		if !strings.HasSuffix(queue.Name, ".dlq") {
+			continue
+		}
+
+		label := fmt.Sprintf("%-30s │ %5d msgs",
+			truncateString(queue.Name, 30),
+			queue.Messages)
+		queueOptions = append(queueOptions, huh.NewOption(label, strings.TrimSuffix(queue.Name, ".dlq")))
+	}
+
+	if len(queueOptions) == 0 {
+		return "", fmt.Errorf("nenhuma DLQ encontrada no RabbitMQ")
+	}
+
+	var selectedQueue string
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("☠ Selecionar DLQ").
+				Description("Escolha a DLQ para explorar").
+				Options(queueOptions...).
+				Value(&selectedQueue),
+		),
+	)
+
+	form.WithTheme(getCustomTheme())
+
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("formulário cancelado: %w", err)
+	}
+
+	if selectedQueue == "" {
+		return "", fmt.Errorf("nenhuma fila selecionada")
+	}
+
+	return selectedQueue, nil
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // RECONFIGURAR FILA COM RETRY
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -991,9 +1052,7 @@ func RunReconfigureQueueForm(cfg *config.Config) (*ReconfigureQueueResult, error
 	var mainQueues []rabbitmq.QueueInfoManagement
 	for _, q := range queues {
 		// Ignorar filas de sistema de retry
-		if strings.HasSuffix(q.Name, ".wait") ||
-			strings.HasSuffix(q.Name, ".dlq") ||
-			strings.Contains(q.Name, ".retry") {
+		if retry.IsRetryQueue(q.Name) || strings.Contains(q.Name, ".retry") {
 			continue
 		}
 		mainQueues = append(mainQueues, q)
@@ -1141,7 +1200,7 @@ func RunReconfigureQueueForm(cfg *config.Config) (*ReconfigureQueueResult, error
 
 	fmt.Println()
 	fmt.Println(warningStyle.Render("⚠️  ATENÇÃO: Esta operação irá:"))
-	fmt.Println("   1. Salvar todas as mensagens da fila")
+	fmt.Println("   1. Salvar todas as mensagens da fila em disco")
 	fmt.Println("   2. Criar sistema de retry (wait queue, exchanges, DLQ)")
 	fmt.Println("   3. Deletar a fila original")
 	fmt.Println("   4. Recriar a fila com DLX configurado")
@@ -1191,7 +1250,11 @@ func RunReconfigureQueueForm(cfg *config.Config) (*ReconfigureQueueResult, error
 	}, nil
 }
 
-// ReconfigureQueueWithRetry executa a migração completa
+// ReconfigureQueueWithRetry executa a migração completa.
+//
+// As mensagens vão para um spool em disco (sincronizado antes de cada ack)
+// e cada etapa concluída é registrada em um journal; se a execução for
+// interrompida, 'gohop queue reconfigure <fila> --resume' conclui a migração.
 func ReconfigureQueueWithRetry(cfg *config.Config, result *ReconfigureQueueResult) error {
 	fmt.Println()
 
@@ -1201,133 +1264,50 @@ func ReconfigureQueueWithRetry(cfg *config.Config, result *ReconfigureQueueResul
 	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E06C75"))
 	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E5C07B")).Bold(true)
 
-	// PASSO 1: Criar cliente e verificar fila
-	fmt.Println(stepStyle.Render("━━━ PASSO 1/5: Conectando ao RabbitMQ ━━━"))
-	
-	client, err := rabbitmq.NewClient(cfg.RabbitMQ)
-	if err != nil {
-		return fmt.Errorf("erro ao conectar: %w", err)
-	}
-	defer client.Close()
-
-	fmt.Println(successStyle.Render("  ✓ Conectado com sucesso"))
-
-	// PASSO 2: Salvar mensagens
-	fmt.Println()
-	fmt.Println(stepStyle.Render("━━━ PASSO 2/5: Salvando mensagens ━━━"))
-	
-	messages, err := client.DrainQueue(result.QueueName, func(current, total int) {
-		progress := float64(current) / float64(total) * 100
-		fmt.Printf("\r  ⏳ Salvando: %d/%d (%.1f%%)", current, total, progress)
-	})
-	if err != nil {
-		return fmt.Errorf("erro ao salvar mensagens: %w", err)
-	}
-
-	if len(messages) > 0 {
-		fmt.Println()
-	}
-	fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ %s mensagens salvas em memória",
-		countStyle.Render(fmt.Sprintf("%d", len(messages))))))
-
-	// PASSO 3: Criar sistema de retry
-	fmt.Println()
-	fmt.Println(stepStyle.Render("━━━ PASSO 3/5: Criando sistema de retry ━━━"))
-
-	// Reconectar (pode ter expirado)
-	client.Close()
-	client, err = rabbitmq.NewClient(cfg.RabbitMQ)
-	if err != nil {
-		return fmt.Errorf("erro ao reconectar: %w", err)
+	vhost := cfg.RabbitMQ.VHost
+	if vhost == "" {
+		vhost = "/"
 	}
-	defer client.Close()
 
-	setupOpts := retry.SetupOptions{
+	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
+	journal, err := retry.StartReconfigure(retry.ReconfigureDir(), mgmtClient, vhost, retry.SetupOptions{
 		QueueName:  result.QueueName,
 		QueueType:  result.QueueType,
 		MaxRetries: result.MaxRetries,
 		RetryDelay: result.RetryDelay,
 		DLQTTL:     result.DLQTTL,
-		Force:      true, // Forçar recriação mesmo se já existir
-	}
-
-	if err := retry.SetupRetry(client, setupOpts); err != nil {
-		return fmt.Errorf("erro ao criar sistema de retry: %w", err)
-	}
-	fmt.Println(successStyle.Render("  ✓ Sistema de retry criado"))
-
-	// PASSO 4: Recriar fila com DLX
-	fmt.Println()
-	fmt.Println(stepStyle.Render("━━━ PASSO 4/5: Recriando fila com DLX ━━━"))
-
-	// Reconectar
-	client.Close()
-	client, err = rabbitmq.NewClient(cfg.RabbitMQ)
+	})
 	if err != nil {
-		return fmt.Errorf("erro ao reconectar: %w", err)
-	}
-	defer client.Close()
-
-	// Primeiro deletar a fila antiga (se ainda existir)
-	mgmtClient := rabbitmq.NewManagementClient(cfg.RabbitMQ)
-	vhost := cfg.RabbitMQ.VHost
-	if vhost == "" {
-		vhost = "/"
+		return err
 	}
-	_ = mgmtClient.DeleteQueueViaAPI(vhost, result.QueueName) // Ignora erro se não existir
-
-	// Recriar com DLX
-	if err := retry.RecreateQueueWithDLX(client, result.QueueName, result.QueueType); err != nil {
-		// Tentar republicar mensagens mesmo se falhar
-		fmt.Println(errorStyle.Render("  ⚠ Erro ao recriar fila: " + err.Error()))
-		fmt.Println("  Tentando criar fila simples para não perder mensagens...")
-		
-		// Reconectar e criar fila simples
-		client.Close()
-		client, err = rabbitmq.NewClient(cfg.RabbitMQ)
-		if err != nil {
-			return fmt.Errorf("CRÍTICO: Não foi possível reconectar. %d mensagens podem ter sido perdidas", len(messages))
-		}
-		defer client.Close()
 
-		opts := rabbitmq.CreateQueueOptions{
-			Name:    result.QueueName,
-			Type:    result.QueueType,
-			Durable: true,
-		}
-		if err := client.CreateQueue(opts); err != nil {
-			return fmt.Errorf("CRÍTICO: Não foi possível criar fila. %d mensagens perdidas", len(messages))
+	total := len(retry.ReconfigureSteps)
+	var current retry.ReconfigureStep
+	finishStep := func() {
+		if current != "" {
+			fmt.Println()
+			fmt.Println(successStyle.Render("  ✓ " + current.Title()))
 		}
 	}
-	fmt.Println(successStyle.Render("  ✓ Fila recriada com DLX"))
-
-	// PASSO 5: Republicar mensagens
-	fmt.Println()
-	fmt.Println(stepStyle.Render("━━━ PASSO 5/5: Republicando mensagens ━━━"))
-
-	if len(messages) == 0 {
-		fmt.Println(successStyle.Render("  ✓ Nenhuma mensagem para republicar"))
-	} else {
-		// Reconectar
-		client.Close()
-		client, err = rabbitmq.NewClient(cfg.RabbitMQ)
-		if err != nil {
-			return fmt.Errorf("CRÍTICO: Não foi possível reconectar para republicar. %d mensagens perdidas", len(messages))
-		}
-		defer client.Close()
-
-		err = client.PublishMessages(result.QueueName, messages, func(current, total int) {
-			progress := float64(current) / float64(total) * 100
-			fmt.Printf("\r  ⏳ Publicando: %d/%d (%.1f%%)", current, total, progress)
-		})
-		if err != nil {
-			return fmt.Errorf("erro ao republicar mensagens: %w", err)
-		}
 
+	err = retry.RunReconfigure(context.Background(), cfg.RabbitMQ, journal, retry.ReconfigureHooks{
+		OnStep: func(step retry.ReconfigureStep, index int) {
+			finishStep()
+			current = step
+			fmt.Println()
+			fmt.Println(stepStyle.Render(fmt.Sprintf("━━━ PASSO %d/%d: %s ━━━", index, total, step.Title())))
+		},
+		OnProgress: func(step retry.ReconfigureStep, count int) {
+			fmt.Printf("\r  ⏳ %d mensagens", count)
+		},
+	})
+	if err != nil {
 		fmt.Println()
-		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ %s mensagens republicadas",
-			countStyle.Render(fmt.Sprintf("%d", len(messages))))))
+		fmt.Println(errorStyle.Render("  ⚠ " + err.Error()))
+		fmt.Printf("  As mensagens estão salvas em %s\n", journal.SpoolPath())
+		return fmt.Errorf("reconfiguração interrompida; conclua com 'gohop queue reconfigure %s --resume' ou desfaça com --rollback: %w", result.QueueName, err)
 	}
+	finishStep()
 
 	// Resumo final
 	fmt.Println()
@@ -1336,13 +1316,16 @@ func ReconfigureQueueWithRetry(cfg *config.Config, result *ReconfigureQueueResul
 	fmt.Printf("  Fila:          %s\n", result.QueueName)
 	fmt.Printf("  Max Retries:   %d\n", result.MaxRetries)
 	fmt.Printf("  Retry Delay:   %ds\n", result.RetryDelay)
-	fmt.Printf("  Mensagens:     %d (preservadas)\n", len(messages))
+	fmt.Printf("  Mensagens:     %s (preservadas)\n", countStyle.Render(strconv.Itoa(journal.Spooled)))
 	fmt.Println()
 	fmt.Println("  Componentes criados:")
-	fmt.Printf("    • %s.wait.exchange\n", result.QueueName)
-	fmt.Printf("    • %s.wait\n", result.QueueName)
-	fmt.Printf("    • %s.retry.exchange\n", result.QueueName)
-	fmt.Printf("    • %s.dlq\n", result.QueueName)
+	fmt.Printf("    • %s\n", retry.WaitExchangeName(result.QueueName))
+	fmt.Printf("    • %s\n", retry.FirstAttemptExchangeName(result.QueueName))
+	for attempt := 1; attempt <= result.MaxRetries; attempt++ {
+		fmt.Printf("    • %s\n", retry.WaitQueueName(result.QueueName, attempt))
+	}
+	fmt.Printf("    • %s\n", retry.RetryExchangeName(result.QueueName))
+	fmt.Printf("    • %s\n", retry.DLQName(result.QueueName))
 
 	return nil
 }
@@ -0,0 +1,92 @@
+package consumer
+
+import (
+	"fmt"
+
+	"github.com/davioliveeira/gohop/internal/retryname"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Attempt retorna o número da tentativa atual de uma mensagem da fila principal
+// (1 = primeira entrega).
+//
+// Cada rejeição pela fila principal incrementa o contador da entrada
+// {queue: <fila>, reason: rejected} do header x-death. Se o header não estiver
+// disponível, usa a routing key "<fila>.attempt.N" definida pela wait queue.
+// No backend delayed o contador viaja no header x-gohop-retries.
+//
+// Retorna erro se um contador tiver um tipo que não é inteiro (ex: float64 de
+// uma cópia que perdeu os tipos dos headers): contá-lo como zero reiniciaria
+// as tentativas e o limite de retries deixaria de valer.
+func Attempt(d amqp.Delivery, queueName string) (int, error) {
+	rejected, err := DeathCount(d, queueName, "rejected")
+	if err != nil {
+		return 0, err
+	}
+	if rejected > 0 {
+		return rejected + 1, nil
+	}
+
+	retries, err := headerCount(d.Headers[retryname.HeaderRetries])
+	if err != nil {
+		return 0, fmt.Errorf("header %s: %w", retryname.HeaderRetries, err)
+	}
+	if retries > 0 {
+		return retries + 1, nil
+	}
+
+	if previous, ok := retryname.ParseAttemptRoutingKey(queueName, d.RoutingKey); ok {
+		return previous + 1, nil
+	}
+
+	return 1, nil
+}
+
+// headerCount lê um contador de header (0 se ausente)
+func headerCount(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case int16:
+		return int(v), nil
+	case int8:
+		return int(v), nil
+	case byte:
+		return int(v), nil
+	case int:
+		return v, nil
+	}
+	return 0, fmt.Errorf("contador com tipo inesperado %T (%v)", value, value)
+}
+
+// DeathCount retorna quantas vezes a mensagem foi dead-lettered pela fila com o
+// motivo informado (rejected, expired, maxlen, delivery_limit), segundo o x-death.
+// Retorna erro se o count da entrada não for inteiro (veja Attempt).
+func DeathCount(d amqp.Delivery, queueName, reason string) (int, error) {
+	deaths, ok := d.Headers["x-death"].([]interface{})
+	if !ok {
+		return 0, nil
+	}
+
+	total := 0
+	for _, entry := range deaths {
+		death, ok := entry.(amqp.Table)
+		if !ok {
+			continue
+		}
+		if death["queue"] != queueName || death["reason"] != reason {
+			continue
+		}
+		count, err := headerCount(death["count"])
+		if err != nil {
+			return 0, fmt.Errorf("x-death da fila %s: %w", queueName, err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
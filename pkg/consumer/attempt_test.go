@@ -0,0 +1,94 @@
+package consumer
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery amqp.Delivery
+		expected int
+	}{
+		{
+			name:     "first delivery",
+			delivery: amqp.Delivery{RoutingKey: "orders"},
+			expected: 1,
+		},
+		{
+			name: "x-death with rejections",
+			delivery: amqp.Delivery{
+				RoutingKey: "orders.attempt.2",
+				Headers: amqp.Table{"x-death": []interface{}{
+					amqp.Table{"queue": "orders.wait.2", "reason": "expired", "count": int64(1)},
+					amqp.Table{"queue": "orders", "reason": "rejected", "count": int64(2)},
+					amqp.Table{"queue": "orders.wait", "reason": "expired", "count": int64(1)},
+				}},
+			},
+			expected: 3,
+		},
+		{
+			name:     "delayed backend retries header",
+			delivery: amqp.Delivery{RoutingKey: "orders", Headers: amqp.Table{"x-gohop-retries": int64(2)}},
+			expected: 3,
+		},
+		{
+			name:     "routing key fallback",
+			delivery: amqp.Delivery{RoutingKey: "orders.attempt.1"},
+			expected: 2,
+		},
+		{
+			name: "other queue in x-death",
+			delivery: amqp.Delivery{Headers: amqp.Table{"x-death": []interface{}{
+				amqp.Table{"queue": "payments", "reason": "rejected", "count": int64(4)},
+			}}},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt, err := Attempt(tt.delivery, "orders")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, attempt)
+		})
+	}
+}
+
+func TestDeathCount(t *testing.T) {
+	d := amqp.Delivery{Headers: amqp.Table{"x-death": []interface{}{
+		amqp.Table{"queue": "orders.wait", "reason": "expired", "count": int64(3)},
+	}}}
+
+	count, err := DeathCount(d, "orders.wait", "expired")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = DeathCount(d, "orders.wait", "rejected")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = DeathCount(amqp.Delivery{}, "orders", "rejected")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestDeathCount_UnexpectedType(t *testing.T) {
+	// x-death lido de um JSON sem tipos: o count vira float64
+	d := amqp.Delivery{Headers: amqp.Table{"x-death": []interface{}{
+		amqp.Table{"queue": "orders", "reason": "rejected", "count": float64(2)},
+	}}}
+
+	_, err := DeathCount(d, "orders", "rejected")
+	assert.ErrorContains(t, err, "tipo inesperado float64")
+
+	_, err = Attempt(d, "orders")
+	assert.Error(t, err, "zerar a contagem desligaria o limite de retries")
+
+	_, err = Attempt(amqp.Delivery{Headers: amqp.Table{"x-gohop-retries": "2"}}, "orders")
+	assert.ErrorContains(t, err, "x-gohop-retries")
+}
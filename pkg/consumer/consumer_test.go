@@ -0,0 +1,250 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAcknowledger registra o que foi feito com a delivery
+type fakeAcknowledger struct {
+	acked    bool
+	rejected bool
+	requeue  bool
+}
+
+func (f *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	f.acked = true
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	f.rejected = true
+	f.requeue = requeue
+	return nil
+}
+
+func (f *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	f.rejected = true
+	f.requeue = requeue
+	return nil
+}
+
+func newTestConsumer(handler Handler) (*Consumer, *[]amqp.Publishing) {
+	published := &[]amqp.Publishing{}
+	c := &Consumer{
+		opts:    Options{Queue: "orders"},
+		handler: handler,
+		publishDLQ: func(ctx context.Context, msg amqp.Publishing) error {
+			*published = append(*published, msg)
+			return nil
+		},
+	}
+	return c, published
+}
+
+func TestConsumer_AckOnSuccess(t *testing.T) {
+	c, published := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return nil
+	})
+	ack := &fakeAcknowledger{}
+
+	result := c.handle(context.Background(), amqp.Delivery{Acknowledger: ack})
+
+	assert.Equal(t, Acked, result)
+	assert.True(t, ack.acked)
+	assert.False(t, ack.rejected)
+	assert.Empty(t, *published)
+}
+
+func TestConsumer_RejectOnRetryableError(t *testing.T) {
+	c, published := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return errors.New("timeout no banco")
+	})
+	ack := &fakeAcknowledger{}
+
+	result := c.handle(context.Background(), amqp.Delivery{Acknowledger: ack})
+
+	assert.Equal(t, Retried, result)
+	assert.True(t, ack.rejected)
+	assert.False(t, ack.requeue, "requeue=true devolveria a mensagem sem passar pelo wait exchange")
+	assert.Empty(t, *published)
+}
+
+func TestConsumer_PanicIsRetried(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, msg Message) error {
+		panic("boom")
+	})
+	ack := &fakeAcknowledger{}
+
+	assert.Equal(t, Retried, c.handle(context.Background(), amqp.Delivery{Acknowledger: ack}))
+	assert.True(t, ack.rejected)
+}
+
+func TestConsumer_PermanentErrorGoesToDLQ(t *testing.T) {
+	c, published := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return Permanent(errors.New("payload inválido"))
+	})
+	ack := &fakeAcknowledger{}
+
+	var gotErr error
+	c.opts.OnResult = func(msg Message, result Result, err error) {
+		gotErr = err
+	}
+
+	result := c.handle(context.Background(), amqp.Delivery{
+		Acknowledger: ack,
+		Body:         []byte(`{"id":1}`),
+		ContentType:  "application/json",
+		MessageId:    "msg-1",
+		Headers:      amqp.Table{"tenant": "acme"},
+	})
+
+	assert.Equal(t, DeadLettered, result)
+	assert.True(t, ack.acked)
+	assert.False(t, ack.rejected)
+	assert.True(t, IsPermanent(gotErr))
+
+	require.Len(t, *published, 1)
+	msg := (*published)[0]
+	assert.Equal(t, []byte(`{"id":1}`), msg.Body)
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, "msg-1", msg.MessageId)
+	assert.Equal(t, "acme", msg.Headers["tenant"])
+	assert.Equal(t, "payload inválido", msg.Headers[HeaderError])
+	assert.Equal(t, int64(1), msg.Headers[HeaderAttempt])
+}
+
+func TestConsumer_InvalidAttemptCountGoesToDLQ(t *testing.T) {
+	called := false
+	c, published := newTestConsumer(func(ctx context.Context, msg Message) error {
+		called = true
+		return nil
+	})
+	ack := &fakeAcknowledger{}
+
+	result := c.handle(context.Background(), amqp.Delivery{
+		Acknowledger: ack,
+		Headers: amqp.Table{"x-death": []interface{}{
+			amqp.Table{"queue": "orders", "reason": "rejected", "count": float64(2)},
+		}},
+	})
+
+	assert.Equal(t, DeadLettered, result)
+	assert.False(t, called, "sem a contagem de tentativas o handler não roda")
+	assert.True(t, ack.acked)
+	require.Len(t, *published, 1)
+	assert.Contains(t, (*published)[0].Headers[HeaderError], "contagem de tentativas inválida")
+}
+
+func TestConsumer_PermanentErrorFallsBackToRetry(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return Permanent(errors.New("payload inválido"))
+	})
+	c.publishDLQ = func(ctx context.Context, msg amqp.Publishing) error {
+		return errors.New("NACK")
+	}
+	ack := &fakeAcknowledger{}
+
+	// Sem confirmação da DLQ a mensagem não pode ser confirmada
+	assert.Equal(t, Retried, c.handle(context.Background(), amqp.Delivery{Acknowledger: ack}))
+	assert.False(t, ack.acked)
+	assert.True(t, ack.rejected)
+}
+
+func TestConsumer_MissingDLQRequeues(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return Permanent(errors.New("payload inválido"))
+	})
+	c.publishDLQ = func(ctx context.Context, msg amqp.Publishing) error {
+		return fmt.Errorf("%w para 'orders.dlq': NO_ROUTE", errNoRoute)
+	}
+	ack := &fakeAcknowledger{}
+
+	var gotErr error
+	c.opts.OnResult = func(msg Message, result Result, err error) {
+		gotErr = err
+	}
+
+	// Confirmada pelo broker mas sem rota: a mensagem não pode ser descartada
+	assert.Equal(t, Retried, c.handle(context.Background(), amqp.Delivery{Acknowledger: ack}))
+	assert.False(t, ack.acked)
+	assert.True(t, ack.rejected)
+	assert.True(t, ack.requeue)
+	assert.ErrorIs(t, gotErr, errNoRoute)
+}
+
+func TestConsumer_DelayedBackendRepublishes(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return errors.New("timeout no banco")
+	})
+	c.opts.Delays = []time.Duration{5 * time.Second, 30 * time.Second}
+	var scheduled []amqp.Publishing
+	c.publishRetry = func(ctx context.Context, msg amqp.Publishing) error {
+		scheduled = append(scheduled, msg)
+		return nil
+	}
+
+	ack := &fakeAcknowledger{}
+	result := c.handle(context.Background(), amqp.Delivery{
+		Acknowledger: ack,
+		Body:         []byte("payload"),
+		Headers:      amqp.Table{"x-gohop-retries": int64(1)},
+	})
+
+	assert.Equal(t, Retried, result)
+	assert.True(t, ack.acked)
+	require.Len(t, scheduled, 1)
+	assert.Equal(t, int64(30000), scheduled[0].Headers["x-delay"])
+	assert.Equal(t, int64(2), scheduled[0].Headers["x-gohop-retries"])
+	assert.Equal(t, []byte("payload"), scheduled[0].Body)
+
+	// Última tentativa esgotada: rejeita para a DLQ via DLX
+	ack = &fakeAcknowledger{}
+	result = c.handle(context.Background(), amqp.Delivery{
+		Acknowledger: ack,
+		Headers:      amqp.Table{"x-gohop-retries": int64(2)},
+	})
+
+	assert.Equal(t, Retried, result)
+	assert.True(t, ack.rejected)
+	assert.False(t, ack.requeue)
+	assert.Len(t, scheduled, 1)
+}
+
+func TestConsumer_DelayedBackendFallsBackToReject(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, msg Message) error {
+		return errors.New("timeout no banco")
+	})
+	c.opts.Delays = []time.Duration{5 * time.Second}
+	c.publishRetry = func(ctx context.Context, msg amqp.Publishing) error {
+		return errors.New("NACK")
+	}
+	ack := &fakeAcknowledger{}
+
+	assert.Equal(t, Retried, c.handle(context.Background(), amqp.Delivery{Acknowledger: ack}))
+	assert.False(t, ack.acked)
+	assert.True(t, ack.rejected)
+}
+
+func TestPermanent(t *testing.T) {
+	assert.Nil(t, Permanent(nil))
+
+	base := errors.New("falha")
+	err := Permanent(base)
+	assert.True(t, IsPermanent(err))
+	assert.True(t, errors.Is(err, base))
+	assert.False(t, IsPermanent(base))
+	assert.True(t, IsPermanent(errors.Join(errors.New("contexto"), err)))
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := New(nil, Options{Queue: "orders"}, func(ctx context.Context, msg Message) error { return nil })
+	assert.Error(t, err)
+}
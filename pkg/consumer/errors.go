@@ -0,0 +1,26 @@
+package consumer
+
+import "errors"
+
+// permanentError marca um erro que não deve ser tentado novamente
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marca o erro como permanente: a mensagem vai direto para a DLQ
+// sem passar pelas wait queues
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent indica se o erro (ou algum erro encapsulado) é permanente
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,147 @@
+//go:build integration
+// +build integration
+
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davioliveeira/gohop/internal/config"
+	"github.com/davioliveeira/gohop/internal/rabbitmq"
+	"github.com/davioliveeira/gohop/internal/retry"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumer_RetriesThenDeadLetters_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client, err := rabbitmq.NewClient(config.RabbitMQConfig{
+		Host:     "localhost",
+		Port:     5672,
+		Username: "test_user",
+		Password: "test_pass",
+		VHost:    "/",
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	queueName := "test_consumer_" + time.Now().Format("20060102150405")
+	maxRetries := 2
+
+	require.NoError(t, retry.SetupRetry(client, retry.SetupOptions{
+		QueueName:  queueName,
+		QueueType:  "classic",
+		MaxRetries: maxRetries,
+		RetryDelay: 1,
+	}))
+	require.NoError(t, retry.RecreateQueueWithDLX(client, queueName, "classic"))
+	defer func() {
+		channel := client.GetChannel()
+		channel.QueueDelete(queueName, false, false, false)
+		for attempt := 1; attempt <= maxRetries; attempt++ {
+			channel.QueueDelete(retry.WaitQueueName(queueName, attempt), false, false, false)
+		}
+		channel.QueueDelete(retry.DLQName(queueName), false, false, false)
+		channel.ExchangeDelete(retry.WaitExchangeName(queueName), false, false)
+		channel.ExchangeDelete(retry.FirstAttemptExchangeName(queueName), false, false)
+		channel.ExchangeDelete(retry.RetryExchangeName(queueName), false, false)
+	}()
+
+	var mu sync.Mutex
+	var attempts []int
+
+	c, err := New(client.GetConnection(), Options{Queue: queueName, Prefetch: 5}, func(ctx context.Context, msg Message) error {
+		mu.Lock()
+		attempts = append(attempts, msg.Attempt)
+		mu.Unlock()
+		return errors.New("sempre falha")
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go c.Run(ctx)
+
+	err = client.GetChannel().Publish("", queueName, false, false, amqp.Publishing{Body: []byte("falha")})
+	require.NoError(t, err)
+
+	// Primeira entrega + MaxRetries tentativas, depois DLQ
+	require.Eventually(t, func() bool {
+		queue, err := client.GetChannel().QueueDeclarePassive(retry.DLQName(queueName), true, false, false, false, nil)
+		return err == nil && queue.Messages == 1
+	}, 10*time.Second, 200*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+}
+
+func TestConsumer_MissingDLQRequeues_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pulando teste de integração em modo short")
+	}
+
+	client, err := rabbitmq.NewClient(config.RabbitMQConfig{
+		Host:     "localhost",
+		Port:     5672,
+		Username: "test_user",
+		Password: "test_pass",
+		VHost:    "/",
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	queueName := "test_consumer_nodlq_" + time.Now().Format("20060102150405")
+	_, err = client.GetChannel().QueueDeclare(queueName, false, true, false, false, nil)
+	require.NoError(t, err)
+	defer client.GetChannel().QueueDelete(queueName, false, false, false)
+
+	// Sem <fila>.dlq: a publicação é confirmada, mas volta com basic.return
+	results := make(chan error, 10)
+	c, err := New(client.GetConnection(), Options{
+		Queue: queueName,
+		OnResult: func(msg Message, result Result, err error) {
+			if result != Retried {
+				return
+			}
+			// A mensagem é reentregue até o contexto ser cancelado
+			select {
+			case results <- err:
+			default:
+			}
+		},
+	}, func(ctx context.Context, msg Message) error {
+		return Permanent(errors.New("payload inválido"))
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = client.GetChannel().Publish("", queueName, false, false, amqp.Publishing{Body: []byte("sem dlq")})
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	select {
+	case err := <-results:
+		assert.ErrorIs(t, err, errNoRoute)
+	case <-time.After(10 * time.Second):
+		t.Fatal("mensagem não foi processada")
+	}
+	cancel()
+	require.NoError(t, <-done)
+
+	// A mensagem volta para a fila em vez de ser perdida
+	require.Eventually(t, func() bool {
+		queue, err := client.GetChannel().QueueDeclarePassive(queueName, false, true, false, false, nil)
+		return err == nil && queue.Messages == 1
+	}, 5*time.Second, 200*time.Millisecond)
+}